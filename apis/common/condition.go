@@ -0,0 +1,59 @@
+/*
+Copyright 2022 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package common contains API types and conditions shared by the resources of
+// all supported databases.
+package common
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// TypeRestartRequired resources, such as PostgreSQL server settings and MySQL
+// global variables, are configured in a way that only takes effect once the
+// server has been restarted.
+const TypeRestartRequired xpv1.ConditionType = "RestartRequired"
+
+// Reasons a resource does or does not require a restart.
+const (
+	ReasonPendingRestart xpv1.ConditionReason = "PendingRestart"
+	ReasonApplied        xpv1.ConditionReason = "Applied"
+)
+
+// PendingRestart returns a condition that indicates the server must be
+// restarted for the resource's configuration to take effect.
+func PendingRestart() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeRestartRequired,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonPendingRestart,
+	}
+}
+
+// Applied returns a condition that indicates the resource's configuration has
+// taken effect without a restart.
+func Applied() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeRestartRequired,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonApplied,
+	}
+}
@@ -0,0 +1,182 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// An AgentJobStep is a step of a SQL Server Agent job.
+type AgentJobStep struct {
+	// Name of the step, unique within the job.
+	Name string `json:"name"`
+
+	// Subsystem that runs the command of the step. Defaults to TSQL.
+	// +kubebuilder:validation:Enum=TSQL;CmdExec;PowerShell
+	// +optional
+	Subsystem *string `json:"subsystem,omitempty"`
+
+	// Command run by the step, e.g. a Transact-SQL batch.
+	Command string `json:"command"`
+
+	// Database a TSQL command is run in. Defaults to master.
+	// +optional
+	Database *string `json:"database,omitempty"`
+}
+
+// An AgentJobScheduleRepeat repeats a job within the days it is scheduled
+// on.
+type AgentJobScheduleRepeat struct {
+	// Unit of the interval the job is repeated at.
+	// +kubebuilder:validation:Enum=Seconds;Minutes;Hours
+	Unit string `json:"unit"`
+
+	// Interval the job is repeated at, in units.
+	// +kubebuilder:validation:Minimum=1
+	Interval int `json:"interval"`
+}
+
+// An AgentJobSchedule is a schedule a SQL Server Agent job is run on.
+type AgentJobSchedule struct {
+	// Name of the schedule, unique within the job.
+	Name string `json:"name"`
+
+	// Frequency of the schedule. AgentStart schedules run the job when SQL
+	// Server Agent starts, and Idle schedules whenever the CPU is idle.
+	// +kubebuilder:validation:Enum=Daily;Weekly;Monthly;AgentStart;Idle
+	Frequency string `json:"frequency"`
+
+	// Interval of the days, weeks or months the job is run on, e.g. 2 to run
+	// a Daily job every other day. Defaults to 1.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	Interval *int `json:"interval,omitempty"`
+
+	// DaysOfWeek a Weekly job is run on.
+	// +optional
+	DaysOfWeek []Weekday `json:"daysOfWeek,omitempty"`
+
+	// DayOfMonth a Monthly job is run on. Defaults to 1.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=31
+	// +optional
+	DayOfMonth *int `json:"dayOfMonth,omitempty"`
+
+	// StartTime of the job on the days it is run, as HH:MM:SS in the time
+	// zone of the server. Defaults to 00:00:00.
+	// +kubebuilder:validation:Pattern:=`^([01][0-9]|2[0-3]):[0-5][0-9]:[0-5][0-9]$`
+	// +optional
+	StartTime *string `json:"startTime,omitempty"`
+
+	// Repeat the job within the days it is run on, starting at its start
+	// time. The job is run once a day if unset.
+	// +optional
+	Repeat *AgentJobScheduleRepeat `json:"repeat,omitempty"`
+
+	// Enabled determines whether the job is run on the schedule. Defaults
+	// to true.
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+}
+
+// A Weekday a job is run on.
+// +kubebuilder:validation:Enum=Sunday;Monday;Tuesday;Wednesday;Thursday;Friday;Saturday
+type Weekday string
+
+// AgentJobParameters are the configurable fields of an AgentJob.
+type AgentJobParameters struct {
+	// Description of the job.
+	// +optional
+	Description *string `json:"description,omitempty"`
+
+	// Enabled determines whether the job is run on its schedules. Defaults
+	// to true.
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// Steps of the job. They are run in order, until one of them fails.
+	// +kubebuilder:validation:MinItems=1
+	Steps []AgentJobStep `json:"steps"`
+
+	// Schedules the job is run on. A job without schedules is only run when
+	// started by hand.
+	// +optional
+	Schedules []AgentJobSchedule `json:"schedules,omitempty"`
+}
+
+// An AgentJobRun represents a run of a SQL Server Agent job.
+type AgentJobRun struct {
+	// Outcome of the run, e.g. Succeeded or Failed.
+	Outcome string `json:"outcome,omitempty"`
+
+	// Message logged by SQL Server Agent for the run.
+	Message string `json:"message,omitempty"`
+
+	// StartTime of the run, in the time zone of the server.
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+}
+
+// An AgentJobObservation represents the observed state of a SQL Server
+// Agent job.
+type AgentJobObservation struct {
+	// JobID assigned to the job by SQL Server Agent.
+	JobID string `json:"jobID,omitempty"`
+
+	// LastRun of the job, as recorded in msdb.dbo.sysjobhistory.
+	LastRun *AgentJobRun `json:"lastRun,omitempty"`
+}
+
+// An AgentJobSpec defines the desired state of an AgentJob.
+type AgentJobSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       AgentJobParameters `json:"forProvider"`
+}
+
+// An AgentJobStatus represents the observed state of an AgentJob.
+type AgentJobStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          AgentJobObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// An AgentJob represents the declarative state of a SQL Server Agent job.
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="ENABLED",type="boolean",JSONPath=".spec.forProvider.enabled"
+// +kubebuilder:printcolumn:name="LAST RUN",type="string",JSONPath=".status.atProvider.lastRun.outcome"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,sql}
+type AgentJob struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   AgentJobSpec   `json:"spec"`
+	Status AgentJobStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// AgentJobList contains a list of AgentJob
+type AgentJobList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []AgentJob `json:"items"`
+}
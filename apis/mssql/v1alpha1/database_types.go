@@ -22,9 +22,80 @@ import (
 	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
 )
 
+// A ForceDrop mode terminates the sessions connected to a database so that
+// it can be dropped.
+// +kubebuilder:validation:Enum=SingleUser;Offline
+type ForceDrop string
+
+// The modes of terminating the sessions connected to a database.
+const (
+	// ForceDropSingleUser sets the database to single user mode before it is
+	// dropped.
+	ForceDropSingleUser ForceDrop = "SingleUser"
+
+	// ForceDropOffline takes the database offline before it is dropped. The
+	// files of an offline database are not deleted when it is dropped.
+	ForceDropOffline ForceDrop = "Offline"
+)
+
+// DatabaseParameters are the configurable fields of a Database.
+type DatabaseParameters struct {
+	// Collation is the default collation of the database, e.g.
+	// SQL_Latin1_General_CP1_CI_AS. Defaults to the collation of the server.
+	// +kubebuilder:validation:Pattern:=`^[A-Za-z0-9_]+$`
+	// +optional
+	Collation *string `json:"collation,omitempty"`
+
+	// RecoveryModel of the database, which controls how transactions are
+	// logged and which backup and restore operations are available.
+	// +kubebuilder:validation:Enum=FULL;BULK_LOGGED;SIMPLE
+	// +optional
+	RecoveryModel *string `json:"recoveryModel,omitempty"`
+
+	// CompatibilityLevel sets the Transact-SQL and query processing
+	// behaviour of the database to be compatible with the specified version
+	// of SQL Server, e.g. 150 for SQL Server 2019.
+	// +kubebuilder:validation:Minimum=80
+	// +optional
+	CompatibilityLevel *int `json:"compatibilityLevel,omitempty"`
+
+	// Containment of the database. PARTIAL allows users to authenticate to
+	// the database without a login, and requires the contained database
+	// authentication server option.
+	// +kubebuilder:validation:Enum=NONE;PARTIAL
+	// +optional
+	Containment *string `json:"containment,omitempty"`
+
+	// ReadCommittedSnapshot makes the READ COMMITTED isolation level use row
+	// versioning instead of locks.
+	// +optional
+	ReadCommittedSnapshot *bool `json:"readCommittedSnapshot,omitempty"`
+
+	// AllowSnapshotIsolation allows transactions to use the SNAPSHOT
+	// isolation level.
+	// +optional
+	AllowSnapshotIsolation *bool `json:"allowSnapshotIsolation,omitempty"`
+
+	// AutoClose shuts the database down cleanly when the last user exits.
+	// +optional
+	AutoClose *bool `json:"autoClose,omitempty"`
+
+	// ForceDrop terminates the sessions connected to the database, rolling
+	// back their open transactions, before it is dropped. Without it, the
+	// database cannot be dropped while sessions are connected to it.
+	// +optional
+	ForceDrop *ForceDrop `json:"forceDrop,omitempty"`
+
+	// DeletionProtection prevents the database from being dropped. Deleting
+	// the Database fails until it is disabled.
+	// +optional
+	DeletionProtection *bool `json:"deletionProtection,omitempty"`
+}
+
 // A DatabaseSpec defines the desired state of a Database.
 type DatabaseSpec struct {
 	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       DatabaseParameters `json:"forProvider,omitempty"`
 }
 
 // A DatabaseStatus represents the observed state of a Database.
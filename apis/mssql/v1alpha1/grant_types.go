@@ -48,13 +48,44 @@ func (gp *GrantPermissions) ToStringSlice() []string {
 	return out
 }
 
+// GrantState is the state of the permissions of a grant.
+// +kubebuilder:validation:Enum=GRANT;GRANT_WITH_GRANT_OPTION;DENY
+type GrantState string
+
+// The states of the permissions of a grant.
+const (
+	// GrantStateGrant grants the permissions.
+	GrantStateGrant GrantState = "GRANT"
+
+	// GrantStateGrantWithGrantOption grants the permissions, and allows the
+	// grantee to grant them to others.
+	GrantStateGrantWithGrantOption GrantState = "GRANT_WITH_GRANT_OPTION"
+
+	// GrantStateDeny denies the permissions, overriding any grant of them,
+	// e.g. through a role.
+	GrantStateDeny GrantState = "DENY"
+)
+
 // GrantParameters define the desired state of a MSSQL grant instance.
 type GrantParameters struct {
 	// Permissions to be granted.
 	// See https://docs.microsoft.com/en-us/sql/t-sql/statements/grant-database-permissions-transact-sql?view=sql-server-ver15#remarks
-	// for available privileges.
+	// for available privileges. The CONNECT permission SQL Server grants on
+	// the database to every user is only managed when listed.
 	Permissions GrantPermissions `json:"permissions"`
 
+	// Securable the permissions are granted on, e.g. SCHEMA::sales or
+	// OBJECT::dbo.orders. Defaults to the database.
+	// +kubebuilder:validation:Pattern:=`^(SCHEMA|OBJECT)::.+$`
+	// +immutable
+	// +optional
+	Securable *string `json:"securable,omitempty"`
+
+	// State of the permissions. GRANT_WITH_GRANT_OPTION allows the grantee to
+	// grant them to others, and DENY denies them. Defaults to GRANT.
+	// +optional
+	State *GrantState `json:"state,omitempty"`
+
 	// User this grant is for.
 	// +optional
 	// +crossplane:generate:reference:type=User
@@ -100,6 +131,7 @@ type GrantStatus struct {
 // +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
 // +kubebuilder:printcolumn:name="ROLE",type="string",JSONPath=".spec.forProvider.user"
 // +kubebuilder:printcolumn:name="DATABASE",type="string",JSONPath=".spec.forProvider.database"
+// +kubebuilder:printcolumn:name="SECURABLE",type="string",JSONPath=".spec.forProvider.securable"
 // +kubebuilder:printcolumn:name="PERMISSIONS",type="string",JSONPath=".spec.forProvider.permissions"
 // +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,sql}
 type Grant struct {
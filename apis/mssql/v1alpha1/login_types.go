@@ -0,0 +1,113 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// A LoginSpec defines the desired state of a Login.
+type LoginSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       LoginParameters `json:"forProvider"`
+}
+
+// A LoginStatus represents the observed state of a Login.
+type LoginStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          LoginObservation `json:"atProvider,omitempty"`
+}
+
+// LoginParameters define the desired state of a MSSQL login.
+type LoginParameters struct {
+	// PasswordSecretRef references the secret that contains the password used
+	// for this login. If no reference is given, a password will be auto-generated.
+	// +optional
+	PasswordSecretRef *xpv1.SecretKeySelector `json:"passwordSecretRef,omitempty"`
+
+	// DefaultDatabase the login connects to. Defaults to master.
+	// +optional
+	// +crossplane:generate:reference:type=Database
+	DefaultDatabase *string `json:"defaultDatabase,omitempty"`
+
+	// DefaultDatabaseRef references the Database the login connects to by
+	// default.
+	// +optional
+	DefaultDatabaseRef *xpv1.Reference `json:"defaultDatabaseRef,omitempty"`
+
+	// DefaultDatabaseSelector selects a reference to a Database the login
+	// connects to by default.
+	// +optional
+	DefaultDatabaseSelector *xpv1.Selector `json:"defaultDatabaseSelector,omitempty"`
+
+	// CheckPolicy enforces the Windows password policies of the server on
+	// the login. Defaults to true.
+	// +optional
+	CheckPolicy *bool `json:"checkPolicy,omitempty"`
+
+	// CheckExpiration enforces the password expiration policy of the server
+	// on the login. Requires CheckPolicy. Defaults to false.
+	// +optional
+	CheckExpiration *bool `json:"checkExpiration,omitempty"`
+
+	// ServerRoles the login is a member of, e.g. dbcreator. If set, the login
+	// is removed from any other server role.
+	// +optional
+	ServerRoles []string `json:"serverRoles,omitempty"`
+}
+
+// A LoginObservation represents the observed state of a MSSQL login.
+type LoginObservation struct {
+	// DefaultDatabase the login connects to.
+	DefaultDatabase string `json:"defaultDatabase,omitempty"`
+
+	// CheckPolicy indicates whether password policies are enforced.
+	CheckPolicy bool `json:"checkPolicy,omitempty"`
+
+	// CheckExpiration indicates whether password expiration is enforced.
+	CheckExpiration bool `json:"checkExpiration,omitempty"`
+
+	// ServerRoles the login is a member of.
+	ServerRoles []string `json:"serverRoles,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A Login represents the declarative state of a MSSQL server login.
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,sql}
+type Login struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   LoginSpec   `json:"spec"`
+	Status LoginStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// LoginList contains a list of Login
+type LoginList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Login `json:"items"`
+}
@@ -58,6 +58,14 @@ var (
 	ProviderConfigUsageListGroupVersionKind = SchemeGroupVersion.WithKind(ProviderConfigUsageListKind)
 )
 
+// AgentJob type metadata.
+var (
+	AgentJobKind             = reflect.TypeOf(AgentJob{}).Name()
+	AgentJobGroupKind        = schema.GroupKind{Group: Group, Kind: AgentJobKind}.String()
+	AgentJobKindAPIVersion   = AgentJobKind + "." + SchemeGroupVersion.String()
+	AgentJobGroupVersionKind = SchemeGroupVersion.WithKind(AgentJobKind)
+)
+
 // Database type metadata.
 var (
 	DatabaseKind             = reflect.TypeOf(Database{}).Name()
@@ -74,6 +82,30 @@ var (
 	UserGroupVersionKind = SchemeGroupVersion.WithKind(UserKind)
 )
 
+// Login type metadata.
+var (
+	LoginKind             = reflect.TypeOf(Login{}).Name()
+	LoginGroupKind        = schema.GroupKind{Group: Group, Kind: LoginKind}.String()
+	LoginKindAPIVersion   = LoginKind + "." + SchemeGroupVersion.String()
+	LoginGroupVersionKind = SchemeGroupVersion.WithKind(LoginKind)
+)
+
+// Role type metadata.
+var (
+	RoleKind             = reflect.TypeOf(Role{}).Name()
+	RoleGroupKind        = schema.GroupKind{Group: Group, Kind: RoleKind}.String()
+	RoleKindAPIVersion   = RoleKind + "." + SchemeGroupVersion.String()
+	RoleGroupVersionKind = SchemeGroupVersion.WithKind(RoleKind)
+)
+
+// Schema type metadata.
+var (
+	SchemaKind             = reflect.TypeOf(Schema{}).Name()
+	SchemaGroupKind        = schema.GroupKind{Group: Group, Kind: SchemaKind}.String()
+	SchemaKindAPIVersion   = SchemaKind + "." + SchemeGroupVersion.String()
+	SchemaGroupVersionKind = SchemeGroupVersion.WithKind(SchemaKind)
+)
+
 // Grant type metadata.
 var (
 	GrantKind             = reflect.TypeOf(Grant{}).Name()
@@ -87,5 +119,9 @@ func init() {
 	SchemeBuilder.Register(&ProviderConfigUsage{}, &ProviderConfigUsageList{})
 	SchemeBuilder.Register(&Database{}, &DatabaseList{})
 	SchemeBuilder.Register(&User{}, &UserList{})
+	SchemeBuilder.Register(&Login{}, &LoginList{})
+	SchemeBuilder.Register(&Role{}, &RoleList{})
+	SchemeBuilder.Register(&Schema{}, &SchemaList{})
 	SchemeBuilder.Register(&Grant{}, &GrantList{})
+	SchemeBuilder.Register(&AgentJob{}, &AgentJobList{})
 }
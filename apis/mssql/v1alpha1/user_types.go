@@ -34,6 +34,26 @@ type UserStatus struct {
 	AtProvider          UserObservation `json:"atProvider,omitempty"`
 }
 
+// UserAuthentication is how a user that is not created for a login
+// authenticates.
+// +kubebuilder:validation:Enum=Password;None;ExternalProvider
+type UserAuthentication string
+
+// The ways a user that is not created for a login authenticates.
+const (
+	// UserAuthenticationPassword users authenticate with a password of their
+	// own, which requires a contained database.
+	UserAuthenticationPassword UserAuthentication = "Password"
+
+	// UserAuthenticationNone users are created WITHOUT LOGIN. They cannot
+	// authenticate, but can be impersonated.
+	UserAuthenticationNone UserAuthentication = "None"
+
+	// UserAuthenticationExternalProvider users are created FROM EXTERNAL
+	// PROVIDER for the Microsoft Entra ID user or group with their name.
+	UserAuthenticationExternalProvider UserAuthentication = "ExternalProvider"
+)
+
 // UserParameters define the desired state of a MSSQL user instance.
 type UserParameters struct {
 	// +crossplane:generate:reference:type=Database
@@ -46,12 +66,66 @@ type UserParameters struct {
 	DatabaseSelector *xpv1.Selector `json:"databaseSelector,omitempty"`
 	// PasswordSecretRef references the secret that contains the password used
 	// for this user. If no reference is given, a password will be auto-generated.
+	// It is ignored if the user is created for a login.
 	// +optional
 	PasswordSecretRef *xpv1.SecretKeySelector `json:"passwordSecretRef,omitempty"`
+
+	// Login the user is created for. The user authenticates with the login
+	// at server level instead of with a password of its own, which does not
+	// require a contained database.
+	// +optional
+	// +crossplane:generate:reference:type=Login
+	Login *string `json:"login,omitempty"`
+
+	// LoginRef references the Login the user is created for.
+	// +optional
+	LoginRef *xpv1.Reference `json:"loginRef,omitempty"`
+
+	// LoginSelector selects a reference to a Login the user is created for.
+	// +optional
+	LoginSelector *xpv1.Selector `json:"loginSelector,omitempty"`
+
+	// Authentication of the user if it is not created for a login. Defaults
+	// to Password.
+	// +immutable
+	// +optional
+	Authentication *UserAuthentication `json:"authentication,omitempty"`
+
+	// Roles the user is a member of, e.g. db_datareader or a custom Role.
+	// If set, the user is removed from any other role of the database.
+	// +optional
+	Roles []string `json:"roles,omitempty"`
+
+	// DefaultSchema of the user, which is searched first for objects that
+	// are not qualified with a schema. Defaults to dbo.
+	// +optional
+	// +crossplane:generate:reference:type=Schema
+	DefaultSchema *string `json:"defaultSchema,omitempty"`
+
+	// DefaultSchemaRef references the Schema that is the default schema of
+	// the user.
+	// +optional
+	DefaultSchemaRef *xpv1.Reference `json:"defaultSchemaRef,omitempty"`
+
+	// DefaultSchemaSelector selects a reference to a Schema that is the
+	// default schema of the user.
+	// +optional
+	DefaultSchemaSelector *xpv1.Selector `json:"defaultSchemaSelector,omitempty"`
+
+	// DefaultLanguage of the user, e.g. us_english. Only users of a
+	// contained database have a default language of their own.
+	// +optional
+	DefaultLanguage *string `json:"defaultLanguage,omitempty"`
 }
 
 // A UserObservation represents the observed state of a MSSQL user.
 type UserObservation struct {
+	// Roles the user is a member of.
+	Roles []string `json:"roles,omitempty"`
+
+	// AuthenticationType of the user, e.g. DATABASE for users with a
+	// password or INSTANCE for users created for a login.
+	AuthenticationType string `json:"authenticationType,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -22,9 +22,34 @@ import (
 	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
 )
 
+// DatabaseParameters are the configurable fields of a Database.
+type DatabaseParameters struct {
+	// CharacterSet is the default character set of the database, e.g.
+	// utf8mb4. Defaults to the character_set_server setting.
+	// +optional
+	CharacterSet *string `json:"characterSet,omitempty"`
+
+	// Collation is the default collation of the database, e.g.
+	// utf8mb4_0900_ai_ci. Defaults to the default collation of the character
+	// set.
+	// +optional
+	Collation *string `json:"collation,omitempty"`
+
+	// Encryption determines whether tables created in the database are
+	// encrypted by default. Requires MySQL 8.0.16 or later and a keyring.
+	// +optional
+	Encryption *bool `json:"encryption,omitempty"`
+
+	// ReadOnly prevents any modification of the database and the objects it
+	// contains. Requires MySQL 8.0.22 or later.
+	// +optional
+	ReadOnly *bool `json:"readOnly,omitempty"`
+}
+
 // A DatabaseSpec defines the desired state of a Database.
 type DatabaseSpec struct {
 	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       DatabaseParameters `json:"forProvider,omitempty"`
 }
 
 // A DatabaseStatus represents the observed state of a Database.
@@ -0,0 +1,97 @@
+/*
+Copyright 2022 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// GlobalVariableParameters are the configurable fields of a GlobalVariable.
+type GlobalVariableParameters struct {
+	// Variable is the name of the global system variable, e.g.
+	// max_connections or long_query_time.
+	// +immutable
+	// +kubebuilder:validation:Pattern:=`^[A-Za-z_][A-Za-z0-9_.]*$`
+	Variable string `json:"variable"`
+
+	// Value of the variable. Values should be written the way MySQL reports
+	// them, e.g. sql_mode flags in the order listed in the MySQL
+	// documentation, for the variable to be considered up to date.
+	Value string `json:"value"`
+}
+
+// A GlobalVariableObservation represents the observed state of a global
+// system variable.
+type GlobalVariableObservation struct {
+	// Value of the variable currently in effect, as reported by
+	// performance_schema.global_variables.
+	Value string `json:"value,omitempty"`
+
+	// PersistedValue of the variable, as reported by
+	// performance_schema.persisted_variables.
+	PersistedValue string `json:"persistedValue,omitempty"`
+
+	// PendingRestart is true if the persisted value of the variable was set
+	// using SET PERSIST_ONLY, which is the case for read only variables, and
+	// will only take effect once the server has been restarted.
+	PendingRestart bool `json:"pendingRestart,omitempty"`
+}
+
+// A GlobalVariableSpec defines the desired state of a GlobalVariable.
+type GlobalVariableSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       GlobalVariableParameters `json:"forProvider"`
+}
+
+// A GlobalVariableStatus represents the observed state of a GlobalVariable.
+type GlobalVariableStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          GlobalVariableObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A GlobalVariable represents the declarative state of a MySQL global system
+// variable set using SET PERSIST. Read only variables are set using SET
+// PERSIST_ONLY and take effect once the server has been restarted.
+// GlobalVariables require MySQL 8.0 or later.
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="VARIABLE",type="string",JSONPath=".spec.forProvider.variable"
+// +kubebuilder:printcolumn:name="VALUE",type="string",JSONPath=".spec.forProvider.value"
+// +kubebuilder:printcolumn:name="RESTART",type="string",JSONPath=".status.conditions[?(@.type=='RestartRequired')].status"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,sql}
+type GlobalVariable struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   GlobalVariableSpec   `json:"spec"`
+	Status GlobalVariableStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// GlobalVariableList contains a list of GlobalVariable
+type GlobalVariableList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []GlobalVariable `json:"items"`
+}
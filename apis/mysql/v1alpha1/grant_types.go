@@ -55,11 +55,28 @@ func (gp *GrantPrivileges) ToStringSlice() []string {
 	return out
 }
 
+// GrantOption represents an OPTION that will be applied to a grant.
+// +kubebuilder:validation:Enum=ADMIN;GRANT
+type GrantOption string
+
+// The possible values for grant option type.
+const (
+	GrantOptionAdmin GrantOption = "ADMIN"
+	GrantOptionGrant GrantOption = "GRANT"
+)
+
 // GrantParameters define the desired state of a MySQL grant instance.
 type GrantParameters struct {
-	// Privileges to be granted.
+	// Privileges to be granted. Required unless MemberOf or Proxy is set.
 	// See https://mariadb.com/kb/en/grant/#database-privileges for available privileges.
-	Privileges GrantPrivileges `json:"privileges"`
+	// +optional
+	Privileges GrantPrivileges `json:"privileges,omitempty"`
+
+	// WithOption allows an option to be set on the grant. ADMIN allows the
+	// grantee to grant the MemberOf role to others. GRANT allows the grantee
+	// to grant the Privileges, or the Proxy, to others.
+	// +optional
+	WithOption *GrantOption `json:"withOption,omitempty"`
 
 	// User this grant is for.
 	// +optional
@@ -75,10 +92,74 @@ type GrantParameters struct {
 	// +optional
 	UserSelector *xpv1.Selector `json:"userSelector,omitempty"`
 
+	// Role this grant is for, as an alternative to User.
+	// +optional
+	Role *string `json:"role,omitempty"`
+
+	// RoleRef references the Role this grant is for.
+	// +immutable
+	// +optional
+	RoleRef *xpv1.Reference `json:"roleRef,omitempty"`
+
+	// RoleSelector selects a reference to a Role this grant is for.
+	// +immutable
+	// +optional
+	RoleSelector *xpv1.Selector `json:"roleSelector,omitempty"`
+
+	// MemberOf is the Role this grant makes the User or Role a member of.
+	// Privileges, Database and Table are ignored for membership grants.
+	// +optional
+	MemberOf *string `json:"memberOf,omitempty"`
+
+	// MemberOfRef references the Role this grant makes the User or Role a
+	// member of.
+	// +immutable
+	// +optional
+	MemberOfRef *xpv1.Reference `json:"memberOfRef,omitempty"`
+
+	// MemberOfSelector selects a reference to a Role this grant makes the
+	// User or Role a member of.
+	// +immutable
+	// +optional
+	MemberOfSelector *xpv1.Selector `json:"memberOfSelector,omitempty"`
+
+	// Proxy is the user the User or Role is allowed to impersonate.
+	// Privileges, Database and Table are ignored for proxy grants.
+	// +optional
+	Proxy *string `json:"proxy,omitempty"`
+
+	// ProxyRef references the User the User or Role is allowed to
+	// impersonate.
+	// +immutable
+	// +optional
+	ProxyRef *xpv1.Reference `json:"proxyRef,omitempty"`
+
+	// ProxySelector selects a reference to a User the User or Role is
+	// allowed to impersonate.
+	// +immutable
+	// +optional
+	ProxySelector *xpv1.Selector `json:"proxySelector,omitempty"`
+
 	// Tables this grant is for, default *.
 	// +optional
 	Table *string `json:"table,omitempty" default:"*"`
 
+	// Columns of the Table the privileges are granted on, e.g. to grant
+	// SELECT (id, name). A grant with columns only manages the privileges on
+	// these columns.
+	// +optional
+	Columns []string `json:"columns,omitempty"`
+
+	// Procedure this grant is for, as an alternative to Table. The procedure
+	// must be in Database.
+	// +optional
+	Procedure *string `json:"procedure,omitempty"`
+
+	// Function this grant is for, as an alternative to Table. The function
+	// must be in Database.
+	// +optional
+	Function *string `json:"function,omitempty"`
+
 	// Database this grant is for, default *.
 	// +optional
 	Database *string `json:"database,omitempty" default:"*"`
@@ -94,9 +175,22 @@ type GrantParameters struct {
 	DatabaseSelector *xpv1.Selector `json:"databaseSelector,omitempty"`
 }
 
+// A GrantObservation represents the observed state of a MySQL grant.
+type GrantObservation struct {
+	// Privileges granted on the database and table, or the procedure or
+	// function, of the grant. Privileges that together make up ALL
+	// PRIVILEGES are reported as such.
+	Privileges []string `json:"privileges,omitempty"`
+
+	// ColumnPrivileges granted on columns of the table of the grant, e.g.
+	// SELECT (id).
+	ColumnPrivileges []string `json:"columnPrivileges,omitempty"`
+}
+
 // A GrantStatus represents the observed state of a Grant.
 type GrantStatus struct {
 	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          GrantObservation `json:"atProvider,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -159,5 +253,47 @@ func (mg *Grant) ResolveReferences(ctx context.Context, c client.Reader) error {
 	mg.Spec.ForProvider.User = reference.ToPtrValue(rsp.ResolvedValue)
 	mg.Spec.ForProvider.UserRef = rsp.ResolvedReference
 
+	// Resolve spec.forProvider.role
+	rsp, err = r.Resolve(ctx, reference.ResolutionRequest{
+		CurrentValue: reference.FromPtrValue(mg.Spec.ForProvider.Role),
+		Reference:    mg.Spec.ForProvider.RoleRef,
+		Selector:     mg.Spec.ForProvider.RoleSelector,
+		To:           reference.To{Managed: &Role{}, List: &RoleList{}},
+		Extract:      reference.ExternalName(),
+	})
+	if err != nil {
+		return errors.Wrap(err, "spec.forProvider.role")
+	}
+	mg.Spec.ForProvider.Role = reference.ToPtrValue(rsp.ResolvedValue)
+	mg.Spec.ForProvider.RoleRef = rsp.ResolvedReference
+
+	// Resolve spec.forProvider.memberOf
+	rsp, err = r.Resolve(ctx, reference.ResolutionRequest{
+		CurrentValue: reference.FromPtrValue(mg.Spec.ForProvider.MemberOf),
+		Reference:    mg.Spec.ForProvider.MemberOfRef,
+		Selector:     mg.Spec.ForProvider.MemberOfSelector,
+		To:           reference.To{Managed: &Role{}, List: &RoleList{}},
+		Extract:      reference.ExternalName(),
+	})
+	if err != nil {
+		return errors.Wrap(err, "spec.forProvider.memberOf")
+	}
+	mg.Spec.ForProvider.MemberOf = reference.ToPtrValue(rsp.ResolvedValue)
+	mg.Spec.ForProvider.MemberOfRef = rsp.ResolvedReference
+
+	// Resolve spec.forProvider.proxy
+	rsp, err = r.Resolve(ctx, reference.ResolutionRequest{
+		CurrentValue: reference.FromPtrValue(mg.Spec.ForProvider.Proxy),
+		Reference:    mg.Spec.ForProvider.ProxyRef,
+		Selector:     mg.Spec.ForProvider.ProxySelector,
+		To:           reference.To{Managed: &User{}, List: &UserList{}},
+		Extract:      reference.ExternalName(),
+	})
+	if err != nil {
+		return errors.Wrap(err, "spec.forProvider.proxy")
+	}
+	mg.Spec.ForProvider.Proxy = reference.ToPtrValue(rsp.ResolvedValue)
+	mg.Spec.ForProvider.ProxyRef = rsp.ResolvedReference
+
 	return nil
 }
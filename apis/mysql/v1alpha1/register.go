@@ -82,10 +82,28 @@ var (
 	GrantGroupVersionKind = SchemeGroupVersion.WithKind(GrantKind)
 )
 
+// Role type metadata.
+var (
+	RoleKind             = reflect.TypeOf(Role{}).Name()
+	RoleGroupKind        = schema.GroupKind{Group: Group, Kind: RoleKind}.String()
+	RoleKindAPIVersion   = RoleKind + "." + SchemeGroupVersion.String()
+	RoleGroupVersionKind = SchemeGroupVersion.WithKind(RoleKind)
+)
+
+// GlobalVariable type metadata.
+var (
+	GlobalVariableKind             = reflect.TypeOf(GlobalVariable{}).Name()
+	GlobalVariableGroupKind        = schema.GroupKind{Group: Group, Kind: GlobalVariableKind}.String()
+	GlobalVariableKindAPIVersion   = GlobalVariableKind + "." + SchemeGroupVersion.String()
+	GlobalVariableGroupVersionKind = SchemeGroupVersion.WithKind(GlobalVariableKind)
+)
+
 func init() {
 	SchemeBuilder.Register(&ProviderConfig{}, &ProviderConfigList{})
 	SchemeBuilder.Register(&ProviderConfigUsage{}, &ProviderConfigUsageList{})
 	SchemeBuilder.Register(&Database{}, &DatabaseList{})
 	SchemeBuilder.Register(&User{}, &UserList{})
 	SchemeBuilder.Register(&Grant{}, &GrantList{})
+	SchemeBuilder.Register(&Role{}, &RoleList{})
+	SchemeBuilder.Register(&GlobalVariable{}, &GlobalVariableList{})
 }
@@ -45,6 +45,138 @@ type UserParameters struct {
 	// See https://dev.mysql.com/doc/refman/8.0/en/user-resources.html
 	// +optional
 	ResourceOptions *ResourceOptions `json:"resourceOptions,omitempty"`
+
+	// AuthPlugin is the authentication plugin of the user, e.g.
+	// caching_sha2_password, mysql_native_password, auth_socket or
+	// AWSAuthenticationPlugin. Defaults to the server's default plugin.
+	// See https://dev.mysql.com/doc/refman/8.0/en/authentication-plugins.html
+	// +optional
+	AuthPlugin *string `json:"authPlugin,omitempty"`
+
+	// AuthString is passed to an authentication plugin that does not use a
+	// password, e.g. RDS for AWSAuthenticationPlugin. It is ignored by the
+	// caching_sha2_password, mysql_native_password and sha256_password
+	// plugins, which authenticate with the password of the user.
+	// +optional
+	AuthString *string `json:"authString,omitempty"`
+
+	// Require sets the TLS requirements of connections made by the user.
+	// See https://dev.mysql.com/doc/refman/8.0/en/create-user.html#create-user-tls
+	// +optional
+	Require *TLSRequirement `json:"require,omitempty"`
+
+	// PasswordOptions sets the password management and failed-login
+	// tracking policy of the account. Requires MySQL 8.0 or later.
+	// See https://dev.mysql.com/doc/refman/8.0/en/password-management.html
+	// +optional
+	PasswordOptions *PasswordOptions `json:"passwordOptions,omitempty"`
+
+	// AccountLocked locks the account, preventing the user from connecting.
+	// +optional
+	AccountLocked *bool `json:"accountLocked,omitempty"`
+
+	// DefaultRoles are activated when the user connects. Each role may
+	// include a host, e.g. app@localhost, which defaults to %. The roles must
+	// have been granted to the user, e.g. by a Grant with memberOf set.
+	// +optional
+	DefaultRoles []string `json:"defaultRoles,omitempty"`
+
+	// Hosts the user may connect from, e.g. 10.0.% and localhost. If set, an
+	// account with the same password and options is managed for each host,
+	// and the host of the external name is ignored. Setting hosts on an
+	// existing User drops its account on the host of its external name,
+	// unless that is one of them. Grants referencing the User are made for
+	// each of its hosts.
+	// +optional
+	Hosts []string `json:"hosts,omitempty"`
+
+	// Comment on the account. It is stored as the comment attribute of the
+	// account. Requires MySQL 8.0.21 or later.
+	// +optional
+	Comment *string `json:"comment,omitempty"`
+
+	// Attributes of the account, e.g. its owner or team. Attributes of the
+	// account that are not configured are removed. Requires MySQL 8.0.21 or
+	// later.
+	// +optional
+	Attributes map[string]string `json:"attributes,omitempty"`
+
+	// AttributesFrom adds labels and annotations of the User to the
+	// attributes of the account.
+	// +optional
+	AttributesFrom *AttributesSource `json:"attributesFrom,omitempty"`
+}
+
+// AttributesSource selects the labels and annotations of a User that are
+// added to the attributes of its account. Attributes set explicitly take
+// precedence.
+type AttributesSource struct {
+	// Labels to add to the attributes, by key. Labels the User does not
+	// have are ignored.
+	// +optional
+	Labels []string `json:"labels,omitempty"`
+
+	// Annotations to add to the attributes, by key. Annotations the User
+	// does not have are ignored.
+	// +optional
+	Annotations []string `json:"annotations,omitempty"`
+}
+
+// TLSRequirement defines the TLS requirements of an account.
+type TLSRequirement struct {
+	// Type of connection the user must use. NONE allows unencrypted
+	// connections, SSL requires an encrypted connection and X509 requires the
+	// client to present a valid certificate. Ignored if Issuer or Subject is
+	// set.
+	// +kubebuilder:validation:Enum=NONE;SSL;X509
+	// +optional
+	Type *string `json:"type,omitempty"`
+
+	// Issuer the client certificate must have been issued by.
+	// +optional
+	Issuer *string `json:"issuer,omitempty"`
+
+	// Subject the client certificate must have.
+	// +optional
+	Subject *string `json:"subject,omitempty"`
+}
+
+// PasswordOptions define the account specific password management options.
+type PasswordOptions struct {
+	// Expire sets the number of days after which the password must be
+	// changed. NEVER disables password expiration and DEFAULT applies the
+	// default_password_lifetime setting of the server.
+	// +kubebuilder:validation:Pattern=`^(DEFAULT|NEVER|[1-9][0-9]*)$`
+	// +optional
+	Expire *string `json:"expire,omitempty"`
+
+	// History sets the number of password changes after which a password may
+	// be reused. DEFAULT applies the password_history setting of the server.
+	// +kubebuilder:validation:Pattern=`^(DEFAULT|[0-9]+)$`
+	// +optional
+	History *string `json:"history,omitempty"`
+
+	// ReuseInterval sets the number of days after which a password may be
+	// reused. DEFAULT applies the password_reuse_interval setting of the
+	// server.
+	// +kubebuilder:validation:Pattern=`^(DEFAULT|[0-9]+)$`
+	// +optional
+	ReuseInterval *string `json:"reuseInterval,omitempty"`
+
+	// FailedLoginAttempts sets the number of consecutive failed logins after
+	// which the account is temporarily locked. 0 disables failed-login
+	// tracking. Requires MySQL 8.0.19 or later.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=32767
+	// +optional
+	FailedLoginAttempts *int `json:"failedLoginAttempts,omitempty"`
+
+	// PasswordLockTime sets the number of days the account is locked after
+	// too many failed logins. UNBOUNDED locks the account until it is
+	// unlocked. Requires MySQL 8.0.19 or later.
+	// +kubebuilder:validation:Pattern=`^(UNBOUNDED|[0-9]+)$`
+	// +optional
+	PasswordLockTime *string `json:"passwordLockTime,omitempty"`
 }
 
 // ResourceOptions define the account specific resource limits.
@@ -70,6 +202,27 @@ type ResourceOptions struct {
 type UserObservation struct {
 	// ResourceOptionsAsClauses represents the applied resource options
 	ResourceOptionsAsClauses []string `json:"resourceOptionsAsClauses,omitempty"`
+
+	// AuthPlugin represents the authentication plugin of the user
+	AuthPlugin string `json:"authPlugin,omitempty"`
+
+	// RequireAsClause represents the applied TLS requirements
+	RequireAsClause string `json:"requireAsClause,omitempty"`
+
+	// PasswordOptionsAsClauses represents the applied password options
+	PasswordOptionsAsClauses []string `json:"passwordOptionsAsClauses,omitempty"`
+
+	// AccountLocked indicates whether the account is locked
+	AccountLocked bool `json:"accountLocked,omitempty"`
+
+	// DefaultRoles represents the roles activated when the user connects
+	DefaultRoles []string `json:"defaultRoles,omitempty"`
+
+	// Hosts the user has an account for, if it was configured with hosts
+	Hosts []string `json:"hosts,omitempty"`
+
+	// Attributes of the account, including its comment
+	Attributes map[string]string `json:"attributes,omitempty"`
 }
 
 // +kubebuilder:object:root=true
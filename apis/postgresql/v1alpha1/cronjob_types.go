@@ -0,0 +1,175 @@
+/*
+Copyright 2022 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/reference"
+)
+
+// CronJobParameters are the configurable fields of a CronJob. The job is
+// scheduled in the database pg_cron is installed in, which must be the
+// ProviderConfig's default database.
+type CronJobParameters struct {
+	// Schedule of the job in cron syntax, e.g. '0 3 * * *', or an interval
+	// such as '30 seconds'.
+	Schedule string `json:"schedule"`
+
+	// Command run by the job.
+	Command string `json:"command"`
+
+	// Active determines whether the job is run on its schedule.
+	// +optional
+	Active *bool `json:"active,omitempty"`
+
+	// Database the command is run in. Defaults to the database pg_cron is
+	// installed in.
+	// +optional
+	Database *string `json:"database,omitempty"`
+
+	// DatabaseRef references the Database the command is run in.
+	// +immutable
+	// +optional
+	DatabaseRef *xpv1.Reference `json:"databaseRef,omitempty"`
+
+	// DatabaseSelector selects a reference to a Database the command is run
+	// in.
+	// +immutable
+	// +optional
+	DatabaseSelector *xpv1.Selector `json:"databaseSelector,omitempty"`
+
+	// Username of the role the command is run as. Defaults to the role used
+	// by the provider. Job names are unique per role, so the job is looked
+	// up by its name and username.
+	// +immutable
+	// +optional
+	Username *string `json:"username,omitempty"`
+
+	// UsernameRef references the Role the command is run as.
+	// +immutable
+	// +optional
+	UsernameRef *xpv1.Reference `json:"usernameRef,omitempty"`
+
+	// UsernameSelector selects a reference to a Role the command is run as.
+	// +immutable
+	// +optional
+	UsernameSelector *xpv1.Selector `json:"usernameSelector,omitempty"`
+}
+
+// A CronJobRun represents a run of a pg_cron job.
+type CronJobRun struct {
+	// Status of the run, e.g. running, succeeded or failed.
+	Status string `json:"status,omitempty"`
+
+	// ReturnMessage of the command, or the error it failed with.
+	ReturnMessage string `json:"returnMessage,omitempty"`
+
+	// StartTime of the run.
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+
+	// EndTime of the run.
+	EndTime *metav1.Time `json:"endTime,omitempty"`
+}
+
+// A CronJobObservation represents the observed state of a pg_cron job.
+type CronJobObservation struct {
+	// JobID assigned to the job by pg_cron.
+	JobID *int64 `json:"jobID,omitempty"`
+
+	// LastRun of the job, as recorded in cron.job_run_details.
+	LastRun *CronJobRun `json:"lastRun,omitempty"`
+}
+
+// A CronJobSpec defines the desired state of a CronJob.
+type CronJobSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       CronJobParameters `json:"forProvider"`
+}
+
+// A CronJobStatus represents the observed state of a CronJob.
+type CronJobStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          CronJobObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A CronJob represents the declarative state of a job scheduled with the
+// pg_cron extension.
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="SCHEDULE",type="string",JSONPath=".spec.forProvider.schedule"
+// +kubebuilder:printcolumn:name="LAST RUN",type="string",JSONPath=".status.atProvider.lastRun.status"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,sql}
+type CronJob struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   CronJobSpec   `json:"spec"`
+	Status CronJobStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// CronJobList contains a list of CronJob
+type CronJobList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []CronJob `json:"items"`
+}
+
+// ResolveReferences of this CronJob
+func (mg *CronJob) ResolveReferences(ctx context.Context, c client.Reader) error {
+	r := reference.NewAPIResolver(c, mg)
+
+	// Resolve spec.forProvider.database
+	rsp, err := r.Resolve(ctx, reference.ResolutionRequest{
+		CurrentValue: reference.FromPtrValue(mg.Spec.ForProvider.Database),
+		Reference:    mg.Spec.ForProvider.DatabaseRef,
+		Selector:     mg.Spec.ForProvider.DatabaseSelector,
+		To:           reference.To{Managed: &Database{}, List: &DatabaseList{}},
+		Extract:      reference.ExternalName(),
+	})
+	if err != nil {
+		return errors.Wrap(err, "spec.forProvider.database")
+	}
+	mg.Spec.ForProvider.Database = reference.ToPtrValue(rsp.ResolvedValue)
+	mg.Spec.ForProvider.DatabaseRef = rsp.ResolvedReference
+
+	// Resolve spec.forProvider.username
+	rsp, err = r.Resolve(ctx, reference.ResolutionRequest{
+		CurrentValue: reference.FromPtrValue(mg.Spec.ForProvider.Username),
+		Reference:    mg.Spec.ForProvider.UsernameRef,
+		Selector:     mg.Spec.ForProvider.UsernameSelector,
+		To:           reference.To{Managed: &Role{}, List: &RoleList{}},
+		Extract:      reference.ExternalName(),
+	})
+	if err != nil {
+		return errors.Wrap(err, "spec.forProvider.username")
+	}
+	mg.Spec.ForProvider.Username = reference.ToPtrValue(rsp.ResolvedValue)
+	mg.Spec.ForProvider.UsernameRef = rsp.ResolvedReference
+	return nil
+}
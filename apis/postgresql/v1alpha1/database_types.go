@@ -17,9 +17,14 @@ limitations under the License.
 package v1alpha1
 
 import (
+	"context"
+
+	"github.com/pkg/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/reference"
 )
 
 // DatabaseParameters are the configurable fields of a Database.
@@ -60,6 +65,18 @@ type DatabaseParameters struct {
 	// See CREATE TABLESPACE for more information.
 	Tablespace *string `json:"tablespace,omitempty"`
 
+	// TablespaceRef references the Tablespace that will be associated with
+	// the new database.
+	// +immutable
+	// +optional
+	TablespaceRef *xpv1.Reference `json:"tablespaceRef,omitempty"`
+
+	// TablespaceSelector selects a reference to a Tablespace that will be
+	// associated with the new database.
+	// +immutable
+	// +optional
+	TablespaceSelector *xpv1.Selector `json:"tablespaceSelector,omitempty"`
+
 	// If false then no one can connect to this database. The default is true,
 	// allowing connections (except as restricted by other mechanisms, such as
 	// GRANT/REVOKE CONNECT).
@@ -110,3 +127,23 @@ type DatabaseList struct {
 	metav1.ListMeta `json:"metadata,omitempty"`
 	Items           []Database `json:"items"`
 }
+
+// ResolveReferences of this Database
+func (mg *Database) ResolveReferences(ctx context.Context, c client.Reader) error {
+	r := reference.NewAPIResolver(c, mg)
+
+	// Resolve spec.forProvider.tablespace
+	rsp, err := r.Resolve(ctx, reference.ResolutionRequest{
+		CurrentValue: reference.FromPtrValue(mg.Spec.ForProvider.Tablespace),
+		Reference:    mg.Spec.ForProvider.TablespaceRef,
+		Selector:     mg.Spec.ForProvider.TablespaceSelector,
+		To:           reference.To{Managed: &Tablespace{}, List: &TablespaceList{}},
+		Extract:      reference.ExternalName(),
+	})
+	if err != nil {
+		return errors.Wrap(err, "spec.forProvider.tablespace")
+	}
+	mg.Spec.ForProvider.Tablespace = reference.ToPtrValue(rsp.ResolvedValue)
+	mg.Spec.ForProvider.TablespaceRef = rsp.ResolvedReference
+	return nil
+}
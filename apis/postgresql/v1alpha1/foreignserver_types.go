@@ -0,0 +1,201 @@
+/*
+Copyright 2022 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/reference"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+)
+
+// ForeignOption is an option of a foreign server or user mapping. The
+// available options depend on the foreign-data wrapper, e.g. host, port and
+// dbname for postgres_fdw servers.
+type ForeignOption struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// ForeignServerParameters are the configurable fields of a ForeignServer.
+type ForeignServerParameters struct {
+	// ForeignDataWrapper is the name of the foreign-data wrapper that manages
+	// the server, e.g. postgres_fdw.
+	// +immutable
+	// +optional
+	ForeignDataWrapper *string `json:"foreignDataWrapper,omitempty"`
+
+	// ForeignDataWrapperRef references the Extension that installs the
+	// foreign-data wrapper. The wrapper is assumed to be named after the
+	// extension, as is the case for postgres_fdw.
+	// +immutable
+	// +optional
+	ForeignDataWrapperRef *xpv1.Reference `json:"foreignDataWrapperRef,omitempty"`
+
+	// ForeignDataWrapperSelector selects a reference to an Extension that
+	// installs the foreign-data wrapper.
+	// +immutable
+	// +optional
+	ForeignDataWrapperSelector *xpv1.Selector `json:"foreignDataWrapperSelector,omitempty"`
+
+	// Type of the server, which may be useful to the foreign-data wrapper.
+	// +immutable
+	// +optional
+	Type *string `json:"type,omitempty"`
+
+	// Version of the server, which may be useful to the foreign-data wrapper.
+	// +optional
+	Version *string `json:"version,omitempty"`
+
+	// Options of the server, e.g. host, port and dbname for postgres_fdw. If
+	// specified, any other options set on the server will be dropped.
+	// +optional
+	Options []ForeignOption `json:"options,omitempty"`
+
+	// Owner is the role that owns the server. Defaults to the role used by
+	// the provider.
+	// +optional
+	Owner *string `json:"owner,omitempty"`
+
+	// OwnerRef references the Role that owns the server.
+	// +immutable
+	// +optional
+	OwnerRef *xpv1.Reference `json:"ownerRef,omitempty"`
+
+	// OwnerSelector selects a reference to a Role that owns the server.
+	// +immutable
+	// +optional
+	OwnerSelector *xpv1.Selector `json:"ownerSelector,omitempty"`
+
+	// Database the server is created in.
+	// +optional
+	Database *string `json:"database,omitempty"`
+
+	// DatabaseRef references the Database the server is created in.
+	// +immutable
+	// +optional
+	DatabaseRef *xpv1.Reference `json:"databaseRef,omitempty"`
+
+	// DatabaseSelector selects a reference to a Database the server is
+	// created in.
+	// +immutable
+	// +optional
+	DatabaseSelector *xpv1.Selector `json:"databaseSelector,omitempty"`
+}
+
+// A ForeignServerSpec defines the desired state of a ForeignServer.
+type ForeignServerSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       ForeignServerParameters `json:"forProvider"`
+}
+
+// A ForeignServerStatus represents the observed state of a ForeignServer.
+type ForeignServerStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+}
+
+// +kubebuilder:object:root=true
+
+// A ForeignServer represents the declarative state of a PostgreSQL foreign
+// server.
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="DATABASE",type="string",JSONPath=".spec.forProvider.database"
+// +kubebuilder:printcolumn:name="WRAPPER",type="string",JSONPath=".spec.forProvider.foreignDataWrapper"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,sql}
+type ForeignServer struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ForeignServerSpec   `json:"spec"`
+	Status ForeignServerStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// ForeignServerList contains a list of ForeignServer
+type ForeignServerList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ForeignServer `json:"items"`
+}
+
+// ExtensionName extracts the name of the extension installed by an Extension.
+func ExtensionName() reference.ExtractValueFn {
+	return func(mg resource.Managed) string {
+		e, ok := mg.(*Extension)
+		if !ok {
+			return ""
+		}
+		return e.Spec.ForProvider.Extension
+	}
+}
+
+// ResolveReferences of this ForeignServer
+func (mg *ForeignServer) ResolveReferences(ctx context.Context, c client.Reader) error {
+	r := reference.NewAPIResolver(c, mg)
+
+	// Resolve spec.forProvider.database
+	rsp, err := r.Resolve(ctx, reference.ResolutionRequest{
+		CurrentValue: reference.FromPtrValue(mg.Spec.ForProvider.Database),
+		Reference:    mg.Spec.ForProvider.DatabaseRef,
+		Selector:     mg.Spec.ForProvider.DatabaseSelector,
+		To:           reference.To{Managed: &Database{}, List: &DatabaseList{}},
+		Extract:      reference.ExternalName(),
+	})
+	if err != nil {
+		return errors.Wrap(err, "spec.forProvider.database")
+	}
+	mg.Spec.ForProvider.Database = reference.ToPtrValue(rsp.ResolvedValue)
+	mg.Spec.ForProvider.DatabaseRef = rsp.ResolvedReference
+
+	// Resolve spec.forProvider.foreignDataWrapper
+	rsp, err = r.Resolve(ctx, reference.ResolutionRequest{
+		CurrentValue: reference.FromPtrValue(mg.Spec.ForProvider.ForeignDataWrapper),
+		Reference:    mg.Spec.ForProvider.ForeignDataWrapperRef,
+		Selector:     mg.Spec.ForProvider.ForeignDataWrapperSelector,
+		To:           reference.To{Managed: &Extension{}, List: &ExtensionList{}},
+		Extract:      ExtensionName(),
+	})
+	if err != nil {
+		return errors.Wrap(err, "spec.forProvider.foreignDataWrapper")
+	}
+	mg.Spec.ForProvider.ForeignDataWrapper = reference.ToPtrValue(rsp.ResolvedValue)
+	mg.Spec.ForProvider.ForeignDataWrapperRef = rsp.ResolvedReference
+
+	// Resolve spec.forProvider.owner
+	rsp, err = r.Resolve(ctx, reference.ResolutionRequest{
+		CurrentValue: reference.FromPtrValue(mg.Spec.ForProvider.Owner),
+		Reference:    mg.Spec.ForProvider.OwnerRef,
+		Selector:     mg.Spec.ForProvider.OwnerSelector,
+		To:           reference.To{Managed: &Role{}, List: &RoleList{}},
+		Extract:      reference.ExternalName(),
+	})
+	if err != nil {
+		return errors.Wrap(err, "spec.forProvider.owner")
+	}
+	mg.Spec.ForProvider.Owner = reference.ToPtrValue(rsp.ResolvedValue)
+	mg.Spec.ForProvider.OwnerRef = rsp.ResolvedReference
+	return nil
+}
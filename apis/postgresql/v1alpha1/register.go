@@ -90,6 +90,46 @@ var (
 	GrantGroupVersionKind = SchemeGroupVersion.WithKind(GrantKind)
 )
 
+// ForeignServer type metadata.
+var (
+	ForeignServerKind             = reflect.TypeOf(ForeignServer{}).Name()
+	ForeignServerGroupKind        = schema.GroupKind{Group: Group, Kind: ForeignServerKind}.String()
+	ForeignServerKindAPIVersion   = ForeignServerKind + "." + SchemeGroupVersion.String()
+	ForeignServerGroupVersionKind = SchemeGroupVersion.WithKind(ForeignServerKind)
+)
+
+// UserMapping type metadata.
+var (
+	UserMappingKind             = reflect.TypeOf(UserMapping{}).Name()
+	UserMappingGroupKind        = schema.GroupKind{Group: Group, Kind: UserMappingKind}.String()
+	UserMappingKindAPIVersion   = UserMappingKind + "." + SchemeGroupVersion.String()
+	UserMappingGroupVersionKind = SchemeGroupVersion.WithKind(UserMappingKind)
+)
+
+// CronJob type metadata.
+var (
+	CronJobKind             = reflect.TypeOf(CronJob{}).Name()
+	CronJobGroupKind        = schema.GroupKind{Group: Group, Kind: CronJobKind}.String()
+	CronJobKindAPIVersion   = CronJobKind + "." + SchemeGroupVersion.String()
+	CronJobGroupVersionKind = SchemeGroupVersion.WithKind(CronJobKind)
+)
+
+// Tablespace type metadata.
+var (
+	TablespaceKind             = reflect.TypeOf(Tablespace{}).Name()
+	TablespaceGroupKind        = schema.GroupKind{Group: Group, Kind: TablespaceKind}.String()
+	TablespaceKindAPIVersion   = TablespaceKind + "." + SchemeGroupVersion.String()
+	TablespaceGroupVersionKind = SchemeGroupVersion.WithKind(TablespaceKind)
+)
+
+// ServerSetting type metadata.
+var (
+	ServerSettingKind             = reflect.TypeOf(ServerSetting{}).Name()
+	ServerSettingGroupKind        = schema.GroupKind{Group: Group, Kind: ServerSettingKind}.String()
+	ServerSettingKindAPIVersion   = ServerSettingKind + "." + SchemeGroupVersion.String()
+	ServerSettingGroupVersionKind = SchemeGroupVersion.WithKind(ServerSettingKind)
+)
+
 func init() {
 	SchemeBuilder.Register(&ProviderConfig{}, &ProviderConfigList{})
 	SchemeBuilder.Register(&ProviderConfigUsage{}, &ProviderConfigUsageList{})
@@ -97,4 +137,9 @@ func init() {
 	SchemeBuilder.Register(&Role{}, &RoleList{})
 	SchemeBuilder.Register(&Grant{}, &GrantList{})
 	SchemeBuilder.Register(&Extension{}, &ExtensionList{})
+	SchemeBuilder.Register(&ForeignServer{}, &ForeignServerList{})
+	SchemeBuilder.Register(&UserMapping{}, &UserMappingList{})
+	SchemeBuilder.Register(&CronJob{}, &CronJobList{})
+	SchemeBuilder.Register(&Tablespace{}, &TablespaceList{})
+	SchemeBuilder.Register(&ServerSetting{}, &ServerSettingList{})
 }
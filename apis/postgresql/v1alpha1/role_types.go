@@ -84,6 +84,15 @@ type RoleParameters struct {
 	// +optional
 	PasswordSecretRef *xpv1.SecretKeySelector `json:"passwordSecretRef,omitempty"`
 
+	// PasswordEncryption is the method used to hash the password before it is
+	// sent to the server, so that the password itself never appears in server
+	// logs. Defaults to scram-sha-256. Use md5 for servers older than
+	// PostgreSQL 10. Passwords must only contain ASCII characters when using
+	// scram-sha-256.
+	// +kubebuilder:validation:Enum=scram-sha-256;md5
+	// +optional
+	PasswordEncryption *string `json:"passwordEncryption,omitempty"`
+
 	// ConfigurationParameters to be applied to the role. If specified, any other configuration parameters set on the
 	// role in the database will be reset.
 	//
@@ -0,0 +1,94 @@
+/*
+Copyright 2022 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// ServerSettingParameters are the configurable fields of a ServerSetting.
+type ServerSettingParameters struct {
+	// Parameter is the name of the server configuration parameter, e.g.
+	// work_mem or log_min_duration_statement.
+	// +immutable
+	Parameter string `json:"parameter"`
+
+	// Value of the parameter as it would be written in postgresql.conf, e.g.
+	// 64MB or 250ms. List parameters such as shared_preload_libraries take a
+	// comma separated value.
+	Value string `json:"value"`
+}
+
+// A ServerSettingObservation represents the observed state of a server
+// configuration parameter, as reported by pg_settings.
+type ServerSettingObservation struct {
+	// Setting is the current value of the parameter, expressed in Unit.
+	Setting string `json:"setting,omitempty"`
+
+	// Unit the setting is expressed in, if any.
+	Unit string `json:"unit,omitempty"`
+
+	// Source of the current value, e.g. configuration file or default.
+	Source string `json:"source,omitempty"`
+
+	// PendingRestart is true if the parameter has been changed but will only
+	// take effect once the server has been restarted.
+	PendingRestart bool `json:"pendingRestart,omitempty"`
+}
+
+// A ServerSettingSpec defines the desired state of a ServerSetting.
+type ServerSettingSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       ServerSettingParameters `json:"forProvider"`
+}
+
+// A ServerSettingStatus represents the observed state of a ServerSetting.
+type ServerSettingStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          ServerSettingObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A ServerSetting represents the declarative state of a PostgreSQL server
+// configuration parameter set using ALTER SYSTEM.
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="PARAMETER",type="string",JSONPath=".spec.forProvider.parameter"
+// +kubebuilder:printcolumn:name="VALUE",type="string",JSONPath=".spec.forProvider.value"
+// +kubebuilder:printcolumn:name="RESTART",type="string",JSONPath=".status.conditions[?(@.type=='RestartRequired')].status"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,sql}
+type ServerSetting struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ServerSettingSpec   `json:"spec"`
+	Status ServerSettingStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// ServerSettingList contains a list of ServerSetting
+type ServerSettingList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ServerSetting `json:"items"`
+}
@@ -0,0 +1,123 @@
+/*
+Copyright 2022 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/reference"
+)
+
+// TablespaceParameters are the configurable fields of a Tablespace.
+type TablespaceParameters struct {
+	// Location is the directory that will be used for the tablespace. The
+	// directory must exist, be empty and be owned by the PostgreSQL system
+	// user, and must be specified by an absolute path.
+	// +immutable
+	Location string `json:"location"`
+
+	// Owner is the role that owns the tablespace. Defaults to the role used
+	// by the provider.
+	// +optional
+	Owner *string `json:"owner,omitempty"`
+
+	// OwnerRef references the Role that owns the tablespace.
+	// +immutable
+	// +optional
+	OwnerRef *xpv1.Reference `json:"ownerRef,omitempty"`
+
+	// OwnerSelector selects a reference to a Role that owns the tablespace.
+	// +immutable
+	// +optional
+	OwnerSelector *xpv1.Selector `json:"ownerSelector,omitempty"`
+
+	// SeqPageCost is the planner's estimate of the cost of a sequentially
+	// fetched page from this tablespace, e.g. "1.0". Defaults to the
+	// seq_page_cost server setting.
+	// +kubebuilder:validation:Pattern=`^[0-9]+(\.[0-9]+)?$`
+	// +optional
+	SeqPageCost *string `json:"seqPageCost,omitempty"`
+
+	// RandomPageCost is the planner's estimate of the cost of a
+	// non-sequentially fetched page from this tablespace, e.g. "1.1".
+	// Defaults to the random_page_cost server setting.
+	// +kubebuilder:validation:Pattern=`^[0-9]+(\.[0-9]+)?$`
+	// +optional
+	RandomPageCost *string `json:"randomPageCost,omitempty"`
+}
+
+// A TablespaceSpec defines the desired state of a Tablespace.
+type TablespaceSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       TablespaceParameters `json:"forProvider"`
+}
+
+// A TablespaceStatus represents the observed state of a Tablespace.
+type TablespaceStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+}
+
+// +kubebuilder:object:root=true
+
+// A Tablespace represents the declarative state of a PostgreSQL tablespace.
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="LOCATION",type="string",JSONPath=".spec.forProvider.location"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,sql}
+type Tablespace struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   TablespaceSpec   `json:"spec"`
+	Status TablespaceStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// TablespaceList contains a list of Tablespace
+type TablespaceList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Tablespace `json:"items"`
+}
+
+// ResolveReferences of this Tablespace
+func (mg *Tablespace) ResolveReferences(ctx context.Context, c client.Reader) error {
+	r := reference.NewAPIResolver(c, mg)
+
+	// Resolve spec.forProvider.owner
+	rsp, err := r.Resolve(ctx, reference.ResolutionRequest{
+		CurrentValue: reference.FromPtrValue(mg.Spec.ForProvider.Owner),
+		Reference:    mg.Spec.ForProvider.OwnerRef,
+		Selector:     mg.Spec.ForProvider.OwnerSelector,
+		To:           reference.To{Managed: &Role{}, List: &RoleList{}},
+		Extract:      reference.ExternalName(),
+	})
+	if err != nil {
+		return errors.Wrap(err, "spec.forProvider.owner")
+	}
+	mg.Spec.ForProvider.Owner = reference.ToPtrValue(rsp.ResolvedValue)
+	mg.Spec.ForProvider.OwnerRef = rsp.ResolvedReference
+	return nil
+}
@@ -0,0 +1,182 @@
+/*
+Copyright 2022 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/reference"
+)
+
+// UserMappingParameters are the configurable fields of a UserMapping.
+type UserMappingParameters struct {
+	// Role that is mapped to the foreign server. PUBLIC maps all roles,
+	// including those created later.
+	// +immutable
+	// +optional
+	Role *string `json:"role,omitempty"`
+
+	// RoleRef references the Role that is mapped to the foreign server.
+	// +immutable
+	// +optional
+	RoleRef *xpv1.Reference `json:"roleRef,omitempty"`
+
+	// RoleSelector selects a reference to a Role that is mapped to the
+	// foreign server.
+	// +immutable
+	// +optional
+	RoleSelector *xpv1.Selector `json:"roleSelector,omitempty"`
+
+	// Server the role is mapped to.
+	// +immutable
+	// +optional
+	Server *string `json:"server,omitempty"`
+
+	// ServerRef references the ForeignServer the role is mapped to.
+	// +immutable
+	// +optional
+	ServerRef *xpv1.Reference `json:"serverRef,omitempty"`
+
+	// ServerSelector selects a reference to a ForeignServer the role is
+	// mapped to.
+	// +immutable
+	// +optional
+	ServerSelector *xpv1.Selector `json:"serverSelector,omitempty"`
+
+	// RemoteCredentialsSecretRef references a secret containing the username
+	// and password used to connect to the foreign server. These are set as
+	// the user and password options of the mapping. A connection secret
+	// written by a Role on the remote server may be used directly.
+	// +optional
+	RemoteCredentialsSecretRef *xpv1.SecretReference `json:"remoteCredentialsSecretRef,omitempty"`
+
+	// Options of the user mapping other than the remote credentials. If
+	// specified, any other options set on the mapping will be dropped.
+	// PostgreSQL only shows the options of a mapping to superusers, to the
+	// mapped role and to the owner of the server of a PUBLIC mapping. Drift
+	// of the options and remote credentials is not detected unless the
+	// provider connects as one of these.
+	// +optional
+	Options []ForeignOption `json:"options,omitempty"`
+
+	// Database the foreign server was created in.
+	// +optional
+	Database *string `json:"database,omitempty"`
+
+	// DatabaseRef references the Database the foreign server was created in.
+	// +immutable
+	// +optional
+	DatabaseRef *xpv1.Reference `json:"databaseRef,omitempty"`
+
+	// DatabaseSelector selects a reference to a Database the foreign server
+	// was created in.
+	// +immutable
+	// +optional
+	DatabaseSelector *xpv1.Selector `json:"databaseSelector,omitempty"`
+}
+
+// A UserMappingSpec defines the desired state of a UserMapping.
+type UserMappingSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       UserMappingParameters `json:"forProvider"`
+}
+
+// A UserMappingStatus represents the observed state of a UserMapping.
+type UserMappingStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+}
+
+// +kubebuilder:object:root=true
+
+// A UserMapping represents the declarative state of a PostgreSQL user mapping
+// to a foreign server.
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="ROLE",type="string",JSONPath=".spec.forProvider.role"
+// +kubebuilder:printcolumn:name="SERVER",type="string",JSONPath=".spec.forProvider.server"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,sql}
+type UserMapping struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   UserMappingSpec   `json:"spec"`
+	Status UserMappingStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// UserMappingList contains a list of UserMapping
+type UserMappingList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []UserMapping `json:"items"`
+}
+
+// ResolveReferences of this UserMapping
+func (mg *UserMapping) ResolveReferences(ctx context.Context, c client.Reader) error {
+	r := reference.NewAPIResolver(c, mg)
+
+	// Resolve spec.forProvider.database
+	rsp, err := r.Resolve(ctx, reference.ResolutionRequest{
+		CurrentValue: reference.FromPtrValue(mg.Spec.ForProvider.Database),
+		Reference:    mg.Spec.ForProvider.DatabaseRef,
+		Selector:     mg.Spec.ForProvider.DatabaseSelector,
+		To:           reference.To{Managed: &Database{}, List: &DatabaseList{}},
+		Extract:      reference.ExternalName(),
+	})
+	if err != nil {
+		return errors.Wrap(err, "spec.forProvider.database")
+	}
+	mg.Spec.ForProvider.Database = reference.ToPtrValue(rsp.ResolvedValue)
+	mg.Spec.ForProvider.DatabaseRef = rsp.ResolvedReference
+
+	// Resolve spec.forProvider.server
+	rsp, err = r.Resolve(ctx, reference.ResolutionRequest{
+		CurrentValue: reference.FromPtrValue(mg.Spec.ForProvider.Server),
+		Reference:    mg.Spec.ForProvider.ServerRef,
+		Selector:     mg.Spec.ForProvider.ServerSelector,
+		To:           reference.To{Managed: &ForeignServer{}, List: &ForeignServerList{}},
+		Extract:      reference.ExternalName(),
+	})
+	if err != nil {
+		return errors.Wrap(err, "spec.forProvider.server")
+	}
+	mg.Spec.ForProvider.Server = reference.ToPtrValue(rsp.ResolvedValue)
+	mg.Spec.ForProvider.ServerRef = rsp.ResolvedReference
+
+	// Resolve spec.forProvider.role
+	rsp, err = r.Resolve(ctx, reference.ResolutionRequest{
+		CurrentValue: reference.FromPtrValue(mg.Spec.ForProvider.Role),
+		Reference:    mg.Spec.ForProvider.RoleRef,
+		Selector:     mg.Spec.ForProvider.RoleSelector,
+		To:           reference.To{Managed: &Role{}, List: &RoleList{}},
+		Extract:      reference.ExternalName(),
+	})
+	if err != nil {
+		return errors.Wrap(err, "spec.forProvider.role")
+	}
+	mg.Spec.ForProvider.Role = reference.ToPtrValue(rsp.ResolvedValue)
+	mg.Spec.ForProvider.RoleRef = rsp.ResolvedReference
+	return nil
+}
@@ -34,10 +34,13 @@ import (
 const (
 	driverName = "sqlserver"
 
-	errNotSupported = "%s not supported by MSSQL client"
+	errUnterminatedIdent = "unterminated quoted identifier in %q"
+	errEmptyIdent        = "empty identifier in %q"
+	errUnexpectedChar    = "unexpected %q after quoted identifier in %q"
 )
 
 type mssqlDB struct {
+	driver   string
 	dsn      string
 	endpoint string
 	port     string
@@ -64,20 +67,45 @@ func New(creds map[string][]byte, database string) xsql.DB {
 		RawQuery: query.Encode(),
 	}
 	return mssqlDB{
+		driver:   driverName,
 		dsn:      u.String(),
 		endpoint: endpoint,
 		port:     port,
 	}
 }
 
-// ExecTx is unsupported in mssql.
-func (c mssqlDB) ExecTx(_ context.Context, _ []xsql.Query) error {
-	return errors.Errorf(errNotSupported, "transactions")
+// ExecTx executes the supplied queries in a transaction, which is rolled
+// back if any of them fails.
+func (c mssqlDB) ExecTx(ctx context.Context, ql []xsql.Query) (err error) {
+	d, err := sql.Open(c.driver, c.dsn)
+	if err != nil {
+		return err
+	}
+	defer d.Close() //nolint:errcheck
+
+	tx, err := d.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback() //nolint:errcheck
+			return
+		}
+		err = tx.Commit()
+	}()
+
+	for _, q := range ql {
+		if _, err = tx.ExecContext(ctx, q.String, q.Parameters...); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 // Exec the supplied query.
 func (c mssqlDB) Exec(ctx context.Context, q xsql.Query) error {
-	d, err := sql.Open(driverName, c.dsn)
+	d, err := sql.Open(c.driver, c.dsn)
 	if err != nil {
 		return err
 	}
@@ -89,7 +117,7 @@ func (c mssqlDB) Exec(ctx context.Context, q xsql.Query) error {
 
 // Query the supplied query.
 func (c mssqlDB) Query(ctx context.Context, q xsql.Query) (*sql.Rows, error) {
-	d, err := sql.Open(driverName, c.dsn)
+	d, err := sql.Open(c.driver, c.dsn)
 	if err != nil {
 		return nil, err
 	}
@@ -100,7 +128,7 @@ func (c mssqlDB) Query(ctx context.Context, q xsql.Query) (*sql.Rows, error) {
 
 // Scan the results of the supplied query into the supplied destination.
 func (c mssqlDB) Scan(ctx context.Context, q xsql.Query, dest ...interface{}) error {
-	db, err := sql.Open(driverName, c.dsn)
+	db, err := sql.Open(c.driver, c.dsn)
 	if err != nil {
 		return err
 	}
@@ -119,12 +147,68 @@ func (c mssqlDB) GetConnectionDetails(username, password string) managed.Connect
 	}
 }
 
-// QuoteIdentifier for mssql queries
+// QuoteIdentifier for mssql queries. Closing brackets in the identifier are
+// escaped by doubling them.
 func QuoteIdentifier(id string) string {
-	return "[" + id + "]"
+	return "[" + strings.ReplaceAll(id, "]", "]]") + "]"
+}
+
+// QuoteQualifiedIdentifier quotes each part of a multi-part name, e.g. a
+// schema and an object in it, and joins them with dots.
+func QuoteQualifiedIdentifier(parts ...string) string {
+	quoted := make([]string, len(parts))
+	for i, p := range parts {
+		quoted[i] = QuoteIdentifier(p)
+	}
+	return strings.Join(quoted, ".")
+}
+
+// SplitQualifiedIdentifier splits a multi-part name, e.g. dbo.orders or
+// [dbo].[my.orders], into its unquoted parts.
+func SplitQualifiedIdentifier(name string) ([]string, error) {
+	var parts []string
+	for i := 0; ; i++ {
+		var part strings.Builder
+		if i < len(name) && name[i] == '[' {
+			closed := false
+			for i++; i < len(name); i++ {
+				if name[i] != ']' {
+					part.WriteByte(name[i])
+					continue
+				}
+				if i+1 < len(name) && name[i+1] == ']' {
+					part.WriteByte(']')
+					i++
+					continue
+				}
+				closed = true
+				i++
+				break
+			}
+			if !closed {
+				return nil, errors.Errorf(errUnterminatedIdent, name)
+			}
+		} else {
+			for ; i < len(name) && name[i] != '.'; i++ {
+				part.WriteByte(name[i])
+			}
+		}
+		if part.Len() == 0 {
+			return nil, errors.Errorf(errEmptyIdent, name)
+		}
+		parts = append(parts, part.String())
+
+		if i == len(name) {
+			return parts, nil
+		}
+		if name[i] != '.' {
+			return nil, errors.Errorf(errUnexpectedChar, name[i], name)
+		}
+	}
 }
 
-// QuoteValue for mssql queries
+// QuoteValue for mssql queries. The value is quoted as a Unicode string, so
+// that characters outside of the code page of the server are preserved.
 func QuoteValue(id string) string {
-	return "'" + strings.ReplaceAll(id, "'", "''") + "'"
+	return "N'" + strings.ReplaceAll(id, "'", "''") + "'"
 }
@@ -0,0 +1,167 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mssql
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane-contrib/provider-sql/pkg/clients/xsql"
+)
+
+func TestQuoteIdentifier(t *testing.T) {
+	cases := map[string]struct {
+		id   string
+		want string
+	}{
+		"Plain":          {id: "example", want: "[example]"},
+		"ClosingBracket": {id: "ex]ample", want: "[ex]]ample]"},
+		"BreakOut":       {id: "x]; DROP DATABASE master; --", want: "[x]]; DROP DATABASE master; --]"},
+		"OpeningBracket": {id: "[example", want: "[[example]"},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if diff := cmp.Diff(tc.want, QuoteIdentifier(tc.id)); diff != "" {
+				t.Errorf("QuoteIdentifier(%q): -want, +got:\n%s", tc.id, diff)
+			}
+		})
+	}
+}
+
+func TestQuoteValue(t *testing.T) {
+	if diff := cmp.Diff("N'it''s'", QuoteValue("it's")); diff != "" {
+		t.Errorf("QuoteValue(...): -want, +got:\n%s", diff)
+	}
+}
+
+func TestSplitQualifiedIdentifier(t *testing.T) {
+	cases := map[string]struct {
+		name    string
+		want    []string
+		wantErr bool
+	}{
+		"Unquoted":          {name: "dbo.orders", want: []string{"dbo", "orders"}},
+		"Quoted":            {name: "[dbo].[my.orders]", want: []string{"dbo", "my.orders"}},
+		"EscapedBracket":    {name: "[a]]b]", want: []string{"a]b"}},
+		"Mixed":             {name: "dbo.[orders]", want: []string{"dbo", "orders"}},
+		"Unterminated":      {name: "[dbo", wantErr: true},
+		"Empty":             {name: "", wantErr: true},
+		"TrailingDot":       {name: "dbo.", wantErr: true},
+		"CharAfterBrackets": {name: "[dbo]x", wantErr: true},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := SplitQualifiedIdentifier(tc.name)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("SplitQualifiedIdentifier(%q): want error %t, got %v", tc.name, tc.wantErr, err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("SplitQualifiedIdentifier(%q): -want, +got:\n%s", tc.name, diff)
+			}
+		})
+	}
+}
+
+func FuzzQuoteIdentifier(f *testing.F) {
+	for _, id := range []string{"example", "ex]ample", "[x]", "a.b", "x]; DROP DATABASE master; --", "]]"} {
+		f.Add(id, "orders")
+	}
+	f.Fuzz(func(t *testing.T, schema, object string) {
+		if schema == "" || object == "" {
+			t.Skip()
+		}
+		got, err := SplitQualifiedIdentifier(QuoteQualifiedIdentifier(schema, object))
+		if err != nil {
+			t.Fatalf("SplitQualifiedIdentifier(QuoteQualifiedIdentifier(%q, %q)): %v", schema, object, err)
+		}
+		if diff := cmp.Diff([]string{schema, object}, got); diff != "" {
+			t.Errorf("SplitQualifiedIdentifier(QuoteQualifiedIdentifier(%q, %q)): -want, +got:\n%s", schema, object, diff)
+		}
+	})
+}
+
+func TestExecTx(t *testing.T) {
+	errBoom := errors.New("boom")
+	ql := []xsql.Query{
+		{String: "EXEC msdb.dbo.sp_add_job @job_name = @p1", Parameters: []interface{}{"example"}},
+		{String: "EXEC msdb.dbo.sp_add_jobserver @job_name = @p1", Parameters: []interface{}{"example"}},
+	}
+
+	cases := map[string]struct {
+		reason string
+		expect func(m sqlmock.Sqlmock)
+		want   error
+	}{
+		"Commit": {
+			reason: "All queries should be executed in a transaction that is committed",
+			expect: func(m sqlmock.Sqlmock) {
+				m.ExpectBegin()
+				m.ExpectExec("sp_add_job ").WithArgs("example").WillReturnResult(sqlmock.NewResult(0, 0))
+				m.ExpectExec("sp_add_jobserver").WithArgs("example").WillReturnResult(sqlmock.NewResult(0, 0))
+				m.ExpectCommit()
+			},
+		},
+		"Rollback": {
+			reason: "The transaction should be rolled back if a query fails",
+			expect: func(m sqlmock.Sqlmock) {
+				m.ExpectBegin()
+				m.ExpectExec("sp_add_job ").WithArgs("example").WillReturnError(errBoom)
+				m.ExpectRollback()
+			},
+			want: errBoom,
+		},
+		"ErrCommit": {
+			reason: "Errors committing the transaction should be returned",
+			expect: func(m sqlmock.Sqlmock) {
+				m.ExpectBegin()
+				m.ExpectExec("sp_add_job ").WithArgs("example").WillReturnResult(sqlmock.NewResult(0, 0))
+				m.ExpectExec("sp_add_jobserver").WithArgs("example").WillReturnResult(sqlmock.NewResult(0, 0))
+				m.ExpectCommit().WillReturnError(errBoom)
+			},
+			want: errBoom,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			dsn := "mssql-exec-tx-" + name
+			d, m, err := sqlmock.NewWithDSN(dsn)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer d.Close() //nolint:errcheck
+			tc.expect(m)
+
+			c := mssqlDB{driver: "sqlmock", dsn: dsn}
+			err = c.ExecTx(context.Background(), ql)
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nc.ExecTx(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if err := m.ExpectationsWereMet(); err != nil {
+				t.Errorf("\n%s\nc.ExecTx(...): %s", tc.reason, err)
+			}
+		})
+	}
+}
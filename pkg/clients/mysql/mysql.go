@@ -7,6 +7,7 @@ import (
 	"strings"
 
 	"github.com/crossplane-contrib/provider-sql/pkg/clients/xsql"
+	"github.com/go-sql-driver/mysql"
 	"github.com/pkg/errors"
 
 	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
@@ -15,6 +16,15 @@ import (
 
 const (
 	errNotSupported = "%s not supported by mysql client"
+
+	// https://dev.mysql.com/doc/mysql-errors/8.0/en/server-error-reference.html
+	// These are not available as part of the mysql library.
+	errNumDBAccessDenied       = 1044
+	errNumBadField             = 1054
+	errNumUnknownTable         = 1109
+	errNumTableAccessDenied    = 1142
+	errNumNoSuchTable          = 1146
+	errNumSpecificAccessDenied = 1227
 )
 
 type mySQLDB struct {
@@ -129,3 +139,30 @@ func SplitUserHost(user string) (username, host string) {
 	}
 	return username, host
 }
+
+// IsUnsupported returns true if passed a MySQL error indicating that a table or
+// column does not exist, typically because the server predates the feature it
+// belongs to.
+func IsUnsupported(err error) bool {
+	var myErr *mysql.MySQLError
+	if errors.As(err, &myErr) {
+		switch myErr.Number {
+		case errNumBadField, errNumUnknownTable, errNumNoSuchTable:
+			return true
+		}
+	}
+	return false
+}
+
+// IsAccessDenied returns true if passed a MySQL error indicating that the
+// connecting user may not access a database or table.
+func IsAccessDenied(err error) bool {
+	var myErr *mysql.MySQLError
+	if errors.As(err, &myErr) {
+		switch myErr.Number {
+		case errNumDBAccessDenied, errNumTableAccessDenied, errNumSpecificAccessDenied:
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,87 @@
+/*
+Copyright 2022 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package postgresql
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/lib/pq"
+)
+
+// ParseOptions converts the name=value entries PostgreSQL stores in catalog
+// option arrays (e.g. srvoptions, umoptions) into a map.
+func ParseOptions(opts []string) map[string]string {
+	out := make(map[string]string, len(opts))
+	for _, o := range opts {
+		kv := strings.SplitN(o, "=", 2)
+		if len(kv) != 2 {
+			out[kv[0]] = ""
+			continue
+		}
+		out[kv[0]] = kv[1]
+	}
+	return out
+}
+
+// OptionsClause returns an OPTIONS clause setting the supplied options, as used
+// by CREATE SERVER and CREATE USER MAPPING. An empty string is returned if
+// there are no options.
+func OptionsClause(opts map[string]string) string {
+	if len(opts) == 0 {
+		return ""
+	}
+	clauses := make([]string, 0, len(opts))
+	for _, k := range sortedKeys(opts) {
+		clauses = append(clauses, pq.QuoteIdentifier(k)+" "+pq.QuoteLiteral(opts[k]))
+	}
+	return "OPTIONS (" + strings.Join(clauses, ", ") + ")"
+}
+
+// AlterOptionsClause returns an OPTIONS clause that moves the observed options
+// to the desired ones, as used by ALTER SERVER and ALTER USER MAPPING. An empty
+// string is returned if the options are already up to date.
+func AlterOptionsClause(observed, desired map[string]string) string {
+	clauses := []string{}
+	for _, k := range sortedKeys(desired) {
+		v, ok := observed[k]
+		switch {
+		case !ok:
+			clauses = append(clauses, "ADD "+pq.QuoteIdentifier(k)+" "+pq.QuoteLiteral(desired[k]))
+		case v != desired[k]:
+			clauses = append(clauses, "SET "+pq.QuoteIdentifier(k)+" "+pq.QuoteLiteral(desired[k]))
+		}
+	}
+	for _, k := range sortedKeys(observed) {
+		if _, ok := desired[k]; !ok {
+			clauses = append(clauses, "DROP "+pq.QuoteIdentifier(k))
+		}
+	}
+	if len(clauses) == 0 {
+		return ""
+	}
+	return "OPTIONS (" + strings.Join(clauses, ", ") + ")"
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
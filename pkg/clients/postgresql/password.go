@@ -0,0 +1,168 @@
+/*
+Copyright 2022 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package postgresql
+
+import (
+	"crypto/hmac"
+	"crypto/md5" //nolint:gosec // md5 is only used for legacy md5 password verifiers.
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"golang.org/x/crypto/pbkdf2"
+)
+
+// Password encryption methods, as accepted by the password_encryption server
+// setting.
+const (
+	PasswordEncryptionSCRAM = "scram-sha-256"
+	PasswordEncryptionMD5   = "md5"
+)
+
+const (
+	scramPrefix     = "SCRAM-SHA-256"
+	scramIterations = 4096
+	scramSaltLength = 16
+	md5Prefix       = "md5"
+)
+
+// EncryptPassword returns the verifier PostgreSQL stores for the supplied
+// password, so that the password itself never needs to be sent to the server.
+// The role name is used as the salt of md5 verifiers. SCRAM-SHA-256 is used if
+// no method is supplied.
+func EncryptPassword(password, role, method string) (string, error) {
+	switch method {
+	case "", PasswordEncryptionSCRAM:
+		if !isASCII(password) {
+			return "", errors.New("SCRAM-SHA-256 passwords must only contain ASCII characters, because SASLprep normalisation is not supported")
+		}
+		salt := make([]byte, scramSaltLength)
+		if _, err := rand.Read(salt); err != nil {
+			return "", err
+		}
+		return scramVerifier(password, salt, scramIterations), nil
+	case PasswordEncryptionMD5:
+		return md5Verifier(password, role), nil
+	}
+	return "", fmt.Errorf("unsupported password encryption method %q", method)
+}
+
+// VerifyPassword returns true if the supplied verifier, as stored in
+// pg_authid.rolpassword, was computed from the supplied password.
+func VerifyPassword(verifier, role, password string) bool {
+	switch {
+	case strings.HasPrefix(verifier, scramPrefix+"$"):
+		return verifyScram(verifier, password)
+	case strings.HasPrefix(verifier, md5Prefix) && len(verifier) == len(md5Prefix)+2*md5.Size:
+		return subtle.ConstantTimeCompare([]byte(verifier), []byte(md5Verifier(password, role))) == 1
+	}
+	return false
+}
+
+// PasswordEncryptionOf returns the method used to compute the supplied
+// verifier, as stored in pg_authid.rolpassword, or an empty string if the
+// verifier is not recognised.
+func PasswordEncryptionOf(verifier string) string {
+	switch {
+	case strings.HasPrefix(verifier, scramPrefix+"$"):
+		return PasswordEncryptionSCRAM
+	case strings.HasPrefix(verifier, md5Prefix) && len(verifier) == len(md5Prefix)+2*md5.Size:
+		return PasswordEncryptionMD5
+	}
+	return ""
+}
+
+// scramVerifier computes a verifier in the format described by RFC 5803,
+// i.e. SCRAM-SHA-256$<iterations>:<salt>$<StoredKey>:<ServerKey>. Unlike the
+// server we don't normalise the password using SASLprep. SASLprep leaves ASCII
+// passwords unchanged, so callers must reject any other password.
+func scramVerifier(password string, salt []byte, iterations int) string {
+	storedKey, serverKey := scramKeys(password, salt, iterations)
+	return fmt.Sprintf("%s$%d:%s$%s:%s",
+		scramPrefix,
+		iterations,
+		base64.StdEncoding.EncodeToString(salt),
+		base64.StdEncoding.EncodeToString(storedKey),
+		base64.StdEncoding.EncodeToString(serverKey),
+	)
+}
+
+func scramKeys(password string, salt []byte, iterations int) (storedKey, serverKey []byte) {
+	salted := pbkdf2.Key([]byte(password), salt, iterations, sha256.Size, sha256.New)
+
+	clientKey := hmacSHA256(salted, "Client Key")
+	sk := sha256.Sum256(clientKey)
+	return sk[:], hmacSHA256(salted, "Server Key")
+}
+
+func verifyScram(verifier, password string) bool {
+	// We can't compute the verifier of passwords SASLprep would normalise.
+	if !isASCII(password) {
+		return false
+	}
+
+	// SCRAM-SHA-256$<iterations>:<salt>$<StoredKey>:<ServerKey>
+	parts := strings.Split(verifier, "$")
+	if len(parts) != 3 {
+		return false
+	}
+	params := strings.Split(parts[1], ":")
+	keys := strings.Split(parts[2], ":")
+	if len(params) != 2 || len(keys) != 2 {
+		return false
+	}
+
+	iterations, err := strconv.Atoi(params[0])
+	if err != nil || iterations < 1 {
+		return false
+	}
+	salt, err := base64.StdEncoding.DecodeString(params[1])
+	if err != nil {
+		return false
+	}
+
+	storedKey, serverKey := scramKeys(password, salt, iterations)
+	return subtle.ConstantTimeCompare([]byte(keys[0]), []byte(base64.StdEncoding.EncodeToString(storedKey))) == 1 &&
+		subtle.ConstantTimeCompare([]byte(keys[1]), []byte(base64.StdEncoding.EncodeToString(serverKey))) == 1
+}
+
+func md5Verifier(password, role string) string {
+	sum := md5.Sum([]byte(password + role)) //nolint:gosec // See import.
+	return md5Prefix + hex.EncodeToString(sum[:])
+}
+
+func isASCII(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] > unicode.MaxASCII {
+			return false
+		}
+	}
+	return true
+}
+
+func hmacSHA256(key []byte, msg string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(msg)) //nolint:errcheck // hash.Hash writes never fail.
+	return h.Sum(nil)
+}
@@ -0,0 +1,101 @@
+package postgresql
+
+import (
+	"encoding/base64"
+	"strings"
+	"testing"
+)
+
+const (
+	// Computed for password "pencil" and role "user", using the salt of the
+	// RFC 7677 example exchange.
+	scramPencil = "SCRAM-SHA-256$4096:W22ZaJ0SNY7soEsUEjb6gQ==$WG5d8oPm3OtcPnkdi4Uo7BkeZkBFzpcXkuLmtbsT4qY=:wfPLwcE6nTWhTAmQ7tl2KeoiWGPlZqQxSrmfPwDl2dU="
+	md5Pencil   = "md520c46e3762c864548e296b33c3406aa9"
+)
+
+func TestScramVerifier(t *testing.T) {
+	salt, _ := base64.StdEncoding.DecodeString("W22ZaJ0SNY7soEsUEjb6gQ==")
+	if got := scramVerifier("pencil", salt, 4096); got != scramPencil {
+		t.Errorf("scramVerifier(...): want %s, got %s", scramPencil, got)
+	}
+}
+
+func TestEncryptPassword(t *testing.T) {
+	cases := map[string]struct {
+		method string
+		prefix string
+	}{
+		"Default": {method: "", prefix: "SCRAM-SHA-256$4096:"},
+		"SCRAM":   {method: PasswordEncryptionSCRAM, prefix: "SCRAM-SHA-256$4096:"},
+		"MD5":     {method: PasswordEncryptionMD5, prefix: "md5"},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			v, err := EncryptPassword("pencil", "user", tc.method)
+			if err != nil {
+				t.Fatalf("EncryptPassword(...): %s", err)
+			}
+			if !strings.HasPrefix(v, tc.prefix) {
+				t.Errorf("EncryptPassword(...): want prefix %s, got %s", tc.prefix, v)
+			}
+			if strings.Contains(v, "pencil") {
+				t.Errorf("EncryptPassword(...): verifier contains the password: %s", v)
+			}
+			if !VerifyPassword(v, "user", "pencil") {
+				t.Errorf("VerifyPassword(...): verifier %s does not match its password", v)
+			}
+		})
+	}
+
+	if _, err := EncryptPassword("pencil", "user", "password"); err == nil {
+		t.Errorf("EncryptPassword(...): want error for unsupported method")
+	}
+	if _, err := EncryptPassword("crayón", "user", PasswordEncryptionSCRAM); err == nil {
+		t.Errorf("EncryptPassword(...): want error for non-ASCII SCRAM password")
+	}
+}
+
+func TestVerifyPassword(t *testing.T) {
+	cases := map[string]struct {
+		verifier string
+		role     string
+		password string
+		want     bool
+	}{
+		"SCRAMMatch":    {verifier: scramPencil, role: "user", password: "pencil", want: true},
+		"SCRAMMismatch": {verifier: scramPencil, role: "user", password: "crayon", want: false},
+		"SCRAMInvalid":  {verifier: "SCRAM-SHA-256$4096$", role: "user", password: "pencil", want: false},
+		"SCRAMNonASCII": {verifier: scramPencil, role: "user", password: "péncil", want: false},
+		"MD5Match":      {verifier: md5Pencil, role: "user", password: "pencil", want: true},
+		"MD5OtherRole":  {verifier: md5Pencil, role: "other", password: "pencil", want: false},
+		"Plaintext":     {verifier: "pencil", role: "user", password: "pencil", want: false},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if got := VerifyPassword(tc.verifier, tc.role, tc.password); got != tc.want {
+				t.Errorf("VerifyPassword(...): want %t, got %t", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestPasswordEncryptionOf(t *testing.T) {
+	cases := map[string]struct {
+		verifier string
+		want     string
+	}{
+		"SCRAM":     {verifier: scramPencil, want: PasswordEncryptionSCRAM},
+		"MD5":       {verifier: md5Pencil, want: PasswordEncryptionMD5},
+		"Plaintext": {verifier: "pencil", want: ""},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if got := PasswordEncryptionOf(tc.verifier); got != tc.want {
+				t.Errorf("PasswordEncryptionOf(...): want %q, got %q", tc.want, got)
+			}
+		})
+	}
+}
@@ -16,7 +16,8 @@ import (
 const (
 	// https://www.postgresql.org/docs/current/errcodes-appendix.html
 	// These are not available as part of the pq library.
-	pqInvalidCatalog = pq.ErrorCode("3D000")
+	pqInvalidCatalog        = pq.ErrorCode("3D000")
+	pqInsufficientPrivilege = pq.ErrorCode("42501")
 )
 
 type postgresDB struct {
@@ -146,3 +147,13 @@ func IsInvalidCatalog(err error) bool {
 	}
 	return false
 }
+
+// IsInsufficientPrivilege returns true if passed a pq error indicating
+// that the provider's role lacks the privilege to run a query.
+func IsInsufficientPrivilege(err error) bool {
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) {
+		return pqErr.Code == pqInsufficientPrivilege
+	}
+	return false
+}
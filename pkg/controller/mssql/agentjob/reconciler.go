@@ -0,0 +1,566 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package agentjob
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/pointer"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/crossplane-contrib/provider-sql/apis/mssql/v1alpha1"
+	"github.com/crossplane-contrib/provider-sql/pkg/clients/mssql"
+	"github.com/crossplane-contrib/provider-sql/pkg/clients/xsql"
+)
+
+const (
+	errTrackPCUsage = "cannot track ProviderConfig usage"
+	errGetPC        = "cannot get ProviderConfig"
+	errNoSecretRef  = "ProviderConfig does not reference a credentials Secret"
+	errGetSecret    = "cannot get credentials Secret"
+
+	errNotAgentJob     = "managed resource is not an AgentJob custom resource"
+	errSelectJob       = "cannot select agent job"
+	errSelectSteps     = "cannot select agent job steps"
+	errSelectSchedules = "cannot select agent job schedules"
+	errSelectLastRun   = "cannot select last run of agent job"
+	errInvalidSchedule = "invalid agent job schedule"
+	errCreateJob       = "cannot create agent job"
+	errUpdateJob       = "cannot update agent job"
+	errDropJob         = "cannot drop agent job"
+
+	errNoDaysOfWeek = "weekly schedule %s has no days of week"
+
+	maxConcurrency = 5
+)
+
+// Agent jobs and their history live in msdb.
+const msdb = "msdb"
+
+// Values of freq_type in msdb.dbo.sysschedules.
+var frequencies = map[string]int{
+	"Daily":      4,
+	"Weekly":     8,
+	"Monthly":    16,
+	"AgentStart": 64,
+	"Idle":       128,
+}
+
+// Values of freq_interval in msdb.dbo.sysschedules for weekly schedules,
+// which are or-ed together.
+var weekdays = map[v1alpha1.Weekday]int{
+	"Sunday":    1,
+	"Monday":    2,
+	"Tuesday":   4,
+	"Wednesday": 8,
+	"Thursday":  16,
+	"Friday":    32,
+	"Saturday":  64,
+}
+
+// Values of freq_subday_type in msdb.dbo.sysschedules.
+var repeatUnits = map[string]int{
+	"Seconds": 2,
+	"Minutes": 4,
+	"Hours":   8,
+}
+
+// Values of run_status in msdb.dbo.sysjobhistory.
+var outcomes = []string{"Failed", "Succeeded", "Retry", "Canceled", "InProgress"}
+
+// Setup adds a controller that reconciles AgentJob managed resources.
+func Setup(mgr ctrl.Manager, l logging.Logger) error {
+	name := managed.ControllerName(v1alpha1.AgentJobGroupKind)
+
+	t := resource.NewProviderConfigUsageTracker(mgr.GetClient(), &v1alpha1.ProviderConfigUsage{})
+	r := managed.NewReconciler(mgr,
+		resource.ManagedKind(v1alpha1.AgentJobGroupVersionKind),
+		managed.WithExternalConnecter(&connector{kube: mgr.GetClient(), usage: t, newClient: mssql.New}),
+		managed.WithLogger(l.WithValues("controller", name)),
+		managed.WithPollInterval(10*time.Minute),
+		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))))
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		For(&v1alpha1.AgentJob{}).
+		WithOptions(controller.Options{
+			MaxConcurrentReconciles: maxConcurrency,
+		}).
+		Complete(r)
+}
+
+type connector struct {
+	kube      client.Client
+	usage     resource.Tracker
+	newClient func(creds map[string][]byte, database string) xsql.DB
+}
+
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	cr, ok := mg.(*v1alpha1.AgentJob)
+	if !ok {
+		return nil, errors.New(errNotAgentJob)
+	}
+
+	if err := c.usage.Track(ctx, mg); err != nil {
+		return nil, errors.Wrap(err, errTrackPCUsage)
+	}
+
+	// ProviderConfigReference could theoretically be nil, but in practice the
+	// DefaultProviderConfig initializer will set it before we get here.
+	pc := &v1alpha1.ProviderConfig{}
+	if err := c.kube.Get(ctx, types.NamespacedName{Name: cr.GetProviderConfigReference().Name}, pc); err != nil {
+		return nil, errors.Wrap(err, errGetPC)
+	}
+
+	// We don't need to check the credentials source because we currently only
+	// support one source (MSSQLConnectionSecret), which is required and
+	// enforced by the ProviderConfig schema.
+	ref := pc.Spec.Credentials.ConnectionSecretRef
+	if ref == nil {
+		return nil, errors.New(errNoSecretRef)
+	}
+
+	s := &corev1.Secret{}
+	if err := c.kube.Get(ctx, types.NamespacedName{Namespace: ref.Namespace, Name: ref.Name}, s); err != nil {
+		return nil, errors.Wrap(err, errGetSecret)
+	}
+
+	return &external{db: c.newClient(s.Data, msdb)}, nil
+}
+
+type external struct{ db xsql.DB }
+
+// A job as stored in msdb.dbo.sysjobs.
+type job struct {
+	id          string
+	enabled     bool
+	description *string
+}
+
+// A step as stored in msdb.dbo.sysjobsteps.
+type step struct {
+	name      string
+	subsystem string
+	command   string
+	database  *string
+}
+
+// A schedule as stored in msdb.dbo.sysschedules.
+type schedule struct {
+	id             int
+	name           string
+	enabled        bool
+	freqType       int
+	freqInterval   int
+	subdayType     int
+	subdayInterval int
+	recurrence     int
+	startTime      int
+}
+
+func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.AgentJob)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotAgentJob)
+	}
+
+	name := meta.GetExternalName(cr)
+	j := job{}
+	query := "SELECT CONVERT(nvarchar(36), job_id), enabled, description FROM msdb.dbo.sysjobs WHERE name = @p1"
+	err := c.db.Scan(ctx, xsql.Query{String: query, Parameters: []interface{}{name}}, &j.id, &j.enabled, &j.description)
+	if xsql.IsNoRows(err) {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errSelectJob)
+	}
+
+	steps, err := c.steps(ctx, name)
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
+	schedules, err := c.schedules(ctx, name)
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
+	desired, err := toSchedules(cr.Spec.ForProvider.Schedules)
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errInvalidSchedule)
+	}
+	lastRun, err := c.lastRun(ctx, name)
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
+
+	cr.Status.AtProvider = v1alpha1.AgentJobObservation{JobID: j.id, LastRun: lastRun}
+	cr.SetConditions(xpv1.Available())
+
+	li := lateInit(&cr.Spec.ForProvider, j)
+
+	return managed.ExternalObservation{
+		ResourceExists:          true,
+		ResourceLateInitialized: li,
+		ResourceUpToDate: jobUpToDate(cr.Spec.ForProvider, j) &&
+			stepsUpToDate(cr.Spec.ForProvider.Steps, steps) &&
+			schedulesUpToDate(desired, schedules),
+	}, nil
+}
+
+func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1alpha1.AgentJob)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotAgentJob)
+	}
+
+	name := meta.GetExternalName(cr)
+	jp := cr.Spec.ForProvider
+	schedules, err := toSchedules(jp.Schedules)
+	if err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errInvalidSchedule)
+	}
+
+	// The job must be targeted at the local server for SQL Server Agent to
+	// run it.
+	ql := []xsql.Query{
+		{
+			String:     "EXEC msdb.dbo.sp_add_job @job_name = @p1, @enabled = @p2, @description = @p3",
+			Parameters: []interface{}{name, pointer.BoolDeref(jp.Enabled, true), jp.Description},
+		},
+		{
+			String:     "EXEC msdb.dbo.sp_add_jobserver @job_name = @p1, @server_name = N'(local)'",
+			Parameters: []interface{}{name},
+		},
+	}
+	ql = append(ql, addStepQueries(name, jp.Steps)...)
+	for _, s := range schedules {
+		ql = append(ql, addScheduleQuery(name, s))
+	}
+
+	err = c.db.ExecTx(ctx, ql)
+	return managed.ExternalCreation{}, errors.Wrap(err, errCreateJob)
+}
+
+func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*v1alpha1.AgentJob)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotAgentJob)
+	}
+
+	name := meta.GetExternalName(cr)
+	jp := cr.Spec.ForProvider
+	desired, err := toSchedules(jp.Schedules)
+	if err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errInvalidSchedule)
+	}
+	steps, err := c.steps(ctx, name)
+	if err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+	schedules, err := c.schedules(ctx, name)
+	if err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+
+	ql := []xsql.Query{{
+		String:     "EXEC msdb.dbo.sp_update_job @job_name = @p1, @enabled = @p2, @description = @p3",
+		Parameters: []interface{}{name, pointer.BoolDeref(jp.Enabled, true), jp.Description},
+	}}
+
+	// Steps refer to each other by their position, so we replace all of them
+	// rather than trying to patch them up one by one. Step 0 stands for all
+	// steps of the job.
+	if !stepsUpToDate(jp.Steps, steps) {
+		ql = append(ql, xsql.Query{
+			String:     "EXEC msdb.dbo.sp_delete_jobstep @job_name = @p1, @step_id = 0",
+			Parameters: []interface{}{name},
+		})
+		ql = append(ql, addStepQueries(name, jp.Steps)...)
+	}
+
+	if !schedulesUpToDate(desired, schedules) {
+		for _, s := range schedules {
+			ql = append(ql, xsql.Query{
+				String:     "EXEC msdb.dbo.sp_detach_schedule @job_name = @p1, @schedule_id = @p2, @delete_unused_schedule = 1",
+				Parameters: []interface{}{name, s.id},
+			})
+		}
+		for _, s := range desired {
+			ql = append(ql, addScheduleQuery(name, s))
+		}
+	}
+
+	err = c.db.ExecTx(ctx, ql)
+	return managed.ExternalUpdate{}, errors.Wrap(err, errUpdateJob)
+}
+
+func (c *external) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1alpha1.AgentJob)
+	if !ok {
+		return errors.New(errNotAgentJob)
+	}
+
+	query := "IF EXISTS (SELECT 1 FROM msdb.dbo.sysjobs WHERE name = @p1) " +
+		"EXEC msdb.dbo.sp_delete_job @job_name = @p1, @delete_unused_schedule = 1"
+	err := c.db.Exec(ctx, xsql.Query{String: query, Parameters: []interface{}{meta.GetExternalName(cr)}})
+	return errors.Wrap(err, errDropJob)
+}
+
+func (c *external) steps(ctx context.Context, name string) ([]step, error) {
+	query := `SELECT s.step_name, s.subsystem, s.command, s.database_name
+	FROM msdb.dbo.sysjobsteps AS s
+	JOIN msdb.dbo.sysjobs AS j ON j.job_id = s.job_id
+	WHERE j.name = @p1
+	ORDER BY s.step_id`
+	rows, err := c.db.Query(ctx, xsql.Query{String: query, Parameters: []interface{}{name}})
+	if err != nil {
+		return nil, errors.Wrap(err, errSelectSteps)
+	}
+	defer rows.Close() //nolint:errcheck
+
+	var steps []step
+	for rows.Next() {
+		s := step{}
+		if err := rows.Scan(&s.name, &s.subsystem, &s.command, &s.database); err != nil {
+			return nil, errors.Wrap(err, errSelectSteps)
+		}
+		steps = append(steps, s)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(err, errSelectSteps)
+	}
+	return steps, nil
+}
+
+func (c *external) schedules(ctx context.Context, name string) ([]schedule, error) {
+	query := `SELECT sc.schedule_id, sc.name, sc.enabled, sc.freq_type, sc.freq_interval,
+	  sc.freq_subday_type, sc.freq_subday_interval, sc.freq_recurrence_factor, sc.active_start_time
+	FROM msdb.dbo.sysjobschedules AS js
+	JOIN msdb.dbo.sysschedules AS sc ON sc.schedule_id = js.schedule_id
+	JOIN msdb.dbo.sysjobs AS j ON j.job_id = js.job_id
+	WHERE j.name = @p1`
+	rows, err := c.db.Query(ctx, xsql.Query{String: query, Parameters: []interface{}{name}})
+	if err != nil {
+		return nil, errors.Wrap(err, errSelectSchedules)
+	}
+	defer rows.Close() //nolint:errcheck
+
+	var schedules []schedule
+	for rows.Next() {
+		s := schedule{}
+		if err := rows.Scan(&s.id, &s.name, &s.enabled, &s.freqType, &s.freqInterval,
+			&s.subdayType, &s.subdayInterval, &s.recurrence, &s.startTime); err != nil {
+			return nil, errors.Wrap(err, errSelectSchedules)
+		}
+		schedules = append(schedules, s)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(err, errSelectSchedules)
+	}
+	return schedules, nil
+}
+
+// lastRun returns the outcome of the last run of the job, which SQL Server
+// Agent records as step 0 in the job history.
+func (c *external) lastRun(ctx context.Context, name string) (*v1alpha1.AgentJobRun, error) {
+	var status int
+	var message string
+	var start time.Time
+	query := `SELECT TOP 1 h.run_status, h.message, msdb.dbo.agent_datetime(h.run_date, h.run_time)
+	FROM msdb.dbo.sysjobhistory AS h
+	JOIN msdb.dbo.sysjobs AS j ON j.job_id = h.job_id
+	WHERE j.name = @p1 AND h.step_id = 0
+	ORDER BY h.instance_id DESC`
+	err := c.db.Scan(ctx, xsql.Query{String: query, Parameters: []interface{}{name}}, &status, &message, &start)
+	if xsql.IsNoRows(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, errSelectLastRun)
+	}
+
+	run := &v1alpha1.AgentJobRun{Message: message, StartTime: &metav1.Time{Time: start}}
+	if status >= 0 && status < len(outcomes) {
+		run.Outcome = outcomes[status]
+	}
+	return run, nil
+}
+
+func addStepQueries(name string, steps []v1alpha1.AgentJobStep) []xsql.Query {
+	ql := make([]xsql.Query, len(steps))
+	for i, s := range steps {
+		// Every step but the last goes on to the next one when it succeeds,
+		// and the job quits with a failure as soon as a step fails.
+		onSuccess := 3
+		if i == len(steps)-1 {
+			onSuccess = 1
+		}
+		ql[i] = xsql.Query{
+			String: fmt.Sprintf("EXEC msdb.dbo.sp_add_jobstep @job_name = @p1, @step_name = @p2, @subsystem = @p3, "+
+				"@command = @p4, @database_name = @p5, @on_success_action = %d, @on_fail_action = 2", onSuccess),
+			Parameters: []interface{}{name, s.Name, pointer.StringDeref(s.Subsystem, "TSQL"), s.Command, s.Database},
+		}
+	}
+	return ql
+}
+
+func addScheduleQuery(name string, s schedule) xsql.Query {
+	return xsql.Query{
+		String: fmt.Sprintf("EXEC msdb.dbo.sp_add_jobschedule @job_name = @p1, @name = @p2, @enabled = @p3, "+
+			"@freq_type = %d, @freq_interval = %d, @freq_subday_type = %d, @freq_subday_interval = %d, "+
+			"@freq_recurrence_factor = %d, @active_start_time = %d",
+			s.freqType, s.freqInterval, s.subdayType, s.subdayInterval, s.recurrence, s.startTime),
+		Parameters: []interface{}{name, s.name, s.enabled},
+	}
+}
+
+// toSchedules returns the schedules as SQL Server Agent stores them, sorted
+// by name.
+func toSchedules(in []v1alpha1.AgentJobSchedule) ([]schedule, error) {
+	out := make([]schedule, 0, len(in))
+	for _, s := range in {
+		sc, err := toSchedule(s)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, sc)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].name < out[j].name })
+	return out, nil
+}
+
+func toSchedule(s v1alpha1.AgentJobSchedule) (schedule, error) {
+	sc := schedule{
+		name:     s.Name,
+		enabled:  pointer.BoolDeref(s.Enabled, true),
+		freqType: frequencies[s.Frequency],
+	}
+
+	// Schedules that run when SQL Server Agent starts or the CPU is idle
+	// have neither intervals nor a start time.
+	if s.Frequency == "AgentStart" || s.Frequency == "Idle" {
+		return sc, nil
+	}
+
+	interval := pointer.IntDeref(s.Interval, 1)
+	switch s.Frequency {
+	case "Daily":
+		sc.freqInterval = interval
+	case "Weekly":
+		for _, d := range s.DaysOfWeek {
+			sc.freqInterval |= weekdays[d]
+		}
+		if sc.freqInterval == 0 {
+			return schedule{}, errors.Errorf(errNoDaysOfWeek, s.Name)
+		}
+		sc.recurrence = interval
+	case "Monthly":
+		sc.freqInterval = pointer.IntDeref(s.DayOfMonth, 1)
+		sc.recurrence = interval
+	}
+
+	// SQL Server Agent stores the start time as an HHMMSS integer.
+	start, err := strconv.Atoi(strings.ReplaceAll(pointer.StringDeref(s.StartTime, "00:00:00"), ":", ""))
+	if err != nil {
+		return schedule{}, errors.Wrapf(err, "cannot parse start time of schedule %s", s.Name)
+	}
+	sc.startTime = start
+
+	sc.subdayType = 1
+	if s.Repeat != nil {
+		sc.subdayType = repeatUnits[s.Repeat.Unit]
+		sc.subdayInterval = s.Repeat.Interval
+	}
+	return sc, nil
+}
+
+func lateInit(jp *v1alpha1.AgentJobParameters, j job) bool {
+	li := false
+	if jp.Enabled == nil {
+		jp.Enabled = pointer.Bool(j.enabled)
+		li = true
+	}
+	if jp.Description == nil && j.description != nil {
+		jp.Description = j.description
+		li = true
+	}
+	return li
+}
+
+func jobUpToDate(jp v1alpha1.AgentJobParameters, j job) bool {
+	if jp.Enabled != nil && *jp.Enabled != j.enabled {
+		return false
+	}
+	if jp.Description != nil && *jp.Description != pointer.StringDeref(j.description, "") {
+		return false
+	}
+	return true
+}
+
+func stepsUpToDate(desired []v1alpha1.AgentJobStep, observed []step) bool {
+	if len(desired) != len(observed) {
+		return false
+	}
+	for i, d := range desired {
+		o := observed[i]
+		if d.Name != o.name || d.Command != o.command {
+			return false
+		}
+		if !strings.EqualFold(pointer.StringDeref(d.Subsystem, "TSQL"), o.subsystem) {
+			return false
+		}
+		if d.Database != nil && *d.Database != pointer.StringDeref(o.database, "") {
+			return false
+		}
+	}
+	return true
+}
+
+// schedulesUpToDate compares the desired schedules, which are sorted by name,
+// with the observed ones, ignoring the IDs assigned by SQL Server Agent.
+func schedulesUpToDate(desired, observed []schedule) bool {
+	if len(desired) != len(observed) {
+		return false
+	}
+	o := make([]schedule, len(observed))
+	copy(o, observed)
+	sort.Slice(o, func(i, j int) bool { return o[i].name < o[j].name })
+	for i := range desired {
+		o[i].id = 0
+		if desired[i] != o[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,797 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package agentjob
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane-contrib/provider-sql/apis/mssql/v1alpha1"
+	"github.com/crossplane-contrib/provider-sql/pkg/clients/xsql"
+)
+
+type mockDB struct {
+	MockExec                 func(ctx context.Context, q xsql.Query) error
+	MockExecTx               func(ctx context.Context, ql []xsql.Query) error
+	MockScan                 func(ctx context.Context, q xsql.Query, dest ...interface{}) error
+	MockQuery                func(ctx context.Context, q xsql.Query) (*sql.Rows, error)
+	MockGetConnectionDetails func(username, password string) managed.ConnectionDetails
+}
+
+func (m mockDB) Exec(ctx context.Context, q xsql.Query) error {
+	return m.MockExec(ctx, q)
+}
+func (m mockDB) ExecTx(ctx context.Context, ql []xsql.Query) error {
+	return m.MockExecTx(ctx, ql)
+}
+func (m mockDB) Scan(ctx context.Context, q xsql.Query, dest ...interface{}) error {
+	return m.MockScan(ctx, q, dest...)
+}
+func (m mockDB) Query(ctx context.Context, q xsql.Query) (*sql.Rows, error) {
+	return m.MockQuery(ctx, q)
+}
+func (m mockDB) GetConnectionDetails(username, password string) managed.ConnectionDetails {
+	return m.MockGetConnectionDetails(username, password)
+}
+
+func mockRowsToSQLRows(mockRows *sqlmock.Rows) *sql.Rows {
+	db, mock, _ := sqlmock.New()
+	mock.ExpectQuery("select").WillReturnRows(mockRows)
+	rows, err := db.Query("select")
+	if err != nil {
+		println("%v", err)
+		return nil
+	}
+	return rows
+}
+
+var stepColumns = []string{"step_name", "subsystem", "command", "database_name"}
+
+var scheduleColumns = []string{"schedule_id", "name", "enabled", "freq_type", "freq_interval",
+	"freq_subday_type", "freq_subday_interval", "freq_recurrence_factor", "active_start_time"}
+
+// queryJob returns a MockQuery that selects the supplied steps and schedules.
+func queryJob(steps, schedules *sqlmock.Rows) func(ctx context.Context, q xsql.Query) (*sql.Rows, error) {
+	return func(ctx context.Context, q xsql.Query) (*sql.Rows, error) {
+		if strings.Contains(q.String, "sysjobsteps") {
+			return mockRowsToSQLRows(steps), nil
+		}
+		return mockRowsToSQLRows(schedules), nil
+	}
+}
+
+// scanJob returns a MockScan that selects an enabled job without history.
+func scanJob(description *string) func(ctx context.Context, q xsql.Query, dest ...interface{}) error {
+	return func(ctx context.Context, q xsql.Query, dest ...interface{}) error {
+		if strings.Contains(q.String, "sysjobhistory") {
+			return sql.ErrNoRows
+		}
+		*dest[0].(*string) = "8d2f3c1e-0000-0000-0000-000000000000"
+		*dest[1].(*bool) = true
+		*dest[2].(**string) = description
+		return nil
+	}
+}
+
+func nightly() v1alpha1.AgentJobParameters {
+	return v1alpha1.AgentJobParameters{
+		Description: pointer.String("Nightly cleanup"),
+		Enabled:     pointer.Bool(true),
+		Steps: []v1alpha1.AgentJobStep{
+			{Name: "cleanup", Command: "EXEC dbo.cleanup", Database: pointer.String("example-db")},
+		},
+		Schedules: []v1alpha1.AgentJobSchedule{
+			{Name: "nightly", Frequency: "Daily", StartTime: pointer.String("02:30:00")},
+		},
+	}
+}
+
+func nightlySteps() *sqlmock.Rows {
+	return sqlmock.NewRows(stepColumns).AddRow("cleanup", "TSQL", "EXEC dbo.cleanup", "example-db")
+}
+
+func nightlySchedules() *sqlmock.Rows {
+	return sqlmock.NewRows(scheduleColumns).AddRow(7, "nightly", true, 4, 1, 1, 0, 0, 23000)
+}
+
+func TestConnect(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type fields struct {
+		kube  client.Client
+		usage resource.Tracker
+	}
+
+	type args struct {
+		ctx context.Context
+		mg  resource.Managed
+	}
+
+	cases := map[string]struct {
+		reason string
+		fields fields
+		args   args
+		want   error
+	}{
+		"ErrNotAgentJob": {
+			reason: "An error should be returned if the managed resource is not an *AgentJob",
+			args: args{
+				mg: nil,
+			},
+			want: errors.New(errNotAgentJob),
+		},
+		"ErrTrackProviderConfigUsage": {
+			reason: "An error should be returned if we can't track our ProviderConfig usage",
+			fields: fields{
+				usage: resource.TrackerFn(func(ctx context.Context, mg resource.Managed) error { return errBoom }),
+			},
+			args: args{
+				mg: &v1alpha1.AgentJob{},
+			},
+			want: errors.Wrap(errBoom, errTrackPCUsage),
+		},
+		"ErrGetProviderConfig": {
+			reason: "An error should be returned if we can't get our ProviderConfig",
+			fields: fields{
+				kube: &test.MockClient{
+					MockGet: test.NewMockGetFn(errBoom),
+				},
+				usage: resource.TrackerFn(func(ctx context.Context, mg resource.Managed) error { return nil }),
+			},
+			args: args{
+				mg: &v1alpha1.AgentJob{
+					Spec: v1alpha1.AgentJobSpec{
+						ResourceSpec: xpv1.ResourceSpec{
+							ProviderConfigReference: &xpv1.Reference{},
+						},
+					},
+				},
+			},
+			want: errors.Wrap(errBoom, errGetPC),
+		},
+		"ErrMissingConnectionSecret": {
+			reason: "An error should be returned if our ProviderConfig doesn't specify a connection secret",
+			fields: fields{
+				kube: &test.MockClient{
+					// We call get to populate the AgentJob struct, then again
+					// to populate the (empty) ProviderConfig struct, resulting
+					// in a ProviderConfig with a nil connection secret.
+					MockGet: test.NewMockGetFn(nil),
+				},
+				usage: resource.TrackerFn(func(ctx context.Context, mg resource.Managed) error { return nil }),
+			},
+			args: args{
+				mg: &v1alpha1.AgentJob{
+					Spec: v1alpha1.AgentJobSpec{
+						ResourceSpec: xpv1.ResourceSpec{
+							ProviderConfigReference: &xpv1.Reference{},
+						},
+					},
+				},
+			},
+			want: errors.New(errNoSecretRef),
+		},
+		"ErrGetConnectionSecret": {
+			reason: "An error should be returned if we can't get our ProviderConfig's connection secret",
+			fields: fields{
+				kube: &test.MockClient{
+					MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+						switch o := obj.(type) {
+						case *v1alpha1.ProviderConfig:
+							o.Spec.Credentials.ConnectionSecretRef = &xpv1.SecretReference{}
+						case *corev1.Secret:
+							return errBoom
+						}
+						return nil
+					}),
+				},
+				usage: resource.TrackerFn(func(ctx context.Context, mg resource.Managed) error { return nil }),
+			},
+			args: args{
+				mg: &v1alpha1.AgentJob{
+					Spec: v1alpha1.AgentJobSpec{
+						ResourceSpec: xpv1.ResourceSpec{
+							ProviderConfigReference: &xpv1.Reference{},
+						},
+					},
+				},
+			},
+			want: errors.Wrap(errBoom, errGetSecret),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := &connector{kube: tc.fields.kube, usage: tc.fields.usage}
+			_, err := e.Connect(tc.args.ctx, tc.args.mg)
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Connect(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestObserve(t *testing.T) {
+	errBoom := errors.New("boom")
+	ran := time.Date(2021, 6, 1, 2, 30, 0, 0, time.UTC)
+
+	type fields struct {
+		db xsql.DB
+	}
+
+	type args struct {
+		ctx context.Context
+		mg  resource.Managed
+	}
+
+	type want struct {
+		o   managed.ExternalObservation
+		obs v1alpha1.AgentJobObservation
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		fields fields
+		args   args
+		want   want
+	}{
+		"ErrNotAgentJob": {
+			reason: "An error should be returned if the managed resource is not an *AgentJob",
+			args: args{
+				mg: nil,
+			},
+			want: want{
+				err: errors.New(errNotAgentJob),
+			},
+		},
+		"ErrNoJob": {
+			reason: "We should return ResourceExists: false when no job is found",
+			fields: fields{
+				db: mockDB{
+					MockScan: func(ctx context.Context, q xsql.Query, dest ...interface{}) error { return sql.ErrNoRows },
+				},
+			},
+			args: args{
+				mg: &v1alpha1.AgentJob{},
+			},
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: false},
+			},
+		},
+		"ErrSelectJob": {
+			reason: "We should return any errors encountered while trying to select the job",
+			fields: fields{
+				db: mockDB{
+					MockScan: func(ctx context.Context, q xsql.Query, dest ...interface{}) error { return errBoom },
+				},
+			},
+			args: args{
+				mg: &v1alpha1.AgentJob{},
+			},
+			want: want{
+				err: errors.Wrap(errBoom, errSelectJob),
+			},
+		},
+		"ErrSelectSteps": {
+			reason: "We should return any errors encountered while trying to select the steps of the job",
+			fields: fields{
+				db: mockDB{
+					MockScan:  scanJob(nil),
+					MockQuery: func(ctx context.Context, q xsql.Query) (*sql.Rows, error) { return nil, errBoom },
+				},
+			},
+			args: args{
+				mg: &v1alpha1.AgentJob{},
+			},
+			want: want{
+				err: errors.Wrap(errBoom, errSelectSteps),
+			},
+		},
+		"ErrInvalidSchedule": {
+			reason: "We should return an error if a weekly schedule has no days of week",
+			fields: fields{
+				db: mockDB{
+					MockScan:  scanJob(nil),
+					MockQuery: queryJob(nightlySteps(), nightlySchedules()),
+				},
+			},
+			args: args{
+				mg: &v1alpha1.AgentJob{
+					Spec: v1alpha1.AgentJobSpec{
+						ForProvider: v1alpha1.AgentJobParameters{
+							Schedules: []v1alpha1.AgentJobSchedule{{Name: "weekly", Frequency: "Weekly"}},
+						},
+					},
+				},
+			},
+			want: want{
+				err: errors.Wrap(errors.Errorf(errNoDaysOfWeek, "weekly"), errInvalidSchedule),
+			},
+		},
+		"Success": {
+			reason: "We should return no error if the job, its steps and schedules are as desired",
+			fields: fields{
+				db: mockDB{
+					MockScan:  scanJob(pointer.String("Nightly cleanup")),
+					MockQuery: queryJob(nightlySteps(), nightlySchedules()),
+				},
+			},
+			args: args{
+				mg: &v1alpha1.AgentJob{
+					Spec: v1alpha1.AgentJobSpec{
+						ForProvider: nightly(),
+					},
+				},
+			},
+			want: want{
+				o: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: true,
+				},
+				obs: v1alpha1.AgentJobObservation{JobID: "8d2f3c1e-0000-0000-0000-000000000000"},
+			},
+		},
+		"SuccessLateInit": {
+			reason: "We should late initialize the description and enabled state of the job",
+			fields: fields{
+				db: mockDB{
+					MockScan: scanJob(pointer.String("No description available.")),
+					MockQuery: queryJob(
+						sqlmock.NewRows(stepColumns).AddRow("run", "CmdExec", "echo hi", nil),
+						sqlmock.NewRows(scheduleColumns),
+					),
+				},
+			},
+			args: args{
+				mg: &v1alpha1.AgentJob{
+					Spec: v1alpha1.AgentJobSpec{
+						ForProvider: v1alpha1.AgentJobParameters{
+							Steps: []v1alpha1.AgentJobStep{
+								{Name: "run", Subsystem: pointer.String("CmdExec"), Command: "echo hi"},
+							},
+						},
+					},
+				},
+			},
+			want: want{
+				o: managed.ExternalObservation{
+					ResourceExists:          true,
+					ResourceUpToDate:        true,
+					ResourceLateInitialized: true,
+				},
+				obs: v1alpha1.AgentJobObservation{JobID: "8d2f3c1e-0000-0000-0000-000000000000"},
+			},
+		},
+		"SuccessStepsDrifted": {
+			reason: "We should report the job as not up to date when a step's command differs",
+			fields: fields{
+				db: mockDB{
+					MockScan: scanJob(pointer.String("Nightly cleanup")),
+					MockQuery: queryJob(
+						sqlmock.NewRows(stepColumns).AddRow("cleanup", "TSQL", "EXEC dbo.purge", "example-db"),
+						nightlySchedules(),
+					),
+				},
+			},
+			args: args{
+				mg: &v1alpha1.AgentJob{
+					Spec: v1alpha1.AgentJobSpec{
+						ForProvider: nightly(),
+					},
+				},
+			},
+			want: want{
+				o: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: false,
+				},
+				obs: v1alpha1.AgentJobObservation{JobID: "8d2f3c1e-0000-0000-0000-000000000000"},
+			},
+		},
+		"SuccessScheduleDrifted": {
+			reason: "We should report the job as not up to date when a schedule's start time differs",
+			fields: fields{
+				db: mockDB{
+					MockScan: scanJob(pointer.String("Nightly cleanup")),
+					MockQuery: queryJob(
+						nightlySteps(),
+						sqlmock.NewRows(scheduleColumns).AddRow(7, "nightly", true, 4, 1, 1, 0, 0, 0),
+					),
+				},
+			},
+			args: args{
+				mg: &v1alpha1.AgentJob{
+					Spec: v1alpha1.AgentJobSpec{
+						ForProvider: nightly(),
+					},
+				},
+			},
+			want: want{
+				o: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: false,
+				},
+				obs: v1alpha1.AgentJobObservation{JobID: "8d2f3c1e-0000-0000-0000-000000000000"},
+			},
+		},
+		"SuccessLastRun": {
+			reason: "We should report the outcome of the last run of the job",
+			fields: fields{
+				db: mockDB{
+					MockScan: func(ctx context.Context, q xsql.Query, dest ...interface{}) error {
+						if !strings.Contains(q.String, "sysjobhistory") {
+							return scanJob(pointer.String("Nightly cleanup"))(ctx, q, dest...)
+						}
+						*dest[0].(*int) = 0
+						*dest[1].(*string) = "The job failed."
+						*dest[2].(*time.Time) = ran
+						return nil
+					},
+					MockQuery: queryJob(nightlySteps(), nightlySchedules()),
+				},
+			},
+			args: args{
+				mg: &v1alpha1.AgentJob{
+					Spec: v1alpha1.AgentJobSpec{
+						ForProvider: nightly(),
+					},
+				},
+			},
+			want: want{
+				o: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: true,
+				},
+				obs: v1alpha1.AgentJobObservation{
+					JobID: "8d2f3c1e-0000-0000-0000-000000000000",
+					LastRun: &v1alpha1.AgentJobRun{
+						Outcome:   "Failed",
+						Message:   "The job failed.",
+						StartTime: &metav1.Time{Time: ran},
+					},
+				},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{db: tc.fields.db}
+			got, err := e.Observe(tc.args.ctx, tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, got); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+			if cr, ok := tc.args.mg.(*v1alpha1.AgentJob); ok {
+				if diff := cmp.Diff(tc.want.obs, cr.Status.AtProvider); diff != "" {
+					t.Errorf("\n%s\ne.Observe(...): -want observation, +got observation:\n%s\n", tc.reason, diff)
+				}
+			}
+		})
+	}
+}
+
+func TestCreate(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type fields struct {
+		db xsql.DB
+	}
+
+	type args struct {
+		ctx context.Context
+		mg  resource.Managed
+	}
+
+	type want struct {
+		c   managed.ExternalCreation
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		fields fields
+		args   args
+		want   want
+	}{
+		"ErrNotAgentJob": {
+			reason: "An error should be returned if the managed resource is not an *AgentJob",
+			args: args{
+				mg: nil,
+			},
+			want: want{
+				err: errors.New(errNotAgentJob),
+			},
+		},
+		"ErrExec": {
+			reason: "Any errors encountered while creating the job should be returned",
+			fields: fields{
+				db: &mockDB{
+					MockExecTx: func(ctx context.Context, ql []xsql.Query) error { return errBoom },
+				},
+			},
+			args: args{
+				mg: &v1alpha1.AgentJob{},
+			},
+			want: want{
+				err: errors.Wrap(errBoom, errCreateJob),
+			},
+		},
+		"Success": {
+			reason: "The job, its server, steps and schedules should be added in a single transaction",
+			fields: fields{
+				db: &mockDB{
+					MockExecTx: func(ctx context.Context, ql []xsql.Query) error {
+						want := []string{
+							"EXEC msdb.dbo.sp_add_job @job_name = @p1, @enabled = @p2, @description = @p3",
+							"EXEC msdb.dbo.sp_add_jobserver @job_name = @p1, @server_name = N'(local)'",
+							"EXEC msdb.dbo.sp_add_jobstep @job_name = @p1, @step_name = @p2, @subsystem = @p3, " +
+								"@command = @p4, @database_name = @p5, @on_success_action = 3, @on_fail_action = 2",
+							"EXEC msdb.dbo.sp_add_jobstep @job_name = @p1, @step_name = @p2, @subsystem = @p3, " +
+								"@command = @p4, @database_name = @p5, @on_success_action = 1, @on_fail_action = 2",
+							"EXEC msdb.dbo.sp_add_jobschedule @job_name = @p1, @name = @p2, @enabled = @p3, " +
+								"@freq_type = 8, @freq_interval = 34, @freq_subday_type = 4, @freq_subday_interval = 15, " +
+								"@freq_recurrence_factor = 1, @active_start_time = 80000",
+						}
+						got := make([]string, len(ql))
+						for i, q := range ql {
+							got[i] = q.String
+						}
+						if diff := cmp.Diff(want, got); diff != "" {
+							t.Errorf("-want, +got:\n%s", diff)
+						}
+						return nil
+					},
+				},
+			},
+			args: args{
+				mg: &v1alpha1.AgentJob{
+					ObjectMeta: metav1.ObjectMeta{
+						Annotations: map[string]string{meta.AnnotationKeyExternalName: "refresh"},
+					},
+					Spec: v1alpha1.AgentJobSpec{
+						ForProvider: v1alpha1.AgentJobParameters{
+							Steps: []v1alpha1.AgentJobStep{
+								{Name: "refresh", Command: "EXEC dbo.refresh"},
+								{Name: "notify", Subsystem: pointer.String("PowerShell"), Command: "Write-Output done"},
+							},
+							Schedules: []v1alpha1.AgentJobSchedule{{
+								Name:       "business-hours",
+								Frequency:  "Weekly",
+								DaysOfWeek: []v1alpha1.Weekday{"Monday", "Friday"},
+								StartTime:  pointer.String("08:00:00"),
+								Repeat:     &v1alpha1.AgentJobScheduleRepeat{Unit: "Minutes", Interval: 15},
+							}},
+						},
+					},
+				},
+			},
+			want: want{
+				err: nil,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{db: tc.fields.db}
+			got, err := e.Create(tc.args.ctx, tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Create(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.c, got); diff != "" {
+				t.Errorf("\n%s\ne.Create(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type fields struct {
+		db xsql.DB
+	}
+
+	type args struct {
+		ctx context.Context
+		mg  resource.Managed
+	}
+
+	type want struct {
+		queries []string
+		err     error
+	}
+
+	cases := map[string]struct {
+		reason string
+		fields fields
+		args   args
+		want   want
+	}{
+		"ErrNotAgentJob": {
+			reason: "An error should be returned if the managed resource is not an *AgentJob",
+			args: args{
+				mg: nil,
+			},
+			want: want{
+				err: errors.New(errNotAgentJob),
+			},
+		},
+		"ErrExec": {
+			reason: "Any errors encountered while updating the job should be returned",
+			fields: fields{
+				db: &mockDB{
+					MockQuery:  queryJob(nightlySteps(), nightlySchedules()),
+					MockExecTx: func(ctx context.Context, ql []xsql.Query) error { return errBoom },
+				},
+			},
+			args: args{
+				mg: &v1alpha1.AgentJob{Spec: v1alpha1.AgentJobSpec{ForProvider: nightly()}},
+			},
+			want: want{
+				err: errors.Wrap(errBoom, errUpdateJob),
+			},
+		},
+		"SuccessJobOnly": {
+			reason: "Only the job itself should be updated when its steps and schedules are as desired",
+			fields: fields{
+				db: &mockDB{
+					MockQuery: queryJob(nightlySteps(), nightlySchedules()),
+				},
+			},
+			args: args{
+				mg: &v1alpha1.AgentJob{Spec: v1alpha1.AgentJobSpec{ForProvider: nightly()}},
+			},
+			want: want{
+				queries: []string{
+					"EXEC msdb.dbo.sp_update_job @job_name = @p1, @enabled = @p2, @description = @p3",
+				},
+			},
+		},
+		"SuccessReplaceStepsAndSchedules": {
+			reason: "Drifted steps and schedules should be replaced",
+			fields: fields{
+				db: &mockDB{
+					MockQuery: queryJob(
+						sqlmock.NewRows(stepColumns).AddRow("cleanup", "TSQL", "EXEC dbo.purge", "example-db"),
+						sqlmock.NewRows(scheduleColumns).AddRow(7, "nightly", false, 4, 1, 1, 0, 0, 23000),
+					),
+				},
+			},
+			args: args{
+				mg: &v1alpha1.AgentJob{Spec: v1alpha1.AgentJobSpec{ForProvider: nightly()}},
+			},
+			want: want{
+				queries: []string{
+					"EXEC msdb.dbo.sp_update_job @job_name = @p1, @enabled = @p2, @description = @p3",
+					"EXEC msdb.dbo.sp_delete_jobstep @job_name = @p1, @step_id = 0",
+					"EXEC msdb.dbo.sp_add_jobstep @job_name = @p1, @step_name = @p2, @subsystem = @p3, " +
+						"@command = @p4, @database_name = @p5, @on_success_action = 1, @on_fail_action = 2",
+					"EXEC msdb.dbo.sp_detach_schedule @job_name = @p1, @schedule_id = @p2, @delete_unused_schedule = 1",
+					"EXEC msdb.dbo.sp_add_jobschedule @job_name = @p1, @name = @p2, @enabled = @p3, " +
+						"@freq_type = 4, @freq_interval = 1, @freq_subday_type = 1, @freq_subday_interval = 0, " +
+						"@freq_recurrence_factor = 0, @active_start_time = 23000",
+				},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var got []string
+			if db, ok := tc.fields.db.(*mockDB); ok && db.MockExecTx == nil {
+				db.MockExecTx = func(ctx context.Context, ql []xsql.Query) error {
+					for _, q := range ql {
+						got = append(got, q.String)
+					}
+					return nil
+				}
+			}
+			e := external{db: tc.fields.db}
+			_, err := e.Update(tc.args.ctx, tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Update(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.queries, got); diff != "" {
+				t.Errorf("\n%s\ne.Update(...): -want queries, +got queries:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestDelete(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type fields struct {
+		db xsql.DB
+	}
+
+	type args struct {
+		ctx context.Context
+		mg  resource.Managed
+	}
+
+	cases := map[string]struct {
+		reason string
+		fields fields
+		args   args
+		want   error
+	}{
+		"ErrNotAgentJob": {
+			reason: "An error should be returned if the managed resource is not an *AgentJob",
+			args: args{
+				mg: nil,
+			},
+			want: errors.New(errNotAgentJob),
+		},
+		"ErrDropJob": {
+			reason: "Errors dropping a job should be returned",
+			fields: fields{
+				db: &mockDB{
+					MockExec: func(ctx context.Context, q xsql.Query) error { return errBoom },
+				},
+			},
+			args: args{
+				mg: &v1alpha1.AgentJob{},
+			},
+			want: errors.Wrap(errBoom, errDropJob),
+		},
+		"Success": {
+			reason: "No error should be returned if the job was dropped",
+			fields: fields{
+				db: &mockDB{
+					MockExec: func(ctx context.Context, q xsql.Query) error { return nil },
+				},
+			},
+			args: args{
+				mg: &v1alpha1.AgentJob{},
+			},
+			want: nil,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{db: tc.fields.db}
+			err := e.Delete(tc.args.ctx, tc.args.mg)
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Delete(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -18,6 +18,8 @@ package database
 
 import (
 	"context"
+	"fmt"
+	"strings"
 	"time"
 
 	"github.com/pkg/errors"
@@ -48,7 +50,9 @@ const (
 	errNotDatabase = "managed resource is not a Database custom resource"
 	errSelectDB    = "cannot select database"
 	errCreateDB    = "cannot create database"
+	errUpdateDB    = "cannot update database"
 	errDropDB      = "cannot drop database"
+	errProtectedDB = "cannot drop database: deletion protection is enabled"
 
 	maxConcurrency = 5
 )
@@ -115,15 +119,35 @@ func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.E
 
 type external struct{ db xsql.DB }
 
+func (c *external) observe(ctx context.Context, name string) (*v1alpha1.DatabaseParameters, error) {
+	observed := &v1alpha1.DatabaseParameters{}
+
+	// Snapshot isolation is considered allowed while it is being turned on,
+	// i.e. in state 3, as it is turned on once the active transactions end.
+	query := "SELECT collation_name, recovery_model_desc, compatibility_level, containment_desc, " +
+		"is_read_committed_snapshot_on, " +
+		"CAST(CASE WHEN snapshot_isolation_state IN (1, 3) THEN 1 ELSE 0 END AS bit), " +
+		"is_auto_close_on " +
+		"FROM master.sys.databases WHERE name = @p1"
+	err := c.db.Scan(ctx, xsql.Query{String: query, Parameters: []interface{}{name}},
+		&observed.Collation,
+		&observed.RecoveryModel,
+		&observed.CompatibilityLevel,
+		&observed.Containment,
+		&observed.ReadCommittedSnapshot,
+		&observed.AllowSnapshotIsolation,
+		&observed.AutoClose,
+	)
+	return observed, err
+}
+
 func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
 	cr, ok := mg.(*v1alpha1.Database)
 	if !ok {
 		return managed.ExternalObservation{}, errors.New(errNotDatabase)
 	}
 
-	var name string
-	query := "SELECT name FROM master.sys.databases WHERE name = @p1"
-	err := c.db.Scan(ctx, xsql.Query{String: query, Parameters: []interface{}{meta.GetExternalName(cr)}}, &name)
+	observed, err := c.observe(ctx, meta.GetExternalName(cr))
 	if xsql.IsNoRows(err) {
 		return managed.ExternalObservation{ResourceExists: false}, nil
 	}
@@ -133,12 +157,11 @@ func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 
 	cr.SetConditions(xpv1.Available())
 
+	changed := changedOptions(observed, cr.Spec.ForProvider)
 	return managed.ExternalObservation{
-		ResourceExists: true,
-
-		// TODO(turkenh): Support these when we have anything to update.
-		ResourceLateInitialized: false,
-		ResourceUpToDate:        true,
+		ResourceExists:          true,
+		ResourceLateInitialized: lateInit(observed, &cr.Spec.ForProvider),
+		ResourceUpToDate:        changed.Collation == nil && len(setClauses(changed)) == 0,
 	}, nil
 }
 
@@ -149,13 +172,63 @@ func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.Ext
 		return managed.ExternalCreation{}, errors.New(errNotDatabase)
 	}
 
-	err := c.db.Exec(ctx, xsql.Query{String: "CREATE DATABASE " + mssql.QuoteIdentifier(meta.GetExternalName(cr))})
+	db := mssql.QuoteIdentifier(meta.GetExternalName(cr))
+	p := cr.Spec.ForProvider
+
+	query := "CREATE DATABASE " + db
+	if p.Containment != nil {
+		query += " CONTAINMENT = " + *p.Containment
+	}
+	if p.Collation != nil {
+		query += " COLLATE " + *p.Collation
+	}
+	if err := c.db.Exec(ctx, xsql.Query{String: query}); err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errCreateDB)
+	}
+
+	// The remaining options may only be specified by ALTER DATABASE.
+	clauses := setClauses(v1alpha1.DatabaseParameters{
+		RecoveryModel:          p.RecoveryModel,
+		CompatibilityLevel:     p.CompatibilityLevel,
+		ReadCommittedSnapshot:  p.ReadCommittedSnapshot,
+		AllowSnapshotIsolation: p.AllowSnapshotIsolation,
+		AutoClose:              p.AutoClose,
+	})
+	if len(clauses) == 0 {
+		return managed.ExternalCreation{}, nil
+	}
+	err := c.db.Exec(ctx, xsql.Query{String: "ALTER DATABASE " + db + " SET " + strings.Join(clauses, ", ")})
 	return managed.ExternalCreation{}, errors.Wrap(err, errCreateDB)
 }
 
-func (c *external) Update(_ context.Context, _ resource.Managed) (managed.ExternalUpdate, error) {
-	// TODO(turkenh): Support updates once we have anything to update.
-	return managed.ExternalUpdate{}, nil
+func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*v1alpha1.Database)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotDatabase)
+	}
+
+	observed, err := c.observe(ctx, meta.GetExternalName(cr))
+	if err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errSelectDB)
+	}
+
+	db := mssql.QuoteIdentifier(meta.GetExternalName(cr))
+	changed := changedOptions(observed, cr.Spec.ForProvider)
+
+	// The collation is not a database option, so it cannot be changed by
+	// ALTER DATABASE ... SET.
+	if changed.Collation != nil {
+		if err := c.db.Exec(ctx, xsql.Query{String: "ALTER DATABASE " + db + " COLLATE " + *changed.Collation}); err != nil {
+			return managed.ExternalUpdate{}, errors.Wrap(err, errUpdateDB)
+		}
+	}
+
+	clauses := setClauses(changed)
+	if len(clauses) == 0 {
+		return managed.ExternalUpdate{}, nil
+	}
+	err = c.db.Exec(ctx, xsql.Query{String: "ALTER DATABASE " + db + " SET " + strings.Join(clauses, ", ")})
+	return managed.ExternalUpdate{}, errors.Wrap(err, errUpdateDB)
 }
 
 func (c *external) Delete(ctx context.Context, mg resource.Managed) error {
@@ -164,6 +237,121 @@ func (c *external) Delete(ctx context.Context, mg resource.Managed) error {
 		return errors.New(errNotDatabase)
 	}
 
-	err := c.db.Exec(ctx, xsql.Query{String: "DROP DATABASE IF EXISTS " + mssql.QuoteIdentifier(meta.GetExternalName(cr))})
+	p := cr.Spec.ForProvider
+	if p.DeletionProtection != nil && *p.DeletionProtection {
+		return errors.New(errProtectedDB)
+	}
+
+	db := mssql.QuoteIdentifier(meta.GetExternalName(cr))
+	query := "DROP DATABASE IF EXISTS " + db
+
+	// The sessions are terminated in the same batch as the database is
+	// dropped, so that they have no chance to reconnect in between.
+	if p.ForceDrop != nil {
+		state := "SINGLE_USER"
+		if *p.ForceDrop == v1alpha1.ForceDropOffline {
+			state = "OFFLINE"
+		}
+		query = "IF DB_ID(@p1) IS NOT NULL ALTER DATABASE " + db + " SET " + state + " WITH ROLLBACK IMMEDIATE; " + query
+		err := c.db.Exec(ctx, xsql.Query{String: query, Parameters: []interface{}{meta.GetExternalName(cr)}})
+		return errors.Wrap(err, errDropDB)
+	}
+
+	err := c.db.Exec(ctx, xsql.Query{String: query})
 	return errors.Wrap(err, errDropDB)
 }
+
+// setClauses returns the options of ALTER DATABASE ... SET that set the
+// supplied options. The collation is not among them.
+func setClauses(p v1alpha1.DatabaseParameters) []string {
+	clauses := []string{}
+	if p.RecoveryModel != nil {
+		clauses = append(clauses, "RECOVERY "+*p.RecoveryModel)
+	}
+	if p.CompatibilityLevel != nil {
+		clauses = append(clauses, fmt.Sprintf("COMPATIBILITY_LEVEL = %d", *p.CompatibilityLevel))
+	}
+	if p.Containment != nil {
+		clauses = append(clauses, "CONTAINMENT = "+*p.Containment)
+	}
+	if p.ReadCommittedSnapshot != nil {
+		clauses = append(clauses, "READ_COMMITTED_SNAPSHOT "+onOff(*p.ReadCommittedSnapshot))
+	}
+	if p.AllowSnapshotIsolation != nil {
+		clauses = append(clauses, "ALLOW_SNAPSHOT_ISOLATION "+onOff(*p.AllowSnapshotIsolation))
+	}
+	if p.AutoClose != nil {
+		clauses = append(clauses, "AUTO_CLOSE "+onOff(*p.AutoClose))
+	}
+	return clauses
+}
+
+// changedOptions returns the desired options that differ from the observed
+// ones.
+func changedOptions(observed *v1alpha1.DatabaseParameters, desired v1alpha1.DatabaseParameters) v1alpha1.DatabaseParameters {
+	changed := v1alpha1.DatabaseParameters{}
+	if desired.Collation != nil && (observed.Collation == nil || !strings.EqualFold(*observed.Collation, *desired.Collation)) {
+		changed.Collation = desired.Collation
+	}
+	if desired.RecoveryModel != nil && (observed.RecoveryModel == nil || !strings.EqualFold(*observed.RecoveryModel, *desired.RecoveryModel)) {
+		changed.RecoveryModel = desired.RecoveryModel
+	}
+	if desired.CompatibilityLevel != nil && (observed.CompatibilityLevel == nil || *observed.CompatibilityLevel != *desired.CompatibilityLevel) {
+		changed.CompatibilityLevel = desired.CompatibilityLevel
+	}
+	if desired.Containment != nil && (observed.Containment == nil || !strings.EqualFold(*observed.Containment, *desired.Containment)) {
+		changed.Containment = desired.Containment
+	}
+	if desired.ReadCommittedSnapshot != nil && (observed.ReadCommittedSnapshot == nil || *observed.ReadCommittedSnapshot != *desired.ReadCommittedSnapshot) {
+		changed.ReadCommittedSnapshot = desired.ReadCommittedSnapshot
+	}
+	if desired.AllowSnapshotIsolation != nil && (observed.AllowSnapshotIsolation == nil || *observed.AllowSnapshotIsolation != *desired.AllowSnapshotIsolation) {
+		changed.AllowSnapshotIsolation = desired.AllowSnapshotIsolation
+	}
+	if desired.AutoClose != nil && (observed.AutoClose == nil || *observed.AutoClose != *desired.AutoClose) {
+		changed.AutoClose = desired.AutoClose
+	}
+	return changed
+}
+
+func lateInit(observed *v1alpha1.DatabaseParameters, desired *v1alpha1.DatabaseParameters) bool {
+	li := false
+
+	if desired.Collation == nil && observed.Collation != nil {
+		desired.Collation = observed.Collation
+		li = true
+	}
+	if desired.RecoveryModel == nil && observed.RecoveryModel != nil {
+		desired.RecoveryModel = observed.RecoveryModel
+		li = true
+	}
+	if desired.CompatibilityLevel == nil && observed.CompatibilityLevel != nil {
+		desired.CompatibilityLevel = observed.CompatibilityLevel
+		li = true
+	}
+	if desired.Containment == nil && observed.Containment != nil {
+		desired.Containment = observed.Containment
+		li = true
+	}
+	if desired.ReadCommittedSnapshot == nil && observed.ReadCommittedSnapshot != nil {
+		desired.ReadCommittedSnapshot = observed.ReadCommittedSnapshot
+		li = true
+	}
+	if desired.AllowSnapshotIsolation == nil && observed.AllowSnapshotIsolation != nil {
+		desired.AllowSnapshotIsolation = observed.AllowSnapshotIsolation
+		li = true
+	}
+	if desired.AutoClose == nil && observed.AutoClose != nil {
+		desired.AutoClose = observed.AutoClose
+		li = true
+	}
+
+	return li
+}
+
+func onOff(b bool) string {
+	if b {
+		return "ON"
+	}
+	return "OFF"
+}
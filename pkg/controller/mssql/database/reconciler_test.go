@@ -19,19 +19,24 @@ package database
 import (
 	"context"
 	"database/sql"
+	"strings"
 	"testing"
 
 	"github.com/crossplane-contrib/provider-sql/apis/mssql/v1alpha1"
 	"github.com/google/go-cmp/cmp"
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
 	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
 	"github.com/crossplane/crossplane-runtime/pkg/resource"
 	"github.com/crossplane/crossplane-runtime/pkg/test"
 
+	"github.com/crossplane-contrib/provider-sql/pkg/clients/mssql"
 	"github.com/crossplane-contrib/provider-sql/pkg/clients/xsql"
 )
 
@@ -254,6 +259,60 @@ func TestObserve(t *testing.T) {
 				err: nil,
 			},
 		},
+		"SuccessQuotedName": {
+			reason: "We should select a database whose name needs quoting by its unquoted name",
+			fields: fields{
+				db: mockDB{
+					MockScan: func(ctx context.Context, q xsql.Query, dest ...interface{}) error {
+						if q.Parameters[0] != "o'rders]db" {
+							return errBoom
+						}
+						return nil
+					},
+				},
+			},
+			args: args{
+				mg: &v1alpha1.Database{
+					ObjectMeta: metav1.ObjectMeta{
+						Annotations: map[string]string{meta.AnnotationKeyExternalName: "o'rders]db"},
+					},
+				},
+			},
+			want: want{
+				o: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: true,
+				},
+			},
+		},
+		"SuccessLateInit": {
+			reason: "We should late initialize unset options and report whether the database is up to date",
+			fields: fields{
+				db: mockDB{
+					MockScan: func(ctx context.Context, q xsql.Query, dest ...interface{}) error {
+						*dest[0].(**string) = pointer.String("SQL_Latin1_General_CP1_CI_AS")
+						*dest[1].(**string) = pointer.String("SIMPLE")
+						return nil
+					},
+				},
+			},
+			args: args{
+				mg: &v1alpha1.Database{
+					Spec: v1alpha1.DatabaseSpec{
+						ForProvider: v1alpha1.DatabaseParameters{
+							RecoveryModel: pointer.String("FULL"),
+						},
+					},
+				},
+			},
+			want: want{
+				o: managed.ExternalObservation{
+					ResourceExists:          true,
+					ResourceUpToDate:        false,
+					ResourceLateInitialized: true,
+				},
+			},
+		},
 	}
 
 	for name, tc := range cases {
@@ -330,6 +389,62 @@ func TestCreate(t *testing.T) {
 				err: nil,
 			},
 		},
+		"SuccessQuotedName": {
+			reason: "A database whose name needs quoting should be created with a quoted identifier",
+			fields: fields{
+				db: &mockDB{
+					MockExec: func(ctx context.Context, q xsql.Query) error {
+						if q.String != "CREATE DATABASE [o'rders]]db]" {
+							return errBoom
+						}
+						return nil
+					},
+				},
+			},
+			args: args{
+				mg: &v1alpha1.Database{
+					ObjectMeta: metav1.ObjectMeta{
+						Annotations: map[string]string{meta.AnnotationKeyExternalName: "o'rders]db"},
+					},
+				},
+			},
+			want: want{
+				err: nil,
+			},
+		},
+		"SuccessOptions": {
+			reason: "Options that CREATE DATABASE does not support should be set by ALTER DATABASE",
+			fields: fields{
+				db: &mockDB{
+					MockExec: func(ctx context.Context, q xsql.Query) error {
+						switch q.String {
+						case "CREATE DATABASE [example] CONTAINMENT = PARTIAL COLLATE Latin1_General_100_CI_AS",
+							"ALTER DATABASE [example] SET RECOVERY SIMPLE, READ_COMMITTED_SNAPSHOT ON":
+							return nil
+						}
+						return errBoom
+					},
+				},
+			},
+			args: args{
+				mg: &v1alpha1.Database{
+					ObjectMeta: metav1.ObjectMeta{
+						Annotations: map[string]string{meta.AnnotationKeyExternalName: "example"},
+					},
+					Spec: v1alpha1.DatabaseSpec{
+						ForProvider: v1alpha1.DatabaseParameters{
+							Collation:             pointer.String("Latin1_General_100_CI_AS"),
+							Containment:           pointer.String("PARTIAL"),
+							RecoveryModel:         pointer.String("SIMPLE"),
+							ReadCommittedSnapshot: pointer.Bool(true),
+						},
+					},
+				},
+			},
+			want: want{
+				err: nil,
+			},
+		},
 	}
 
 	for name, tc := range cases {
@@ -346,6 +461,127 @@ func TestCreate(t *testing.T) {
 	}
 }
 
+func TestUpdate(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type fields struct {
+		db xsql.DB
+	}
+
+	type args struct {
+		ctx context.Context
+		mg  resource.Managed
+	}
+
+	type want struct {
+		u   managed.ExternalUpdate
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		fields fields
+		args   args
+		want   want
+	}{
+		"ErrNotDatabase": {
+			reason: "An error should be returned if the managed resource is not a *Database",
+			args: args{
+				mg: nil,
+			},
+			want: want{
+				err: errors.New(errNotDatabase),
+			},
+		},
+		"ErrSelectDatabase": {
+			reason: "Any errors encountered while selecting the database should be returned",
+			fields: fields{
+				db: &mockDB{
+					MockScan: func(ctx context.Context, q xsql.Query, dest ...interface{}) error { return errBoom },
+				},
+			},
+			args: args{
+				mg: &v1alpha1.Database{},
+			},
+			want: want{
+				err: errors.Wrap(errBoom, errSelectDB),
+			},
+		},
+		"ErrExec": {
+			reason: "Any errors encountered while altering the database should be returned",
+			fields: fields{
+				db: &mockDB{
+					MockScan: func(ctx context.Context, q xsql.Query, dest ...interface{}) error { return nil },
+					MockExec: func(ctx context.Context, q xsql.Query) error { return errBoom },
+				},
+			},
+			args: args{
+				mg: &v1alpha1.Database{
+					Spec: v1alpha1.DatabaseSpec{
+						ForProvider: v1alpha1.DatabaseParameters{
+							AutoClose: pointer.Bool(false),
+						},
+					},
+				},
+			},
+			want: want{
+				err: errors.Wrap(errBoom, errUpdateDB),
+			},
+		},
+		"Success": {
+			reason: "Only the options that differ from the observed ones should be altered",
+			fields: fields{
+				db: &mockDB{
+					MockScan: func(ctx context.Context, q xsql.Query, dest ...interface{}) error {
+						*dest[0].(**string) = pointer.String("SQL_Latin1_General_CP1_CI_AS")
+						*dest[2].(**int) = pointer.Int(140)
+						*dest[6].(**bool) = pointer.Bool(true)
+						return nil
+					},
+					MockExec: func(ctx context.Context, q xsql.Query) error {
+						switch q.String {
+						case "ALTER DATABASE [example] COLLATE Latin1_General_100_CI_AS",
+							"ALTER DATABASE [example] SET COMPATIBILITY_LEVEL = 150, AUTO_CLOSE OFF":
+							return nil
+						}
+						return errBoom
+					},
+				},
+			},
+			args: args{
+				mg: &v1alpha1.Database{
+					ObjectMeta: metav1.ObjectMeta{
+						Annotations: map[string]string{meta.AnnotationKeyExternalName: "example"},
+					},
+					Spec: v1alpha1.DatabaseSpec{
+						ForProvider: v1alpha1.DatabaseParameters{
+							Collation:          pointer.String("Latin1_General_100_CI_AS"),
+							CompatibilityLevel: pointer.Int(150),
+							AutoClose:          pointer.Bool(false),
+						},
+					},
+				},
+			},
+			want: want{
+				err: nil,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{db: tc.fields.db}
+			got, err := e.Update(tc.args.ctx, tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Update(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.u, got); diff != "" {
+				t.Errorf("\n%s\ne.Update(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
 func TestDelete(t *testing.T) {
 	errBoom := errors.New("boom")
 
@@ -385,6 +621,80 @@ func TestDelete(t *testing.T) {
 			},
 			want: errors.Wrap(errBoom, errDropDB),
 		},
+		"ErrDeletionProtection": {
+			reason: "A database with deletion protection should not be dropped",
+			fields: fields{
+				db: &mockDB{
+					MockExec: func(ctx context.Context, q xsql.Query) error { return errBoom },
+				},
+			},
+			args: args{
+				mg: &v1alpha1.Database{
+					Spec: v1alpha1.DatabaseSpec{
+						ForProvider: v1alpha1.DatabaseParameters{
+							DeletionProtection: pointer.Bool(true),
+						},
+					},
+				},
+			},
+			want: errors.New(errProtectedDB),
+		},
+		"SuccessForceDrop": {
+			reason: "The sessions connected to the database should be terminated before it is dropped",
+			fields: fields{
+				db: &mockDB{
+					MockExec: func(ctx context.Context, q xsql.Query) error {
+						if q.String != "IF DB_ID(@p1) IS NOT NULL ALTER DATABASE [example] SET SINGLE_USER WITH ROLLBACK IMMEDIATE; DROP DATABASE IF EXISTS [example]" {
+							return errBoom
+						}
+						return nil
+					},
+				},
+			},
+			args: args{
+				mg: &v1alpha1.Database{
+					ObjectMeta: metav1.ObjectMeta{
+						Annotations: map[string]string{meta.AnnotationKeyExternalName: "example"},
+					},
+					Spec: v1alpha1.DatabaseSpec{
+						ForProvider: v1alpha1.DatabaseParameters{
+							ForceDrop:          forceDropPtr(v1alpha1.ForceDropSingleUser),
+							DeletionProtection: pointer.Bool(false),
+						},
+					},
+				},
+			},
+			want: nil,
+		},
+		"SuccessQuotedName": {
+			reason: "A database whose name needs quoting should be dropped by a quoted identifier and looked up by its unquoted name",
+			fields: fields{
+				db: &mockDB{
+					MockExec: func(ctx context.Context, q xsql.Query) error {
+						if q.String != "IF DB_ID(@p1) IS NOT NULL ALTER DATABASE [o'rders]]db] SET OFFLINE WITH ROLLBACK IMMEDIATE; DROP DATABASE IF EXISTS [o'rders]]db]" {
+							return errBoom
+						}
+						if q.Parameters[0] != "o'rders]db" {
+							return errBoom
+						}
+						return nil
+					},
+				},
+			},
+			args: args{
+				mg: &v1alpha1.Database{
+					ObjectMeta: metav1.ObjectMeta{
+						Annotations: map[string]string{meta.AnnotationKeyExternalName: "o'rders]db"},
+					},
+					Spec: v1alpha1.DatabaseSpec{
+						ForProvider: v1alpha1.DatabaseParameters{
+							ForceDrop: forceDropPtr(v1alpha1.ForceDropOffline),
+						},
+					},
+				},
+			},
+			want: nil,
+		},
 	}
 
 	for name, tc := range cases {
@@ -397,3 +707,65 @@ func TestDelete(t *testing.T) {
 		})
 	}
 }
+
+func forceDropPtr(f v1alpha1.ForceDrop) *v1alpha1.ForceDrop {
+	return &f
+}
+
+func FuzzDatabaseName(f *testing.F) {
+	for _, name := range []string{"example", "ex]ample", "[example]", "x]; DROP DATABASE master; --"} {
+		f.Add(name)
+	}
+	f.Fuzz(func(t *testing.T, name string) {
+		if name == "" {
+			t.Skip()
+		}
+
+		// identifier returns the name identified by the remainder of a
+		// statement with the supplied prefix.
+		identifier := func(q xsql.Query, prefix string) string {
+			if !strings.HasPrefix(q.String, prefix) {
+				t.Fatalf("%q does not start with %q", q.String, prefix)
+			}
+			parts, err := mssql.SplitQualifiedIdentifier(strings.TrimPrefix(q.String, prefix))
+			if err != nil || len(parts) != 1 {
+				t.Fatalf("%q does not end with a single identifier: %v", q.String, err)
+			}
+			return parts[0]
+		}
+
+		var created, observed, dropped string
+		e := external{db: &mockDB{
+			MockExec: func(ctx context.Context, q xsql.Query) error {
+				if strings.HasPrefix(q.String, "CREATE") {
+					created = identifier(q, "CREATE DATABASE ")
+					return nil
+				}
+				dropped = identifier(q, "DROP DATABASE IF EXISTS ")
+				return nil
+			},
+			MockScan: func(ctx context.Context, q xsql.Query, dest ...interface{}) error {
+				observed = q.Parameters[0].(string)
+				return nil
+			},
+		}}
+		cr := &v1alpha1.Database{
+			ObjectMeta: metav1.ObjectMeta{
+				Annotations: map[string]string{meta.AnnotationKeyExternalName: name},
+			},
+		}
+
+		if _, err := e.Create(context.Background(), cr); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := e.Observe(context.Background(), cr); err != nil {
+			t.Fatal(err)
+		}
+		if err := e.Delete(context.Background(), cr); err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff([]string{name, name, name}, []string{created, observed, dropped}); diff != "" {
+			t.Errorf("names of the created, observed and dropped database: -want, +got:\n%s", diff)
+		}
+	})
+}
@@ -25,6 +25,7 @@ import (
 
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/utils/pointer"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -34,6 +35,7 @@ import (
 	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
 	"github.com/crossplane/crossplane-runtime/pkg/event"
 	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
 	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
 	"github.com/crossplane/crossplane-runtime/pkg/resource"
 
@@ -48,10 +50,18 @@ const (
 	errNoSecretRef  = "ProviderConfig does not reference a credentials Secret"
 	errGetSecret    = "cannot get credentials Secret"
 
-	errNotGrant        = "managed resource is not a Grant custom resource"
-	errGrant           = "cannot grant"
-	errRevoke          = "cannot revoke"
-	errCannotGetGrants = "cannot get current grants"
+	errNotGrant         = "managed resource is not a Grant custom resource"
+	errGrant            = "cannot grant"
+	errRevoke           = "cannot revoke"
+	errCannotGetGrants  = "cannot get current grants"
+	errParseSecurable   = "cannot parse securable"
+	errNoUser           = "user not passed or could not be resolved"
+	errGetUser          = "cannot get referenced User"
+	errGetDatabase      = "cannot get referenced Database"
+	errUserNotReady     = "waiting for referenced User to become ready"
+	errDatabaseNotReady = "waiting for referenced Database to become ready"
+	errGetBuiltins      = "cannot get built-in permissions"
+	errUnknownPerm      = "unknown permission"
 
 	maxConcurrency = 5
 )
@@ -131,33 +141,291 @@ func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 		return managed.ExternalObservation{}, errors.New(errNotGrant)
 	}
 
-	permissions, err := c.getPermissions(ctx, *cr.Spec.ForProvider.User)
+	gp := cr.Spec.ForProvider
+	if meta.WasDeleted(cr) {
+		// References are not resolved while a grant is deleted, and the User
+		// or Database it references may already be gone along with the
+		// permissions granted to it, in which case there is nothing left to
+		// revoke.
+		gone, err := c.referencesGone(ctx, gp)
+		if err != nil {
+			return managed.ExternalObservation{}, err
+		}
+		if gp.User == nil || gone {
+			return managed.ExternalObservation{ResourceExists: false}, nil
+		}
+	} else {
+		if gp.User == nil {
+			return managed.ExternalObservation{}, errors.New(errNoUser)
+		}
+		// We wait for the User and Database to be created rather than failing
+		// to grant to them. Returning an error requeues the grant with backoff.
+		if err := c.waitingFor(ctx, gp); err != nil {
+			return managed.ExternalObservation{}, err
+		}
+	}
+
+	sec, err := parseSecurable(gp.Securable)
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
+	desired := gp.Permissions.ToStringSlice()
+	permissions, err := c.getPermissions(ctx, *gp.User, sec)
 	if err != nil {
 		return managed.ExternalObservation{}, err
 	}
+	permissions = managedPermissions(permissions, desired, sec)
 	if len(permissions) == 0 {
 		return managed.ExternalObservation{}, nil
 	}
 
 	cr.SetConditions(xpv1.Available())
 
-	g, r := diffPermissions(cr.Spec.ForProvider.Permissions.ToStringSlice(), permissions)
+	// Permissions that are not desired are revoked whatever their state.
+	g, _ := diffPermissions(desired, inState(permissions, state(gp)))
 	return managed.ExternalObservation{
 		ResourceExists:   true,
-		ResourceUpToDate: len(g) == 0 && len(r) == 0,
+		ResourceUpToDate: len(g) == 0 && len(undesired(permissions, desired)) == 0,
 	}, nil
 }
 
+// waitingFor returns an error if the User or Database the grant references is
+// not ready yet. Resolving a reference only requires the referenced resource
+// to exist, not that it was created.
+func (c *external) waitingFor(ctx context.Context, gp v1alpha1.GrantParameters) error {
+	u := &v1alpha1.User{}
+	found, err := c.getReferenced(ctx, gp.UserRef, u)
+	if err != nil {
+		return errors.Wrap(err, errGetUser)
+	}
+	if found && u.GetCondition(xpv1.TypeReady).Status != corev1.ConditionTrue {
+		return errors.New(errUserNotReady)
+	}
+	db := &v1alpha1.Database{}
+	found, err = c.getReferenced(ctx, gp.DatabaseRef, db)
+	if err != nil {
+		return errors.Wrap(err, errGetDatabase)
+	}
+	if found && db.GetCondition(xpv1.TypeReady).Status != corev1.ConditionTrue {
+		return errors.New(errDatabaseNotReady)
+	}
+	return nil
+}
+
+// referencesGone returns true if the User or Database the grant references
+// no longer exists.
+func (c *external) referencesGone(ctx context.Context, gp v1alpha1.GrantParameters) (bool, error) {
+	found, err := c.getReferenced(ctx, gp.UserRef, &v1alpha1.User{})
+	if err != nil {
+		return false, errors.Wrap(err, errGetUser)
+	}
+	if gp.UserRef != nil && !found {
+		return true, nil
+	}
+	found, err = c.getReferenced(ctx, gp.DatabaseRef, &v1alpha1.Database{})
+	if err != nil {
+		return false, errors.Wrap(err, errGetDatabase)
+	}
+	return gp.DatabaseRef != nil && !found, nil
+}
+
+// getReferenced gets the resource the supplied reference points to, and
+// returns false if there is none.
+func (c *external) getReferenced(ctx context.Context, ref *xpv1.Reference, obj client.Object) (bool, error) {
+	if ref == nil {
+		return false, nil
+	}
+	err := c.kube.Get(ctx, types.NamespacedName{Name: ref.Name}, obj)
+	if kerrors.IsNotFound(err) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// implicitPermissions are granted on the database by SQL Server itself, e.g.
+// CONNECT to every user created for a login. They are only managed by a
+// grant that lists them.
+var implicitPermissions = map[string]bool{
+	"CONNECT": true,
+}
+
+// managedPermissions returns the observed permissions without the implicit
+// ones that are not desired.
+func managedPermissions(observed map[string]v1alpha1.GrantState, desired []string, sec securable) map[string]v1alpha1.GrantState {
+	if sec.class != 0 {
+		return observed
+	}
+	d := make(map[string]bool, len(desired))
+	for _, p := range desired {
+		d[p] = true
+	}
+	out := make(map[string]v1alpha1.GrantState, len(observed))
+	for p, s := range observed {
+		if implicitPermissions[p] && !d[p] {
+			continue
+		}
+		out[p] = s
+	}
+	return out
+}
+
+// validatePermissions returns an error if any of the permissions can not be
+// granted on the class of the securable.
+func (c *external) validatePermissions(ctx context.Context, permissions []string, sec securable) error {
+	rows, err := c.db.Query(ctx, xsql.Query{
+		String:     "SELECT permission_name FROM sys.fn_builtin_permissions(@p1)",
+		Parameters: []interface{}{sec.classDesc},
+	})
+	if err != nil {
+		return errors.Wrap(err, errGetBuiltins)
+	}
+	defer rows.Close() //nolint:errcheck
+
+	builtins := map[string]bool{}
+	for rows.Next() {
+		var p string
+		if err := rows.Scan(&p); err != nil {
+			return errors.Wrap(err, errGetBuiltins)
+		}
+		builtins[p] = true
+	}
+	if err := rows.Err(); err != nil {
+		return errors.Wrap(err, errGetBuiltins)
+	}
+
+	for _, p := range permissions {
+		if !builtins[p] {
+			return errors.Errorf("%s %s on %s", errUnknownPerm, p, sec.classDesc)
+		}
+	}
+	return nil
+}
+
+// state returns the desired state of the permissions of a grant.
+func state(gp v1alpha1.GrantParameters) v1alpha1.GrantState {
+	if gp.State != nil {
+		return *gp.State
+	}
+	return v1alpha1.GrantStateGrant
+}
+
+// inState returns the permissions that are in the supplied state.
+func inState(permissions map[string]v1alpha1.GrantState, s v1alpha1.GrantState) []string {
+	var out []string
+	for p, ps := range permissions {
+		if ps == s {
+			out = append(out, p)
+		}
+	}
+	return out
+}
+
+// undesired returns the observed permissions that are not desired, whatever
+// their state, grouped by state.
+func undesired(permissions map[string]v1alpha1.GrantState, desired []string) map[v1alpha1.GrantState][]string {
+	d := make(map[string]bool, len(desired))
+	for _, p := range desired {
+		d[p] = true
+	}
+	out := map[v1alpha1.GrantState][]string{}
+	for p, s := range permissions {
+		if !d[p] {
+			out[s] = append(out[s], p)
+		}
+	}
+	return out
+}
+
+// A securable permissions are granted on, as recorded by
+// sys.database_permissions.
+type securable struct {
+	// class of the securable, e.g. 0 for the database or 3 for a schema.
+	class int
+
+	// classDesc is the class of the securable as expected by
+	// sys.fn_builtin_permissions, e.g. DATABASE.
+	classDesc string
+
+	// majorID is an expression returning the ID of the securable, given its
+	// name as parameter @p2.
+	majorID string
+
+	// name of the securable, as expected by majorID.
+	name string
+
+	// on is the ON clause naming the securable in a GRANT, DENY or REVOKE
+	// statement. It is empty for the database.
+	on string
+}
+
+// parseSecurable parses a securable such as SCHEMA::sales or
+// OBJECT::dbo.orders. A nil securable is the database.
+func parseSecurable(s *string) (securable, error) {
+	if s == nil {
+		return securable{class: 0, classDesc: "DATABASE", majorID: "0"}, nil
+	}
+
+	class, name := "", *s
+	if parts := strings.SplitN(*s, "::", 2); len(parts) == 2 {
+		class, name = parts[0], parts[1]
+	}
+	parts, err := mssql.SplitQualifiedIdentifier(name)
+	if err != nil {
+		return securable{}, errors.Wrap(err, errParseSecurable)
+	}
+	on := fmt.Sprintf(" ON %s::%s", class, mssql.QuoteQualifiedIdentifier(parts...))
+
+	if class == "SCHEMA" {
+		if len(parts) != 1 {
+			return securable{}, errors.Errorf("%s: schema name %q has more than one part", errParseSecurable, name)
+		}
+		return securable{class: 3, classDesc: "SCHEMA", majorID: "SCHEMA_ID(@p2)", name: parts[0], on: on}, nil
+	}
+	return securable{class: 1, classDesc: "OBJECT", majorID: "OBJECT_ID(@p2)", name: mssql.QuoteQualifiedIdentifier(parts...), on: on}, nil
+}
+
+// grantQuery returns a statement granting or denying the supplied
+// permissions in the supplied state.
+func grantQuery(permissions []string, sec securable, username string, s v1alpha1.GrantState) string {
+	switch s {
+	case v1alpha1.GrantStateDeny:
+		return fmt.Sprintf("DENY %s%s TO %s", strings.Join(permissions, ", "), sec.on, mssql.QuoteIdentifier(username))
+	case v1alpha1.GrantStateGrantWithGrantOption:
+		return fmt.Sprintf("GRANT %s%s TO %s WITH GRANT OPTION", strings.Join(permissions, ", "), sec.on, mssql.QuoteIdentifier(username))
+	case v1alpha1.GrantStateGrant:
+	}
+	return fmt.Sprintf("GRANT %s%s TO %s", strings.Join(permissions, ", "), sec.on, mssql.QuoteIdentifier(username))
+}
+
+// revokeQuery returns a statement revoking the supplied permissions, which
+// are in the supplied state. Permissions granted with the grant option are
+// also revoked from the principals they were granted to by the grantee.
+func revokeQuery(permissions []string, sec securable, username string, s v1alpha1.GrantState) string {
+	query := fmt.Sprintf("REVOKE %s%s FROM %s", strings.Join(permissions, ", "), sec.on, mssql.QuoteIdentifier(username))
+	if s == v1alpha1.GrantStateGrantWithGrantOption {
+		query += " CASCADE"
+	}
+	return query
+}
+
 func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
 	cr, ok := mg.(*v1alpha1.Grant)
 	if !ok {
 		return managed.ExternalCreation{}, errors.New(errNotGrant)
 	}
 
-	username := *cr.Spec.ForProvider.User
-	permissions := strings.Join(cr.Spec.ForProvider.Permissions.ToStringSlice(), ", ")
-
-	query := fmt.Sprintf("GRANT %s TO %s", permissions, mssql.QuoteIdentifier(username))
+	gp := cr.Spec.ForProvider
+	if gp.User == nil {
+		return managed.ExternalCreation{}, errors.New(errNoUser)
+	}
+	sec, err := parseSecurable(gp.Securable)
+	if err != nil {
+		return managed.ExternalCreation{}, err
+	}
+	if err := c.validatePermissions(ctx, gp.Permissions.ToStringSlice(), sec); err != nil {
+		return managed.ExternalCreation{}, err
+	}
+	query := grantQuery(gp.Permissions.ToStringSlice(), sec, *gp.User, state(gp))
 	return managed.ExternalCreation{}, errors.Wrap(c.db.Exec(ctx, xsql.Query{String: query}), errGrant)
 }
 
@@ -167,25 +435,66 @@ func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.Ext
 		return managed.ExternalUpdate{}, errors.New(errNotGrant)
 	}
 
-	observed, err := c.getPermissions(ctx, *cr.Spec.ForProvider.User)
+	gp := cr.Spec.ForProvider
+	if gp.User == nil {
+		return managed.ExternalUpdate{}, errors.New(errNoUser)
+	}
+	sec, err := parseSecurable(gp.Securable)
 	if err != nil {
 		return managed.ExternalUpdate{}, err
 	}
-	desired := cr.Spec.ForProvider.Permissions.ToStringSlice()
-	toGrant, toRevoke := diffPermissions(desired, observed)
+	s := state(gp)
 
-	if len(toRevoke) > 0 {
-		sort.Strings(toRevoke)
-		query := fmt.Sprintf("REVOKE %s FROM %s",
-			strings.Join(toRevoke, ", "), mssql.QuoteIdentifier(*cr.Spec.ForProvider.User))
+	desired := gp.Permissions.ToStringSlice()
+	if err := c.validatePermissions(ctx, desired, sec); err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+	permissions, err := c.getPermissions(ctx, *gp.User, sec)
+	if err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+	permissions = managedPermissions(permissions, desired, sec)
+	toGrant, _ := diffPermissions(desired, inState(permissions, s))
+
+	// Granting a permission without the grant option does not remove the
+	// grant option, which must be revoked on its own instead.
+	var toDowngrade []string
+	if s == v1alpha1.GrantStateGrant {
+		var rest []string
+		for _, p := range toGrant {
+			if permissions[p] == v1alpha1.GrantStateGrantWithGrantOption {
+				toDowngrade = append(toDowngrade, p)
+				continue
+			}
+			rest = append(rest, p)
+		}
+		toGrant = rest
+	}
+
+	// Permissions that are no longer desired are revoked in whichever state
+	// they are, e.g. those denied before the state of the grant changed.
+	toRevoke := undesired(permissions, desired)
+	for _, rs := range []v1alpha1.GrantState{v1alpha1.GrantStateGrant, v1alpha1.GrantStateGrantWithGrantOption, v1alpha1.GrantStateDeny} {
+		if len(toRevoke[rs]) == 0 {
+			continue
+		}
+		sort.Strings(toRevoke[rs])
+		query := revokeQuery(toRevoke[rs], sec, *gp.User, rs)
+		if err = c.db.Exec(ctx, xsql.Query{String: query}); err != nil {
+			return managed.ExternalUpdate{}, errors.Wrap(err, errRevoke)
+		}
+	}
+	if len(toDowngrade) > 0 {
+		sort.Strings(toDowngrade)
+		query := fmt.Sprintf("REVOKE GRANT OPTION FOR %s%s FROM %s CASCADE",
+			strings.Join(toDowngrade, ", "), sec.on, mssql.QuoteIdentifier(*gp.User))
 		if err = c.db.Exec(ctx, xsql.Query{String: query}); err != nil {
 			return managed.ExternalUpdate{}, errors.Wrap(err, errRevoke)
 		}
 	}
 	if len(toGrant) > 0 {
 		sort.Strings(toGrant)
-		query := fmt.Sprintf("GRANT %s TO %s",
-			strings.Join(toGrant, ", "), mssql.QuoteIdentifier(*cr.Spec.ForProvider.User))
+		query := grantQuery(toGrant, sec, *gp.User, s)
 		if err = c.db.Exec(ctx, xsql.Query{String: query}); err != nil {
 			return managed.ExternalUpdate{}, errors.Wrap(err, errGrant)
 		}
@@ -199,38 +508,45 @@ func (c *external) Delete(ctx context.Context, mg resource.Managed) error {
 		return errors.New(errNotGrant)
 	}
 
-	username := *cr.Spec.ForProvider.User
-
-	query := fmt.Sprintf("REVOKE %s FROM %s",
-		strings.Join(cr.Spec.ForProvider.Permissions.ToStringSlice(), ", "),
-		mssql.QuoteIdentifier(username),
-	)
+	// References are not resolved while a resource is deleted, so a grant
+	// whose user was never resolved has nothing to revoke.
+	gp := cr.Spec.ForProvider
+	if gp.User == nil {
+		return nil
+	}
+	sec, err := parseSecurable(gp.Securable)
+	if err != nil {
+		return err
+	}
+	query := revokeQuery(gp.Permissions.ToStringSlice(), sec, *gp.User, state(gp))
 	return errors.Wrap(c.db.Exec(ctx, xsql.Query{String: query}), errRevoke)
 }
 
-func (c *external) getPermissions(ctx context.Context, username string) ([]string, error) {
-	// TODO(turkenh/ulucinar): Possible performance improvement. We first
-	//  calculate the Cartesian product, and then filter. It would be more
-	//  efficient to first filter principals by name, and then join.
-	query := fmt.Sprintf(`SELECT pe.permission_name
-	FROM sys.database_principals AS pr  
-	JOIN sys.database_permissions AS pe  
-	    ON pe.grantee_principal_id = pr.principal_id  
+// getPermissions returns the state of each permission the user has on the
+// securable.
+func (c *external) getPermissions(ctx context.Context, username string, sec securable) (map[string]v1alpha1.GrantState, error) {
+	// Only permissions on the securable itself are selected, not those on
+	// the columns of an object.
+	query := fmt.Sprintf(`SELECT pe.permission_name, pe.state_desc
+	FROM sys.database_permissions AS pe
+	JOIN sys.database_principals AS pr
+	    ON pe.grantee_principal_id = pr.principal_id
 	WHERE
-	  pr.name = %s`, mssql.QuoteValue(username))
-	rows, err := c.db.Query(ctx, xsql.Query{String: query})
+	  pr.name = @p1 AND pe.class = %d AND pe.major_id = %s AND pe.minor_id = 0`,
+		sec.class, sec.majorID)
+	rows, err := c.db.Query(ctx, xsql.Query{String: query, Parameters: []interface{}{username, sec.name}})
 	if err != nil {
 		return nil, errors.Wrap(err, errCannotGetGrants)
 	}
 	defer rows.Close() //nolint:errcheck
 
-	var permissions []string
+	permissions := map[string]v1alpha1.GrantState{}
 	for rows.Next() {
-		var grant string
-		if err := rows.Scan(&grant); err != nil {
+		var grant, state string
+		if err := rows.Scan(&grant, &state); err != nil {
 			return nil, errors.Wrap(err, errCannotGetGrants)
 		}
-		permissions = append(permissions, grant)
+		permissions[grant] = v1alpha1.GrantState(state)
 	}
 	if err := rows.Err(); err != nil {
 		return nil, errors.Wrap(err, errCannotGetGrants)
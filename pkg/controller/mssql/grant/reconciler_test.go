@@ -27,6 +27,9 @@ import (
 	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/utils/pointer"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -183,9 +186,11 @@ func TestConnect(t *testing.T) {
 
 func TestObserve(t *testing.T) {
 	errBoom := errors.New("boom")
+	now := metav1.Now()
 
 	type fields struct {
-		db xsql.DB
+		db   xsql.DB
+		kube client.Client
 	}
 
 	type args struct {
@@ -213,6 +218,125 @@ func TestObserve(t *testing.T) {
 				err: errors.New(errNotGrant),
 			},
 		},
+		"ErrNoUser": {
+			reason: "An error should be returned if the user reference has not been resolved",
+			args: args{
+				mg: &v1alpha1.Grant{
+					Spec: v1alpha1.GrantSpec{
+						ForProvider: v1alpha1.GrantParameters{
+							Database: pointer.StringPtr("test-example"),
+						},
+					},
+				},
+			},
+			want: want{
+				err: errors.New(errNoUser),
+			},
+		},
+		"WaitingForUser": {
+			reason: "We should return an error to wait for the referenced user to be created",
+			fields: fields{
+				kube: &test.MockClient{
+					MockGet: test.NewMockGetFn(nil),
+				},
+			},
+			args: args{
+				mg: &v1alpha1.Grant{
+					Spec: v1alpha1.GrantSpec{
+						ForProvider: v1alpha1.GrantParameters{
+							Database: pointer.StringPtr("test-example"),
+							User:     pointer.StringPtr("test-example"),
+							UserRef:  &xpv1.Reference{Name: "test-example"},
+						},
+					},
+				},
+			},
+			want: want{
+				err: errors.New(errUserNotReady),
+			},
+		},
+		"WaitingForDatabase": {
+			reason: "We should return an error to wait for the referenced database to be created",
+			fields: fields{
+				kube: &test.MockClient{
+					MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+						if u, ok := obj.(*v1alpha1.User); ok {
+							u.SetConditions(xpv1.Available())
+						}
+						return nil
+					}),
+				},
+			},
+			args: args{
+				mg: &v1alpha1.Grant{
+					Spec: v1alpha1.GrantSpec{
+						ForProvider: v1alpha1.GrantParameters{
+							Database:    pointer.StringPtr("test-example"),
+							DatabaseRef: &xpv1.Reference{Name: "test-example"},
+							User:        pointer.StringPtr("test-example"),
+							UserRef:     &xpv1.Reference{Name: "test-example"},
+						},
+					},
+				},
+			},
+			want: want{
+				err: errors.New(errDatabaseNotReady),
+			},
+		},
+		"DeletedUserGone": {
+			reason: "A grant being deleted should not exist once its referenced user is gone",
+			fields: fields{
+				kube: &test.MockClient{
+					MockGet: test.NewMockGetFn(kerrors.NewNotFound(schema.GroupResource{}, "test-example")),
+				},
+			},
+			args: args{
+				mg: &v1alpha1.Grant{
+					ObjectMeta: metav1.ObjectMeta{DeletionTimestamp: &now},
+					Spec: v1alpha1.GrantSpec{
+						ForProvider: v1alpha1.GrantParameters{
+							Database: pointer.StringPtr("test-example"),
+							User:     pointer.StringPtr("test-example"),
+							UserRef:  &xpv1.Reference{Name: "test-example"},
+						},
+					},
+				},
+			},
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: false},
+			},
+		},
+		"DeletedUserNotReady": {
+			reason: "A grant being deleted should be observed even if its referenced user is not ready",
+			fields: fields{
+				kube: &test.MockClient{
+					MockGet: test.NewMockGetFn(nil),
+				},
+				db: mockDB{
+					MockQuery: func(ctx context.Context, q xsql.Query) (*sql.Rows, error) {
+						return mockRowsToSQLRows(
+							sqlmock.NewRows([]string{"Grants", "State"}).AddRow("SELECT", "GRANT"),
+						), nil
+					},
+				},
+			},
+			args: args{
+				mg: &v1alpha1.Grant{
+					ObjectMeta: metav1.ObjectMeta{DeletionTimestamp: &now},
+					Spec: v1alpha1.GrantSpec{
+						ForProvider: v1alpha1.GrantParameters{
+							Database:    pointer.StringPtr("test-example"),
+							User:        pointer.StringPtr("test-example"),
+							UserRef:     &xpv1.Reference{Name: "test-example"},
+							Permissions: v1alpha1.GrantPermissions{"SELECT"},
+						},
+					},
+				},
+			},
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+			},
+		},
 		"SuccessNoGrant": {
 			reason: "We should return ResourceExists: false when no grant is found",
 			fields: fields{
@@ -264,8 +388,8 @@ func TestObserve(t *testing.T) {
 					MockQuery: func(ctx context.Context, q xsql.Query) (*sql.Rows, error) {
 						return mockRowsToSQLRows(
 							sqlmock.NewRows(
-								[]string{"Grants"},
-							).AddRow("CREATE TABLE"),
+								[]string{"Grants", "State"},
+							).AddRow("CREATE TABLE", "GRANT"),
 						), nil
 					},
 				},
@@ -289,6 +413,63 @@ func TestObserve(t *testing.T) {
 				err: nil,
 			},
 		},
+		"SuccessImplicitConnect": {
+			reason: "The implicit CONNECT permission should be ignored unless it is listed",
+			fields: fields{
+				db: mockDB{
+					MockQuery: func(ctx context.Context, q xsql.Query) (*sql.Rows, error) {
+						return mockRowsToSQLRows(
+							sqlmock.NewRows([]string{"Grants", "State"}).
+								AddRow("CONNECT", "GRANT").
+								AddRow("SELECT", "GRANT"),
+						), nil
+					},
+				},
+			},
+			args: args{
+				mg: &v1alpha1.Grant{
+					Spec: v1alpha1.GrantSpec{
+						ForProvider: v1alpha1.GrantParameters{
+							Database:    pointer.StringPtr("test-example"),
+							User:        pointer.StringPtr("test-example"),
+							Permissions: v1alpha1.GrantPermissions{"SELECT"},
+						},
+					},
+				},
+			},
+			want: want{
+				o: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: true,
+				},
+			},
+		},
+		"SuccessOnlyImplicitConnect": {
+			reason: "A grant should not exist when the user only has the implicit CONNECT permission",
+			fields: fields{
+				db: mockDB{
+					MockQuery: func(ctx context.Context, q xsql.Query) (*sql.Rows, error) {
+						return mockRowsToSQLRows(
+							sqlmock.NewRows([]string{"Grants", "State"}).AddRow("CONNECT", "GRANT"),
+						), nil
+					},
+				},
+			},
+			args: args{
+				mg: &v1alpha1.Grant{
+					Spec: v1alpha1.GrantSpec{
+						ForProvider: v1alpha1.GrantParameters{
+							Database:    pointer.StringPtr("test-example"),
+							User:        pointer.StringPtr("test-example"),
+							Permissions: v1alpha1.GrantPermissions{"SELECT"},
+						},
+					},
+				},
+			},
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: false},
+			},
+		},
 		"SuccessDiffPermissions": {
 			reason: "We should return no error if different permissions exist",
 			fields: fields{
@@ -296,8 +477,8 @@ func TestObserve(t *testing.T) {
 					MockQuery: func(ctx context.Context, q xsql.Query) (*sql.Rows, error) {
 						return mockRowsToSQLRows(
 							sqlmock.NewRows(
-								[]string{"Grants"},
-							).AddRow("CREATE TABLE"),
+								[]string{"Grants", "State"},
+							).AddRow("CREATE TABLE", "GRANT"),
 						), nil
 					},
 				},
@@ -326,10 +507,10 @@ func TestObserve(t *testing.T) {
 				db: mockDB{
 					MockQuery: func(ctx context.Context, q xsql.Query) (*sql.Rows, error) {
 						return mockRowsToSQLRows(
-							sqlmock.NewRows([]string{"Grants"}).
-								AddRow("CREATE").
-								AddRow("DELETE").
-								AddRow("EVENT"),
+							sqlmock.NewRows([]string{"Grants", "State"}).
+								AddRow("CREATE", "GRANT").
+								AddRow("DELETE", "GRANT").
+								AddRow("EVENT", "GRANT"),
 						), nil
 					},
 				},
@@ -353,11 +534,144 @@ func TestObserve(t *testing.T) {
 				err: nil,
 			},
 		},
+		"SuccessDiffState": {
+			reason: "We should return ResourceUpToDate: false if the permissions are in a different state",
+			fields: fields{
+				db: mockDB{
+					MockQuery: func(ctx context.Context, q xsql.Query) (*sql.Rows, error) {
+						return mockRowsToSQLRows(
+							sqlmock.NewRows(
+								[]string{"Grants", "State"},
+							).AddRow("SELECT", "GRANT"),
+						), nil
+					},
+				},
+			},
+			args: args{
+				mg: &v1alpha1.Grant{
+					Spec: v1alpha1.GrantSpec{
+						ForProvider: v1alpha1.GrantParameters{
+							Database:    pointer.StringPtr("success-db"),
+							User:        pointer.StringPtr("success-user"),
+							Permissions: v1alpha1.GrantPermissions{"SELECT"},
+							State:       stateP(v1alpha1.GrantStateDeny),
+						},
+					},
+				},
+			},
+			want: want{
+				o: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: false,
+				},
+			},
+		},
+		"SuccessUndesiredOtherState": {
+			reason: "We should return ResourceUpToDate: false if a permission that is not desired is in a different state",
+			fields: fields{
+				db: mockDB{
+					MockQuery: func(ctx context.Context, q xsql.Query) (*sql.Rows, error) {
+						return mockRowsToSQLRows(
+							sqlmock.NewRows(
+								[]string{"Grants", "State"},
+							).AddRow("SELECT", "GRANT").AddRow("DELETE", "DENY"),
+						), nil
+					},
+				},
+			},
+			args: args{
+				mg: &v1alpha1.Grant{
+					Spec: v1alpha1.GrantSpec{
+						ForProvider: v1alpha1.GrantParameters{
+							Database:    pointer.StringPtr("success-db"),
+							User:        pointer.StringPtr("success-user"),
+							Permissions: v1alpha1.GrantPermissions{"SELECT"},
+						},
+					},
+				},
+			},
+			want: want{
+				o: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: false,
+				},
+			},
+		},
+		"SuccessSecurable": {
+			reason: "We should only observe the permissions on the securable of the grant",
+			fields: fields{
+				db: mockDB{
+					MockQuery: func(ctx context.Context, q xsql.Query) (*sql.Rows, error) {
+						if !strings.Contains(q.String, "pe.class = 3 AND pe.major_id = SCHEMA_ID(@p2)") || q.Parameters[1] != "sales" {
+							return nil, errBoom
+						}
+						return mockRowsToSQLRows(
+							sqlmock.NewRows(
+								[]string{"Grants", "State"},
+							).AddRow("SELECT", "GRANT"),
+						), nil
+					},
+				},
+			},
+			args: args{
+				mg: &v1alpha1.Grant{
+					Spec: v1alpha1.GrantSpec{
+						ForProvider: v1alpha1.GrantParameters{
+							Database:    pointer.StringPtr("success-db"),
+							User:        pointer.StringPtr("success-user"),
+							Permissions: v1alpha1.GrantPermissions{"SELECT"},
+							Securable:   pointer.StringPtr("SCHEMA::sales"),
+						},
+					},
+				},
+			},
+			want: want{
+				o: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: true,
+				},
+			},
+		},
+		"SuccessQuotedNames": {
+			reason: "We should select the permissions of a user on an object whose names need quoting by the unquoted user name and the quoted object name",
+			fields: fields{
+				db: mockDB{
+					MockQuery: func(ctx context.Context, q xsql.Query) (*sql.Rows, error) {
+						if !strings.Contains(q.String, "pe.major_id = OBJECT_ID(@p2)") || q.Parameters[0] != "o'brien]" || q.Parameters[1] != "[dbo].[o'rders]]x]" {
+							return nil, errBoom
+						}
+						return mockRowsToSQLRows(
+							sqlmock.NewRows(
+								[]string{"Grants", "State"},
+							).AddRow("SELECT", "GRANT"),
+						), nil
+					},
+				},
+			},
+			args: args{
+				mg: &v1alpha1.Grant{
+					Spec: v1alpha1.GrantSpec{
+						ForProvider: v1alpha1.GrantParameters{
+							Database:    pointer.StringPtr("success-db"),
+							User:        pointer.StringPtr("o'brien]"),
+							Permissions: v1alpha1.GrantPermissions{"SELECT"},
+							Securable:   pointer.StringPtr("OBJECT::dbo.[o'rders]]x]"),
+						},
+					},
+				},
+			},
+			want: want{
+				o: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: true,
+				},
+			},
+		},
 	}
 
 	for name, tc := range cases {
 		t.Run(name, func(t *testing.T) {
-			e := external{db: tc.fields.db}
+			e := external{db: tc.fields.db, kube: tc.fields.kube}
 			got, err := e.Observe(tc.args.ctx, tc.args.mg)
 			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
 				t.Errorf("\n%s\ne.Observe(...): -want error, +got error:\n%s\n", tc.reason, diff)
@@ -405,7 +719,8 @@ func TestCreate(t *testing.T) {
 			reason: "Any errors encountered while creating the grant should be returned",
 			fields: fields{
 				db: &mockDB{
-					MockExec: func(ctx context.Context, q xsql.Query) error { return errBoom },
+					MockQuery: queryPermissions(noGrants()),
+					MockExec:  func(ctx context.Context, q xsql.Query) error { return errBoom },
 				},
 			},
 			args: args{
@@ -422,11 +737,43 @@ func TestCreate(t *testing.T) {
 				err: errors.Wrap(errBoom, errGrant),
 			},
 		},
+		"ErrNoUser": {
+			reason: "An error should be returned if the user reference has not been resolved",
+			args: args{
+				mg: &v1alpha1.Grant{},
+			},
+			want: want{
+				err: errors.New(errNoUser),
+			},
+		},
+		"ErrUnknownPermission": {
+			reason: "An error should be returned if a permission can not be granted on the securable",
+			fields: fields{
+				db: &mockDB{
+					MockQuery: queryPermissions(noGrants(), "ALTER", "SELECT"),
+				},
+			},
+			args: args{
+				mg: &v1alpha1.Grant{
+					Spec: v1alpha1.GrantSpec{
+						ForProvider: v1alpha1.GrantParameters{
+							User:        pointer.StringPtr("test-example"),
+							Permissions: v1alpha1.GrantPermissions{"SELECT", "CREATE TABLE"},
+							Securable:   pointer.StringPtr("SCHEMA::sales"),
+						},
+					},
+				},
+			},
+			want: want{
+				err: errors.Errorf("%s CREATE TABLE on SCHEMA", errUnknownPerm),
+			},
+		},
 		"Success": {
 			reason: "No error should be returned when we successfully create a grant",
 			fields: fields{
 				db: &mockDB{
-					MockExec: func(ctx context.Context, q xsql.Query) error { return nil },
+					MockQuery: queryPermissions(noGrants(), "CREATE", "DELETE"),
+					MockExec:  func(ctx context.Context, q xsql.Query) error { return nil },
 				},
 			},
 			args: args{
@@ -444,6 +791,35 @@ func TestCreate(t *testing.T) {
 				err: nil,
 			},
 		},
+		"SuccessQuotedNames": {
+			reason: "The user and the securable of the grant should be quoted",
+			fields: fields{
+				db: &mockDB{
+					MockQuery: queryPermissions(noGrants(), "SELECT"),
+					MockExec: func(ctx context.Context, q xsql.Query) error {
+						if q.String != "GRANT SELECT ON OBJECT::[dbo].[o'rders]]x] TO [o'brien]]]" {
+							return errBoom
+						}
+						return nil
+					},
+				},
+			},
+			args: args{
+				mg: &v1alpha1.Grant{
+					Spec: v1alpha1.GrantSpec{
+						ForProvider: v1alpha1.GrantParameters{
+							Database:    pointer.StringPtr("test-example"),
+							User:        pointer.StringPtr("o'brien]"),
+							Permissions: v1alpha1.GrantPermissions{"SELECT"},
+							Securable:   pointer.StringPtr("OBJECT::dbo.[o'rders]]x]"),
+						},
+					},
+				},
+			},
+			want: want{
+				err: nil,
+			},
+		},
 	}
 
 	for name, tc := range cases {
@@ -496,10 +872,8 @@ func TestUpdate(t *testing.T) {
 			reason: "Any errors encountered while updating the grant should be returned",
 			fields: fields{
 				db: &mockDB{
-					MockQuery: func(ctx context.Context, q xsql.Query) (*sql.Rows, error) {
-						return mockRowsToSQLRows(sqlmock.NewRows([]string{})), nil
-					},
-					MockExec: func(ctx context.Context, q xsql.Query) error { return errBoom },
+					MockQuery: queryPermissions(noGrants(), "CREATE", "DELETE"),
+					MockExec:  func(ctx context.Context, q xsql.Query) error { return errBoom },
 				},
 			},
 			args: args{
@@ -521,9 +895,7 @@ func TestUpdate(t *testing.T) {
 			reason: "No error should be returned when we update a grant",
 			fields: fields{
 				db: &mockDB{
-					MockQuery: func(ctx context.Context, q xsql.Query) (*sql.Rows, error) {
-						return mockRowsToSQLRows(sqlmock.NewRows([]string{})), nil
-					},
+					MockQuery: queryPermissions(noGrants(), "CREATE", "DELETE"),
 					MockExec: func(ctx context.Context, q xsql.Query) error {
 						if strings.Contains(q.String, "CREATE, DELETE") {
 							return nil
@@ -548,6 +920,107 @@ func TestUpdate(t *testing.T) {
 				c:   managed.ExternalUpdate{},
 			},
 		},
+		"SuccessDowngrade": {
+			reason: "The grant option should be revoked on its own when it is no longer desired",
+			fields: fields{
+				db: &mockDB{
+					MockQuery: queryPermissions(
+						sqlmock.NewRows([]string{"Grants", "State"}).AddRow("SELECT", "GRANT_WITH_GRANT_OPTION"),
+						"SELECT",
+					),
+					MockExec: func(ctx context.Context, q xsql.Query) error {
+						if q.String == "REVOKE GRANT OPTION FOR SELECT ON OBJECT::[dbo].[orders] FROM [test-example] CASCADE" {
+							return nil
+						}
+						return errBoom
+					},
+				},
+			},
+			args: args{
+				mg: &v1alpha1.Grant{
+					Spec: v1alpha1.GrantSpec{
+						ForProvider: v1alpha1.GrantParameters{
+							Database:    pointer.StringPtr("test-example"),
+							User:        pointer.StringPtr("test-example"),
+							Permissions: v1alpha1.GrantPermissions{"SELECT"},
+							Securable:   pointer.StringPtr("OBJECT::dbo.orders"),
+						},
+					},
+				},
+			},
+			want: want{
+				c: managed.ExternalUpdate{},
+			},
+		},
+		"SuccessDeny": {
+			reason: "Permissions should be denied when the state of the grant is DENY",
+			fields: fields{
+				db: &mockDB{
+					MockQuery: queryPermissions(noGrants(), "DELETE"),
+					MockExec: func(ctx context.Context, q xsql.Query) error {
+						if q.String == "DENY DELETE ON SCHEMA::[sales] TO [test-example]" {
+							return nil
+						}
+						return errBoom
+					},
+				},
+			},
+			args: args{
+				mg: &v1alpha1.Grant{
+					Spec: v1alpha1.GrantSpec{
+						ForProvider: v1alpha1.GrantParameters{
+							Database:    pointer.StringPtr("test-example"),
+							User:        pointer.StringPtr("test-example"),
+							Permissions: v1alpha1.GrantPermissions{"DELETE"},
+							Securable:   pointer.StringPtr("SCHEMA::sales"),
+							State:       stateP(v1alpha1.GrantStateDeny),
+						},
+					},
+				},
+			},
+			want: want{
+				c: managed.ExternalUpdate{},
+			},
+		},
+		"SuccessRevokeOtherStates": {
+			reason: "Permissions that are not desired should be revoked in each state they are in",
+			fields: fields{
+				db: &mockDB{
+					MockQuery: queryPermissions(
+						sqlmock.NewRows([]string{"Grants", "State"}).
+							AddRow("DELETE", "DENY").
+							AddRow("INSERT", "GRANT_WITH_GRANT_OPTION").
+							AddRow("UPDATE", "GRANT"),
+						"SELECT",
+					),
+					MockExec: func(ctx context.Context, q xsql.Query) error {
+						switch q.String {
+						case "REVOKE DELETE ON SCHEMA::[sales] FROM [test-example]",
+							"REVOKE INSERT ON SCHEMA::[sales] FROM [test-example] CASCADE",
+							"REVOKE UPDATE ON SCHEMA::[sales] FROM [test-example]",
+							"GRANT SELECT ON SCHEMA::[sales] TO [test-example]":
+							return nil
+						}
+						return errBoom
+					},
+				},
+			},
+			args: args{
+				mg: &v1alpha1.Grant{
+					Spec: v1alpha1.GrantSpec{
+						ForProvider: v1alpha1.GrantParameters{
+							Database:    pointer.StringPtr("test-example"),
+							User:        pointer.StringPtr("test-example"),
+							Permissions: v1alpha1.GrantPermissions{"SELECT"},
+							Securable:   pointer.StringPtr("SCHEMA::sales"),
+						},
+					},
+				},
+			},
+			want: want{
+				c: managed.ExternalUpdate{},
+			},
+		},
 	}
 
 	for name, tc := range cases {
@@ -612,6 +1085,23 @@ func TestDelete(t *testing.T) {
 			},
 			want: errors.Wrap(errBoom, errRevoke),
 		},
+		"SuccessNoUser": {
+			reason: "Nothing should be revoked if the user reference was never resolved",
+			args: args{
+				mg: &v1alpha1.Grant{
+					Spec: v1alpha1.GrantSpec{
+						ForProvider: v1alpha1.GrantParameters{
+							Database:    pointer.StringPtr("test-example"),
+							Permissions: v1alpha1.GrantPermissions{"SELECT"},
+						},
+					},
+				},
+			},
+			fields: fields{
+				db: &mockDB{},
+			},
+			want: nil,
+		},
 		"Success": {
 			reason: "No error should be returned if the grant was revoked",
 			args: args{
@@ -631,6 +1121,32 @@ func TestDelete(t *testing.T) {
 			},
 			want: nil,
 		},
+		"SuccessQuotedNames": {
+			reason: "The user and the securable of the grant should be quoted when it is revoked",
+			args: args{
+				mg: &v1alpha1.Grant{
+					Spec: v1alpha1.GrantSpec{
+						ForProvider: v1alpha1.GrantParameters{
+							Database:    pointer.StringPtr("test-example"),
+							User:        pointer.StringPtr("o'brien]"),
+							Permissions: v1alpha1.GrantPermissions{"SELECT"},
+							Securable:   pointer.StringPtr("OBJECT::dbo.[o'rders]]x]"),
+						},
+					},
+				},
+			},
+			fields: fields{
+				db: &mockDB{
+					MockExec: func(ctx context.Context, q xsql.Query) error {
+						if q.String != "REVOKE SELECT ON OBJECT::[dbo].[o'rders]]x] FROM [o'brien]]]" {
+							return errBoom
+						}
+						return nil
+					},
+				},
+			},
+			want: nil,
+		},
 	}
 
 	for name, tc := range cases {
@@ -751,3 +1267,26 @@ func equateSlices() cmp.Option {
 		return x < y
 	})
 }
+
+func noGrants() *sqlmock.Rows {
+	return sqlmock.NewRows([]string{"Grants", "State"})
+}
+
+// queryPermissions returns a MockQuery that selects the supplied granted
+// permissions, and the supplied built-in permissions of the securable.
+func queryPermissions(granted *sqlmock.Rows, builtins ...string) func(ctx context.Context, q xsql.Query) (*sql.Rows, error) {
+	return func(ctx context.Context, q xsql.Query) (*sql.Rows, error) {
+		if strings.Contains(q.String, "fn_builtin_permissions") {
+			rows := sqlmock.NewRows([]string{"permission_name"})
+			for _, p := range builtins {
+				rows.AddRow(p)
+			}
+			return mockRowsToSQLRows(rows), nil
+		}
+		return mockRowsToSQLRows(granted), nil
+	}
+}
+
+func stateP(s v1alpha1.GrantState) *v1alpha1.GrantState {
+	return &s
+}
@@ -0,0 +1,370 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package login
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/password"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/crossplane-contrib/provider-sql/apis/mssql/v1alpha1"
+	"github.com/crossplane-contrib/provider-sql/pkg/clients/mssql"
+	"github.com/crossplane-contrib/provider-sql/pkg/clients/xsql"
+)
+
+const (
+	errTrackPCUsage = "cannot track ProviderConfig usage"
+	errGetPC        = "cannot get ProviderConfig"
+	errNoSecretRef  = "ProviderConfig does not reference a credentials Secret"
+	errGetSecret    = "cannot get credentials Secret"
+
+	errNotLogin                = "managed resource is not a Login custom resource"
+	errSelectLogin             = "cannot select login"
+	errCreateLogin             = "cannot create login"
+	errDropLogin               = "cannot drop login"
+	errUpdateLogin             = "cannot update login"
+	errSelectServerRoles       = "cannot select server roles of login"
+	errUpdateServerRoles       = "cannot update server roles of login"
+	errGetPasswordSecretFailed = "cannot get password secret"
+
+	maxConcurrency = 5
+)
+
+// Setup adds a controller that reconciles Login managed resources.
+func Setup(mgr ctrl.Manager, l logging.Logger) error {
+	name := managed.ControllerName(v1alpha1.LoginGroupKind)
+
+	t := resource.NewProviderConfigUsageTracker(mgr.GetClient(), &v1alpha1.ProviderConfigUsage{})
+	r := managed.NewReconciler(mgr,
+		resource.ManagedKind(v1alpha1.LoginGroupVersionKind),
+		managed.WithExternalConnecter(&connector{kube: mgr.GetClient(), usage: t, newClient: mssql.New}),
+		managed.WithReferenceResolver(managed.NewAPISimpleReferenceResolver(mgr.GetClient())),
+		managed.WithLogger(l.WithValues("controller", name)),
+		managed.WithPollInterval(10*time.Minute),
+		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))))
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		For(&v1alpha1.Login{}).
+		WithOptions(controller.Options{
+			MaxConcurrentReconciles: maxConcurrency,
+		}).
+		Complete(r)
+}
+
+type connector struct {
+	kube      client.Client
+	usage     resource.Tracker
+	newClient func(creds map[string][]byte, database string) xsql.DB
+}
+
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	cr, ok := mg.(*v1alpha1.Login)
+	if !ok {
+		return nil, errors.New(errNotLogin)
+	}
+
+	if err := c.usage.Track(ctx, mg); err != nil {
+		return nil, errors.Wrap(err, errTrackPCUsage)
+	}
+
+	// ProviderConfigReference could theoretically be nil, but in practice the
+	// DefaultProviderConfig initializer will set it before we get here.
+	pc := &v1alpha1.ProviderConfig{}
+	if err := c.kube.Get(ctx, types.NamespacedName{Name: cr.GetProviderConfigReference().Name}, pc); err != nil {
+		return nil, errors.Wrap(err, errGetPC)
+	}
+
+	// We don't need to check the credentials source because we currently only
+	// support one source (MSSQLConnectionSecret), which is required and
+	// enforced by the ProviderConfig schema.
+	ref := pc.Spec.Credentials.ConnectionSecretRef
+	if ref == nil {
+		return nil, errors.New(errNoSecretRef)
+	}
+
+	s := &corev1.Secret{}
+	if err := c.kube.Get(ctx, types.NamespacedName{Namespace: ref.Namespace, Name: ref.Name}, s); err != nil {
+		return nil, errors.Wrap(err, errGetSecret)
+	}
+
+	// Logins are server principals, so we connect to the default database
+	// of the ProviderConfig.
+	return &external{
+		db:   c.newClient(s.Data, ""),
+		kube: c.kube,
+	}, nil
+}
+
+type external struct {
+	db   xsql.DB
+	kube client.Client
+}
+
+func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.Login)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotLogin)
+	}
+
+	observed := v1alpha1.LoginObservation{}
+
+	query := "SELECT p.default_database_name, l.is_policy_checked, l.is_expiration_checked " +
+		"FROM sys.server_principals p JOIN sys.sql_logins l ON l.principal_id = p.principal_id " +
+		"WHERE p.type = 'S' AND p.name = @p1"
+	err := c.db.Scan(ctx, xsql.Query{String: query, Parameters: []interface{}{
+		meta.GetExternalName(cr)},
+	}, &observed.DefaultDatabase, &observed.CheckPolicy, &observed.CheckExpiration)
+	if xsql.IsNoRows(err) {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errSelectLogin)
+	}
+
+	rolesUpToDate := true
+	if len(cr.Spec.ForProvider.ServerRoles) > 0 {
+		if observed.ServerRoles, err = c.observeServerRoles(ctx, cr); err != nil {
+			return managed.ExternalObservation{}, errors.Wrap(err, errSelectServerRoles)
+		}
+		rolesUpToDate = cmp.Equal(cr.Spec.ForProvider.ServerRoles, observed.ServerRoles, cmpopts.SortSlices(func(a, b string) bool { return a < b }), cmpopts.EquateEmpty())
+	}
+
+	cr.Status.AtProvider = observed
+
+	cr.SetConditions(xpv1.Available())
+
+	_, pwdChanged, err := c.getPassword(ctx, cr)
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
+
+	return managed.ExternalObservation{
+		ResourceExists:   true,
+		ResourceUpToDate: !pwdChanged && rolesUpToDate && len(changedOptions(cr.Spec.ForProvider, observed)) == 0,
+	}, nil
+}
+
+// observeServerRoles returns the server roles the login is a member of.
+func (c *external) observeServerRoles(ctx context.Context, cr *v1alpha1.Login) ([]string, error) {
+	query := "SELECT r.name " +
+		"FROM sys.server_role_members m " +
+		"JOIN sys.server_principals r ON r.principal_id = m.role_principal_id " +
+		"JOIN sys.server_principals l ON l.principal_id = m.member_principal_id " +
+		"WHERE l.name = @p1 ORDER BY r.name"
+	rows, err := c.db.Query(ctx, xsql.Query{String: query, Parameters: []interface{}{
+		meta.GetExternalName(cr)},
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close() //nolint:errcheck
+
+	var roles []string
+	for rows.Next() {
+		var r string
+		if err := rows.Scan(&r); err != nil {
+			return nil, err
+		}
+		roles = append(roles, r)
+	}
+	return roles, rows.Err()
+}
+
+// updateServerRoles adds the login to the desired server roles it is not a
+// member of, and removes it from the server roles that are not desired.
+func (c *external) updateServerRoles(ctx context.Context, cr *v1alpha1.Login) error {
+	desired := cr.Spec.ForProvider.ServerRoles
+	if len(desired) == 0 {
+		return nil
+	}
+
+	name := mssql.QuoteIdentifier(meta.GetExternalName(cr))
+	for _, r := range difference(desired, cr.Status.AtProvider.ServerRoles) {
+		if err := c.db.Exec(ctx, xsql.Query{
+			String: fmt.Sprintf("ALTER SERVER ROLE %s ADD MEMBER %s", mssql.QuoteIdentifier(r), name),
+		}); err != nil {
+			return errors.Wrap(err, errUpdateServerRoles)
+		}
+	}
+	for _, r := range difference(cr.Status.AtProvider.ServerRoles, desired) {
+		if err := c.db.Exec(ctx, xsql.Query{
+			String: fmt.Sprintf("ALTER SERVER ROLE %s DROP MEMBER %s", mssql.QuoteIdentifier(r), name),
+		}); err != nil {
+			return errors.Wrap(err, errUpdateServerRoles)
+		}
+	}
+
+	cr.Status.AtProvider.ServerRoles = desired
+	return nil
+}
+
+// difference returns the elements of a that are not in b.
+func difference(a, b []string) []string {
+	set := map[string]bool{}
+	for _, s := range b {
+		set[s] = true
+	}
+	var out []string
+	for _, s := range a {
+		if !set[s] {
+			out = append(out, s)
+		}
+	}
+	return out
+}
+
+func onOff(b bool) string {
+	if b {
+		return "ON"
+	}
+	return "OFF"
+}
+
+// options returns the desired options of a login, as set by CREATE LOGIN.
+func options(p v1alpha1.LoginParameters) []string {
+	var o []string
+	if p.DefaultDatabase != nil {
+		o = append(o, fmt.Sprintf("DEFAULT_DATABASE=%s", mssql.QuoteIdentifier(*p.DefaultDatabase)))
+	}
+	if p.CheckPolicy != nil {
+		o = append(o, fmt.Sprintf("CHECK_POLICY=%s", onOff(*p.CheckPolicy)))
+	}
+	if p.CheckExpiration != nil {
+		o = append(o, fmt.Sprintf("CHECK_EXPIRATION=%s", onOff(*p.CheckExpiration)))
+	}
+	return o
+}
+
+// changedOptions returns the desired options of a login that differ from the
+// observed ones. Options that are not set are not compared.
+func changedOptions(p v1alpha1.LoginParameters, observed v1alpha1.LoginObservation) []string {
+	changed := v1alpha1.LoginParameters{}
+	if p.DefaultDatabase != nil && *p.DefaultDatabase != observed.DefaultDatabase {
+		changed.DefaultDatabase = p.DefaultDatabase
+	}
+	if p.CheckPolicy != nil && *p.CheckPolicy != observed.CheckPolicy {
+		changed.CheckPolicy = p.CheckPolicy
+	}
+	if p.CheckExpiration != nil && *p.CheckExpiration != observed.CheckExpiration {
+		changed.CheckExpiration = p.CheckExpiration
+	}
+	return options(changed)
+}
+
+func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1alpha1.Login)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotLogin)
+	}
+
+	pw, _, err := c.getPassword(ctx, cr)
+	if err != nil {
+		return managed.ExternalCreation{}, err
+	}
+	if pw == "" {
+		pw, err = password.Generate()
+		if err != nil {
+			return managed.ExternalCreation{}, err
+		}
+	}
+
+	o := append([]string{fmt.Sprintf("PASSWORD=%s", mssql.QuoteValue(pw))}, options(cr.Spec.ForProvider)...)
+	query := fmt.Sprintf("CREATE LOGIN %s WITH %s", mssql.QuoteIdentifier(meta.GetExternalName(cr)), strings.Join(o, ", "))
+	if err := c.db.Exec(ctx, xsql.Query{
+		String: query,
+	}); err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errCreateLogin)
+	}
+
+	return managed.ExternalCreation{
+		ConnectionDetails: c.db.GetConnectionDetails(meta.GetExternalName(cr), pw),
+	}, nil
+}
+
+func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*v1alpha1.Login)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotLogin)
+	}
+
+	pw, changed, err := c.getPassword(ctx, cr)
+	if err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+
+	if err := c.updateServerRoles(ctx, cr); err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+
+	o := changedOptions(cr.Spec.ForProvider, cr.Status.AtProvider)
+	if changed {
+		o = append([]string{fmt.Sprintf("PASSWORD=%s", mssql.QuoteValue(pw))}, o...)
+	}
+	if len(o) == 0 {
+		return managed.ExternalUpdate{}, nil
+	}
+
+	query := fmt.Sprintf("ALTER LOGIN %s WITH %s", mssql.QuoteIdentifier(meta.GetExternalName(cr)), strings.Join(o, ", "))
+	if err := c.db.Exec(ctx, xsql.Query{
+		String: query,
+	}); err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errUpdateLogin)
+	}
+
+	if !changed {
+		return managed.ExternalUpdate{}, nil
+	}
+	return managed.ExternalUpdate{
+		ConnectionDetails: c.db.GetConnectionDetails(meta.GetExternalName(cr), pw),
+	}, nil
+}
+
+func (c *external) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1alpha1.Login)
+	if !ok {
+		return errors.New(errNotLogin)
+	}
+
+	// DROP LOGIN does not support IF EXISTS.
+	query := fmt.Sprintf("IF EXISTS (SELECT 1 FROM sys.server_principals WHERE name = @p1) DROP LOGIN %s", mssql.QuoteIdentifier(meta.GetExternalName(cr)))
+	if err := c.db.Exec(ctx, xsql.Query{
+		String:     query,
+		Parameters: []interface{}{meta.GetExternalName(cr)},
+	}); err != nil {
+		return errors.Wrap(err, errDropLogin)
+	}
+	return nil
+}
@@ -22,8 +22,12 @@ import (
 
 	"github.com/crossplane/crossplane-runtime/pkg/logging"
 
+	"github.com/crossplane-contrib/provider-sql/pkg/controller/mssql/agentjob"
 	"github.com/crossplane-contrib/provider-sql/pkg/controller/mssql/config"
 	"github.com/crossplane-contrib/provider-sql/pkg/controller/mssql/database"
+	"github.com/crossplane-contrib/provider-sql/pkg/controller/mssql/login"
+	"github.com/crossplane-contrib/provider-sql/pkg/controller/mssql/role"
+	"github.com/crossplane-contrib/provider-sql/pkg/controller/mssql/schema"
 	"github.com/crossplane-contrib/provider-sql/pkg/controller/mssql/user"
 )
 
@@ -33,8 +37,12 @@ func Setup(mgr ctrl.Manager, l logging.Logger) error {
 	for _, setup := range []func(ctrl.Manager, logging.Logger) error{
 		config.Setup,
 		database.Setup,
+		login.Setup,
+		role.Setup,
+		schema.Setup,
 		user.Setup,
 		grant.Setup,
+		agentjob.Setup,
 	} {
 		if err := setup(mgr, l); err != nil {
 			return err
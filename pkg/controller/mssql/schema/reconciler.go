@@ -0,0 +1,205 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package schema
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/pointer"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/crossplane-contrib/provider-sql/apis/mssql/v1alpha1"
+	"github.com/crossplane-contrib/provider-sql/pkg/clients/mssql"
+	"github.com/crossplane-contrib/provider-sql/pkg/clients/xsql"
+)
+
+const (
+	errTrackPCUsage = "cannot track ProviderConfig usage"
+	errGetPC        = "cannot get ProviderConfig"
+	errNoSecretRef  = "ProviderConfig does not reference a credentials Secret"
+	errGetSecret    = "cannot get credentials Secret"
+
+	errNotSchema    = "managed resource is not a Schema custom resource"
+	errSelectSchema = "cannot select schema"
+	errCreateSchema = "cannot create schema"
+	errUpdateSchema = "cannot update schema"
+	errDropSchema   = "cannot drop schema"
+	errNotEmpty     = "cannot drop schema: it still contains objects"
+
+	maxConcurrency = 5
+)
+
+// Setup adds a controller that reconciles Schema managed resources.
+func Setup(mgr ctrl.Manager, l logging.Logger) error {
+	name := managed.ControllerName(v1alpha1.SchemaGroupKind)
+
+	t := resource.NewProviderConfigUsageTracker(mgr.GetClient(), &v1alpha1.ProviderConfigUsage{})
+	r := managed.NewReconciler(mgr,
+		resource.ManagedKind(v1alpha1.SchemaGroupVersionKind),
+		managed.WithExternalConnecter(&connector{kube: mgr.GetClient(), usage: t, newClient: mssql.New}),
+		managed.WithReferenceResolver(managed.NewAPISimpleReferenceResolver(mgr.GetClient())),
+		managed.WithLogger(l.WithValues("controller", name)),
+		managed.WithPollInterval(10*time.Minute),
+		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))))
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		For(&v1alpha1.Schema{}).
+		WithOptions(controller.Options{
+			MaxConcurrentReconciles: maxConcurrency,
+		}).
+		Complete(r)
+}
+
+type connector struct {
+	kube      client.Client
+	usage     resource.Tracker
+	newClient func(creds map[string][]byte, database string) xsql.DB
+}
+
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	cr, ok := mg.(*v1alpha1.Schema)
+	if !ok {
+		return nil, errors.New(errNotSchema)
+	}
+
+	if err := c.usage.Track(ctx, mg); err != nil {
+		return nil, errors.Wrap(err, errTrackPCUsage)
+	}
+
+	// ProviderConfigReference could theoretically be nil, but in practice the
+	// DefaultProviderConfig initializer will set it before we get here.
+	pc := &v1alpha1.ProviderConfig{}
+	if err := c.kube.Get(ctx, types.NamespacedName{Name: cr.GetProviderConfigReference().Name}, pc); err != nil {
+		return nil, errors.Wrap(err, errGetPC)
+	}
+
+	// We don't need to check the credentials source because we currently only
+	// support one source (MSSQLConnectionSecret), which is required and
+	// enforced by the ProviderConfig schema.
+	ref := pc.Spec.Credentials.ConnectionSecretRef
+	if ref == nil {
+		return nil, errors.New(errNoSecretRef)
+	}
+
+	s := &corev1.Secret{}
+	if err := c.kube.Get(ctx, types.NamespacedName{Namespace: ref.Namespace, Name: ref.Name}, s); err != nil {
+		return nil, errors.Wrap(err, errGetSecret)
+	}
+
+	return &external{db: c.newClient(s.Data, pointer.StringPtrDerefOr(cr.Spec.ForProvider.Database, ""))}, nil
+}
+
+type external struct{ db xsql.DB }
+
+func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.Schema)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotSchema)
+	}
+
+	var owner string
+	query := "SELECT pr.name FROM sys.schemas AS s " +
+		"JOIN sys.database_principals AS pr ON pr.principal_id = s.principal_id " +
+		"WHERE s.name = @p1"
+	err := c.db.Scan(ctx, xsql.Query{String: query, Parameters: []interface{}{meta.GetExternalName(cr)}}, &owner)
+	if xsql.IsNoRows(err) {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errSelectSchema)
+	}
+
+	cr.SetConditions(xpv1.Available())
+
+	li := false
+	if cr.Spec.ForProvider.Owner == nil {
+		cr.Spec.ForProvider.Owner = &owner
+		li = true
+	}
+
+	return managed.ExternalObservation{
+		ResourceExists:          true,
+		ResourceLateInitialized: li,
+		ResourceUpToDate:        *cr.Spec.ForProvider.Owner == owner,
+	}, nil
+}
+
+func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1alpha1.Schema)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotSchema)
+	}
+
+	query := "CREATE SCHEMA " + mssql.QuoteIdentifier(meta.GetExternalName(cr))
+	if cr.Spec.ForProvider.Owner != nil {
+		query += " AUTHORIZATION " + mssql.QuoteIdentifier(*cr.Spec.ForProvider.Owner)
+	}
+	err := c.db.Exec(ctx, xsql.Query{String: query})
+	return managed.ExternalCreation{}, errors.Wrap(err, errCreateSchema)
+}
+
+func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*v1alpha1.Schema)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotSchema)
+	}
+
+	// The owner is the only thing that can drift, and is always late
+	// initialized by the time we get here.
+	if cr.Spec.ForProvider.Owner == nil {
+		return managed.ExternalUpdate{}, nil
+	}
+	query := "ALTER AUTHORIZATION ON SCHEMA::" + mssql.QuoteIdentifier(meta.GetExternalName(cr)) +
+		" TO " + mssql.QuoteIdentifier(*cr.Spec.ForProvider.Owner)
+	err := c.db.Exec(ctx, xsql.Query{String: query})
+	return managed.ExternalUpdate{}, errors.Wrap(err, errUpdateSchema)
+}
+
+func (c *external) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1alpha1.Schema)
+	if !ok {
+		return errors.New(errNotSchema)
+	}
+
+	// We refuse to drop a schema that still contains objects rather than
+	// dropping them along with it.
+	var objects int
+	query := "SELECT COUNT(*) FROM sys.objects WHERE schema_id = SCHEMA_ID(@p1)"
+	if err := c.db.Scan(ctx, xsql.Query{String: query, Parameters: []interface{}{meta.GetExternalName(cr)}}, &objects); err != nil {
+		return errors.Wrap(err, errSelectSchema)
+	}
+	if objects > 0 {
+		return errors.New(errNotEmpty)
+	}
+
+	err := c.db.Exec(ctx, xsql.Query{String: "DROP SCHEMA IF EXISTS " + mssql.QuoteIdentifier(meta.GetExternalName(cr))})
+	return errors.Wrap(err, errDropSchema)
+}
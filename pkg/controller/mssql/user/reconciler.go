@@ -19,8 +19,11 @@ package user
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/types"
@@ -50,9 +53,12 @@ const (
 
 	errNotUser                 = "managed resource is not a User custom resource"
 	errSelectUser              = "cannot select user"
+	errSelectRoles             = "cannot select roles of user"
+	errUpdateRoles             = "cannot update roles of user"
 	errCreateUser              = "cannot create user"
 	errDropUser                = "cannot drop user"
 	errUpdateUser              = "cannot update user"
+	errAuthenticationChanged   = "user exists with a different authentication type"
 	errGetPasswordSecretFailed = "cannot get password secret"
 
 	maxConcurrency = 5
@@ -66,6 +72,7 @@ func Setup(mgr ctrl.Manager, l logging.Logger) error {
 	r := managed.NewReconciler(mgr,
 		resource.ManagedKind(v1alpha1.UserGroupVersionKind),
 		managed.WithExternalConnecter(&connector{kube: mgr.GetClient(), usage: t, newClient: mssql.New}),
+		managed.WithReferenceResolver(managed.NewAPISimpleReferenceResolver(mgr.GetClient())),
 		managed.WithLogger(l.WithValues("controller", name)),
 		managed.WithPollInterval(10*time.Minute),
 		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))))
@@ -132,12 +139,7 @@ func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 		return managed.ExternalObservation{}, errors.New(errNotUser)
 	}
 
-	var name string
-
-	query := "SELECT name FROM sys.database_principals WHERE type = 'S' AND name = @p1"
-	err := c.db.Scan(ctx, xsql.Query{String: query, Parameters: []interface{}{
-		meta.GetExternalName(cr)},
-	}, &name)
+	observed, err := c.observe(ctx, cr)
 	if xsql.IsNoRows(err) {
 		return managed.ExternalObservation{ResourceExists: false}, nil
 	}
@@ -145,25 +147,260 @@ func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 		return managed.ExternalObservation{}, errors.Wrap(err, errSelectUser)
 	}
 
+	// The authentication type of a user cannot be changed, so a user with a
+	// different one is not ours to manage.
+	cr.Status.AtProvider.AuthenticationType = observed.authenticationType
+	if observed.authenticationType != authenticationType(cr.Spec.ForProvider) {
+		return managed.ExternalObservation{}, errors.New(errAuthenticationChanged)
+	}
+
 	cr.SetConditions(xpv1.Available())
 
+	li := lateInit(observed, &cr.Spec.ForProvider)
+	changed := changedDefaults(observed, cr.Spec.ForProvider)
+	upToDate := changed.DefaultSchema == nil && changed.DefaultLanguage == nil
+
+	if len(cr.Spec.ForProvider.Roles) > 0 {
+		roles, err := c.observeRoles(ctx, cr)
+		if err != nil {
+			return managed.ExternalObservation{}, errors.Wrap(err, errSelectRoles)
+		}
+		cr.Status.AtProvider.Roles = roles
+		upToDate = upToDate && cmp.Equal(cr.Spec.ForProvider.Roles, roles, cmpopts.SortSlices(func(a, b string) bool { return a < b }), cmpopts.EquateEmpty())
+	}
+
+	if cr.Spec.ForProvider.Login != nil {
+		return managed.ExternalObservation{
+			ResourceExists:          true,
+			ResourceLateInitialized: li,
+			ResourceUpToDate:        upToDate && !loginChanged(observed, cr.Spec.ForProvider),
+		}, nil
+	}
+
+	if authentication(cr.Spec.ForProvider) != v1alpha1.UserAuthenticationPassword {
+		return managed.ExternalObservation{
+			ResourceExists:          true,
+			ResourceLateInitialized: li,
+			ResourceUpToDate:        upToDate,
+		}, nil
+	}
+
 	_, pwdChanged, err := c.getPassword(ctx, cr)
 	if err != nil {
 		return managed.ExternalObservation{}, err
 	}
 
 	return managed.ExternalObservation{
-		ResourceExists:   true,
-		ResourceUpToDate: !pwdChanged,
+		ResourceExists:          true,
+		ResourceLateInitialized: li,
+		ResourceUpToDate:        upToDate && !pwdChanged,
 	}, nil
 }
 
+// A principal is the observed state of a database user.
+type principal struct {
+	login              *string
+	defaultSchema      *string
+	defaultLanguage    *string
+	authenticationType string
+}
+
+// observe returns the observed state of the user. External users and groups
+// are principals of type E and X respectively.
+func (c *external) observe(ctx context.Context, cr *v1alpha1.User) (principal, error) {
+	p := principal{}
+	query := "SELECT SUSER_SNAME(sid), default_schema_name, default_language_name, authentication_type_desc " +
+		"FROM sys.database_principals WHERE type IN ('S', 'E', 'X') AND name = @p1"
+	err := c.db.Scan(ctx, xsql.Query{String: query, Parameters: []interface{}{
+		meta.GetExternalName(cr)},
+	}, &p.login, &p.defaultSchema, &p.defaultLanguage, &p.authenticationType)
+	return p, err
+}
+
+// authentication returns how the user authenticates if it is not created
+// for a login.
+func authentication(p v1alpha1.UserParameters) v1alpha1.UserAuthentication {
+	if p.Authentication != nil {
+		return *p.Authentication
+	}
+	return v1alpha1.UserAuthenticationPassword
+}
+
+// authenticationType returns the authentication type of the user as
+// recorded by sys.database_principals.
+func authenticationType(p v1alpha1.UserParameters) string {
+	if p.Login != nil {
+		return "INSTANCE"
+	}
+	switch authentication(p) {
+	case v1alpha1.UserAuthenticationNone:
+		return "NONE"
+	case v1alpha1.UserAuthenticationExternalProvider:
+		return "EXTERNAL"
+	case v1alpha1.UserAuthenticationPassword:
+	}
+	return "DATABASE"
+}
+
+func lateInit(observed principal, desired *v1alpha1.UserParameters) bool {
+	li := false
+
+	if desired.DefaultSchema == nil && observed.defaultSchema != nil {
+		desired.DefaultSchema = observed.defaultSchema
+		li = true
+	}
+	if desired.DefaultLanguage == nil && observed.defaultLanguage != nil {
+		desired.DefaultLanguage = observed.defaultLanguage
+		li = true
+	}
+
+	return li
+}
+
+// changedDefaults returns the parameters holding only the desired default
+// schema and language of the user that differ from the observed ones.
+func changedDefaults(observed principal, desired v1alpha1.UserParameters) v1alpha1.UserParameters {
+	p := v1alpha1.UserParameters{}
+	if desired.DefaultSchema != nil && *desired.DefaultSchema != pointer.StringPtrDerefOr(observed.defaultSchema, "") {
+		p.DefaultSchema = desired.DefaultSchema
+	}
+	if desired.DefaultLanguage != nil && !strings.EqualFold(*desired.DefaultLanguage, pointer.StringPtrDerefOr(observed.defaultLanguage, "")) {
+		p.DefaultLanguage = desired.DefaultLanguage
+	}
+	return p
+}
+
+// loginChanged returns true if the user is desired to be mapped to another
+// login than the observed one.
+func loginChanged(observed principal, desired v1alpha1.UserParameters) bool {
+	return desired.Login != nil && *desired.Login != pointer.StringPtrDerefOr(observed.login, "")
+}
+
+// defaultsClause returns the options of CREATE USER or ALTER USER that set
+// the default schema and language of the user, if any.
+func defaultsClause(p v1alpha1.UserParameters) string {
+	var opts []string
+	if p.DefaultSchema != nil {
+		opts = append(opts, "DEFAULT_SCHEMA="+mssql.QuoteIdentifier(*p.DefaultSchema))
+	}
+	if p.DefaultLanguage != nil {
+		opts = append(opts, "DEFAULT_LANGUAGE="+mssql.QuoteIdentifier(*p.DefaultLanguage))
+	}
+	return strings.Join(opts, ", ")
+}
+
+// observeRoles returns the database roles the user is a member of.
+func (c *external) observeRoles(ctx context.Context, cr *v1alpha1.User) ([]string, error) {
+	query := "SELECT r.name " +
+		"FROM sys.database_role_members m " +
+		"JOIN sys.database_principals r ON r.principal_id = m.role_principal_id " +
+		"JOIN sys.database_principals u ON u.principal_id = m.member_principal_id " +
+		"WHERE u.name = @p1 ORDER BY r.name"
+	rows, err := c.db.Query(ctx, xsql.Query{String: query, Parameters: []interface{}{
+		meta.GetExternalName(cr)},
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close() //nolint:errcheck
+
+	var roles []string
+	for rows.Next() {
+		var r string
+		if err := rows.Scan(&r); err != nil {
+			return nil, err
+		}
+		roles = append(roles, r)
+	}
+	return roles, rows.Err()
+}
+
+// updateRoles adds the user to the desired roles it is not a member of, and
+// removes it from the roles that are not desired.
+func (c *external) updateRoles(ctx context.Context, cr *v1alpha1.User) error {
+	desired := cr.Spec.ForProvider.Roles
+	if len(desired) == 0 {
+		return nil
+	}
+
+	name := mssql.QuoteIdentifier(meta.GetExternalName(cr))
+	for _, r := range difference(desired, cr.Status.AtProvider.Roles) {
+		if err := c.db.Exec(ctx, xsql.Query{
+			String: fmt.Sprintf("ALTER ROLE %s ADD MEMBER %s", mssql.QuoteIdentifier(r), name),
+		}); err != nil {
+			return errors.Wrap(err, errUpdateRoles)
+		}
+	}
+	for _, r := range difference(cr.Status.AtProvider.Roles, desired) {
+		if err := c.db.Exec(ctx, xsql.Query{
+			String: fmt.Sprintf("ALTER ROLE %s DROP MEMBER %s", mssql.QuoteIdentifier(r), name),
+		}); err != nil {
+			return errors.Wrap(err, errUpdateRoles)
+		}
+	}
+
+	cr.Status.AtProvider.Roles = desired
+	return nil
+}
+
+// difference returns the elements of a that are not in b.
+func difference(a, b []string) []string {
+	set := map[string]bool{}
+	for _, s := range b {
+		set[s] = true
+	}
+	var out []string
+	for _, s := range a {
+		if !set[s] {
+			out = append(out, s)
+		}
+	}
+	return out
+}
+
 func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
 	cr, ok := mg.(*v1alpha1.User)
 	if !ok {
 		return managed.ExternalCreation{}, errors.New(errNotUser)
 	}
 
+	name := mssql.QuoteIdentifier(meta.GetExternalName(cr))
+	defaults := defaultsClause(cr.Spec.ForProvider)
+
+	if l := cr.Spec.ForProvider.Login; l != nil {
+		query := fmt.Sprintf("CREATE USER %s FOR LOGIN %s", name, mssql.QuoteIdentifier(*l))
+		if defaults != "" {
+			query += " WITH " + defaults
+		}
+		if err := c.db.Exec(ctx, xsql.Query{
+			String: query,
+		}); err != nil {
+			return managed.ExternalCreation{}, errors.Wrap(err, errCreateUser)
+		}
+
+		// The user authenticates with the login, whose connection details
+		// are published by the Login.
+		return managed.ExternalCreation{}, nil
+	}
+
+	if a := authentication(cr.Spec.ForProvider); a != v1alpha1.UserAuthenticationPassword {
+		query := "CREATE USER " + name + " WITHOUT LOGIN"
+		if a == v1alpha1.UserAuthenticationExternalProvider {
+			query = "CREATE USER " + name + " FROM EXTERNAL PROVIDER"
+		}
+		if defaults != "" {
+			query += " WITH " + defaults
+		}
+		if err := c.db.Exec(ctx, xsql.Query{
+			String: query,
+		}); err != nil {
+			return managed.ExternalCreation{}, errors.Wrap(err, errCreateUser)
+		}
+
+		// The user has no password, so there are no connection details.
+		return managed.ExternalCreation{}, nil
+	}
+
 	pw, _, err := c.getPassword(ctx, cr)
 	if err != nil {
 		return managed.ExternalCreation{}, err
@@ -174,7 +411,10 @@ func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.Ext
 			return managed.ExternalCreation{}, err
 		}
 	}
-	query := fmt.Sprintf("CREATE USER %s WITH PASSWORD=%s", mssql.QuoteIdentifier(meta.GetExternalName(cr)), mssql.QuoteValue(pw))
+	query := fmt.Sprintf("CREATE USER %s WITH PASSWORD=%s", name, mssql.QuoteValue(pw))
+	if defaults != "" {
+		query += ", " + defaults
+	}
 	if err := c.db.Exec(ctx, xsql.Query{
 		String: query,
 	}); err != nil {
@@ -192,6 +432,41 @@ func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.Ext
 		return managed.ExternalUpdate{}, errors.New(errNotUser)
 	}
 
+	if err := c.updateRoles(ctx, cr); err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+
+	observed, err := c.observe(ctx, cr)
+	if err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errSelectUser)
+	}
+
+	if defaults := defaultsClause(changedDefaults(observed, cr.Spec.ForProvider)); defaults != "" {
+		query := fmt.Sprintf("ALTER USER %s WITH %s", mssql.QuoteIdentifier(meta.GetExternalName(cr)), defaults)
+		if err := c.db.Exec(ctx, xsql.Query{
+			String: query,
+		}); err != nil {
+			return managed.ExternalUpdate{}, errors.Wrap(err, errUpdateUser)
+		}
+	}
+
+	if l := cr.Spec.ForProvider.Login; l != nil {
+		if !loginChanged(observed, cr.Spec.ForProvider) {
+			return managed.ExternalUpdate{}, nil
+		}
+		query := fmt.Sprintf("ALTER USER %s WITH LOGIN=%s", mssql.QuoteIdentifier(meta.GetExternalName(cr)), mssql.QuoteIdentifier(*l))
+		if err := c.db.Exec(ctx, xsql.Query{
+			String: query,
+		}); err != nil {
+			return managed.ExternalUpdate{}, errors.Wrap(err, errUpdateUser)
+		}
+		return managed.ExternalUpdate{}, nil
+	}
+
+	if authentication(cr.Spec.ForProvider) != v1alpha1.UserAuthenticationPassword {
+		return managed.ExternalUpdate{}, nil
+	}
+
 	pw, changed, err := c.getPassword(ctx, cr)
 	if err != nil {
 		return managed.ExternalUpdate{}, err
@@ -19,14 +19,17 @@ package user
 import (
 	"context"
 	"database/sql"
+	"strings"
 	"testing"
 
+	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/crossplane-contrib/provider-sql/apis/mssql/v1alpha1"
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
@@ -42,6 +45,7 @@ type mockDB struct {
 	MockExec   func(ctx context.Context, q xsql.Query) error
 	MockExecTx func(ctx context.Context, ql []xsql.Query) error
 	MockScan   func(ctx context.Context, q xsql.Query, dest ...interface{}) error
+	MockQuery  func(ctx context.Context, q xsql.Query) (*sql.Rows, error)
 }
 
 func (m mockDB) Exec(ctx context.Context, q xsql.Query) error {
@@ -54,7 +58,7 @@ func (m mockDB) Scan(ctx context.Context, q xsql.Query, dest ...interface{}) err
 	return m.MockScan(ctx, q, dest...)
 }
 func (m mockDB) Query(ctx context.Context, q xsql.Query) (*sql.Rows, error) {
-	return &sql.Rows{}, nil
+	return m.MockQuery(ctx, q)
 }
 func (m mockDB) GetConnectionDetails(username, password string) managed.ConnectionDetails {
 	return managed.ConnectionDetails{
@@ -65,6 +69,17 @@ func (m mockDB) GetConnectionDetails(username, password string) managed.Connecti
 	}
 }
 
+func mockRowsToSQLRows(mockRows *sqlmock.Rows) *sql.Rows {
+	db, mock, _ := sqlmock.New()
+	mock.ExpectQuery("select").WillReturnRows(mockRows)
+	rows, err := db.Query("select")
+	if err != nil {
+		println("%v", err)
+		return nil
+	}
+	return rows
+}
+
 func TestConnect(t *testing.T) {
 	errBoom := errors.New("boom")
 
@@ -248,7 +263,7 @@ func TestObserve(t *testing.T) {
 			reason: "We should return no error if we can successfully select our user",
 			fields: fields{
 				db: mockDB{
-					MockScan: func(ctx context.Context, q xsql.Query, dest ...interface{}) error { return nil },
+					MockScan: scanPrincipal("DATABASE", func(ctx context.Context, q xsql.Query, dest ...interface{}) error { return nil }),
 				},
 			},
 			args: args{
@@ -263,11 +278,37 @@ func TestObserve(t *testing.T) {
 				err: nil,
 			},
 		},
+		"SuccessQuotedName": {
+			reason: "We should select a user whose name needs quoting by its unquoted name",
+			fields: fields{
+				db: mockDB{
+					MockScan: scanPrincipal("DATABASE", func(ctx context.Context, q xsql.Query, dest ...interface{}) error {
+						if q.Parameters[0] != "o'brien]" {
+							return errBoom
+						}
+						return nil
+					}),
+				},
+			},
+			args: args{
+				mg: &v1alpha1.User{
+					ObjectMeta: v1.ObjectMeta{
+						Annotations: map[string]string{meta.AnnotationKeyExternalName: "o'brien]"},
+					},
+				},
+			},
+			want: want{
+				o: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: true,
+				},
+			},
+		},
 		"PasswordChanged": {
 			reason: "We should return ResourceUpToDate=false if the password changed",
 			fields: fields{
 				db: mockDB{
-					MockScan: func(ctx context.Context, q xsql.Query, dest ...interface{}) error { return nil },
+					MockScan: scanPrincipal("DATABASE", func(ctx context.Context, q xsql.Query, dest ...interface{}) error { return nil }),
 				},
 				kube: &test.MockClient{
 					MockGet: func(_ context.Context, key client.ObjectKey, obj client.Object) error {
@@ -307,6 +348,151 @@ func TestObserve(t *testing.T) {
 				err: nil,
 			},
 		},
+		"LoginChanged": {
+			reason: "We should return ResourceUpToDate=false if the user is not mapped to its login",
+			fields: fields{
+				db: mockDB{
+					MockScan: scanPrincipal("INSTANCE", func(ctx context.Context, q xsql.Query, dest ...interface{}) error {
+						if strings.Contains(q.String, "SUSER_SNAME") {
+							*dest[0].(**string) = pointer.String("other")
+						}
+						return nil
+					}),
+				},
+			},
+			args: args{
+				mg: &v1alpha1.User{
+					Spec: v1alpha1.UserSpec{
+						ForProvider: v1alpha1.UserParameters{
+							Login: pointer.String("example"),
+						},
+					},
+				},
+			},
+			want: want{
+				o: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: false,
+				},
+			},
+		},
+		"ErrSelectRoles": {
+			reason: "We should return any errors encountered while trying to select the roles of the user",
+			fields: fields{
+				db: mockDB{
+					MockScan: scanPrincipal("DATABASE", func(ctx context.Context, q xsql.Query, dest ...interface{}) error { return nil }),
+					MockQuery: func(ctx context.Context, q xsql.Query) (*sql.Rows, error) {
+						return nil, errBoom
+					},
+				},
+			},
+			args: args{
+				mg: &v1alpha1.User{
+					Spec: v1alpha1.UserSpec{
+						ForProvider: v1alpha1.UserParameters{
+							Roles: []string{"db_datareader"},
+						},
+					},
+				},
+			},
+			want: want{
+				err: errors.Wrap(errBoom, errSelectRoles),
+			},
+		},
+		"RolesChanged": {
+			reason: "We should return ResourceUpToDate=false if the user is not a member of exactly its roles",
+			fields: fields{
+				db: mockDB{
+					MockScan: scanPrincipal("DATABASE", func(ctx context.Context, q xsql.Query, dest ...interface{}) error { return nil }),
+					MockQuery: func(ctx context.Context, q xsql.Query) (*sql.Rows, error) {
+						return mockRowsToSQLRows(sqlmock.NewRows([]string{"name"}).AddRow("db_datareader").AddRow("db_owner")), nil
+					},
+				},
+			},
+			args: args{
+				mg: &v1alpha1.User{
+					Spec: v1alpha1.UserSpec{
+						ForProvider: v1alpha1.UserParameters{
+							Roles: []string{"db_datawriter", "db_datareader"},
+						},
+					},
+				},
+			},
+			want: want{
+				o: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: false,
+				},
+			},
+		},
+		"ErrAuthenticationChanged": {
+			reason: "We should return an error if the user exists with a different authentication type",
+			fields: fields{
+				db: mockDB{
+					MockScan: scanPrincipal("INSTANCE", func(ctx context.Context, q xsql.Query, dest ...interface{}) error { return nil }),
+				},
+			},
+			args: args{
+				mg: &v1alpha1.User{},
+			},
+			want: want{
+				err: errors.New(errAuthenticationChanged),
+			},
+		},
+		"DefaultSchemaChanged": {
+			reason: "We should return ResourceUpToDate=false if the user has a different default schema",
+			fields: fields{
+				db: mockDB{
+					MockScan: scanPrincipal("NONE", func(ctx context.Context, q xsql.Query, dest ...interface{}) error {
+						*dest[1].(**string) = pointer.String("dbo")
+						return nil
+					}),
+				},
+			},
+			args: args{
+				mg: &v1alpha1.User{
+					Spec: v1alpha1.UserSpec{
+						ForProvider: v1alpha1.UserParameters{
+							Authentication: authenticationPtr(v1alpha1.UserAuthenticationNone),
+							DefaultSchema:  pointer.String("sales"),
+						},
+					},
+				},
+			},
+			want: want{
+				o: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: false,
+				},
+			},
+		},
+		"DefaultSchemaLateInit": {
+			reason: "We should late initialize the default schema of the user",
+			fields: fields{
+				db: mockDB{
+					MockScan: scanPrincipal("EXTERNAL", func(ctx context.Context, q xsql.Query, dest ...interface{}) error {
+						*dest[1].(**string) = pointer.String("dbo")
+						return nil
+					}),
+				},
+			},
+			args: args{
+				mg: &v1alpha1.User{
+					Spec: v1alpha1.UserSpec{
+						ForProvider: v1alpha1.UserParameters{
+							Authentication: authenticationPtr(v1alpha1.UserAuthenticationExternalProvider),
+						},
+					},
+				},
+			},
+			want: want{
+				o: managed.ExternalObservation{
+					ResourceExists:          true,
+					ResourceUpToDate:        true,
+					ResourceLateInitialized: true,
+				},
+			},
+		},
 	}
 
 	for name, tc := range cases {
@@ -456,6 +642,154 @@ func TestCreate(t *testing.T) {
 				},
 			},
 		},
+		"UserForLogin": {
+			reason: "A user for a login should be created without a password or connection details",
+			fields: fields{
+				db: &mockDB{
+					MockExec: func(ctx context.Context, q xsql.Query) error {
+						if q.String != "CREATE USER [example] FOR LOGIN [example-login]" {
+							return errBoom
+						}
+						return nil
+					},
+				},
+			},
+			args: args{
+				mg: &v1alpha1.User{
+					ObjectMeta: v1.ObjectMeta{
+						Annotations: map[string]string{
+							meta.AnnotationKeyExternalName: "example",
+						},
+					},
+					Spec: v1alpha1.UserSpec{
+						ForProvider: v1alpha1.UserParameters{
+							Login: pointer.String("example-login"),
+						},
+					},
+				},
+			},
+			want: want{
+				err: nil,
+				c:   managed.ExternalCreation{},
+			},
+		},
+		"UserWithoutLogin": {
+			reason: "A user without login should be created with its default schema and without connection details",
+			fields: fields{
+				db: &mockDB{
+					MockExec: func(ctx context.Context, q xsql.Query) error {
+						if q.String != "CREATE USER [example] WITHOUT LOGIN WITH DEFAULT_SCHEMA=[sales]" {
+							return errBoom
+						}
+						return nil
+					},
+				},
+			},
+			args: args{
+				mg: &v1alpha1.User{
+					ObjectMeta: v1.ObjectMeta{
+						Annotations: map[string]string{
+							meta.AnnotationKeyExternalName: "example",
+						},
+					},
+					Spec: v1alpha1.UserSpec{
+						ForProvider: v1alpha1.UserParameters{
+							Authentication: authenticationPtr(v1alpha1.UserAuthenticationNone),
+							DefaultSchema:  pointer.String("sales"),
+						},
+					},
+				},
+			},
+			want: want{
+				err: nil,
+				c:   managed.ExternalCreation{},
+			},
+		},
+		"UserWithQuotedName": {
+			reason: "The name, password and default schema of the user should be quoted",
+			fields: fields{
+				db: &mockDB{
+					MockExec: func(ctx context.Context, q xsql.Query) error {
+						if q.String != "CREATE USER [o'brien]]] WITH PASSWORD=N'it''s]', DEFAULT_SCHEMA=[sa]]les]" {
+							return errBoom
+						}
+						return nil
+					},
+				},
+				kube: &test.MockClient{
+					MockGet: func(_ context.Context, key client.ObjectKey, obj client.Object) error {
+						secret := corev1.Secret{
+							Data: map[string][]byte{},
+						}
+						secret.Data["password"] = []byte("it's]")
+						secret.DeepCopyInto(obj.(*corev1.Secret))
+						return nil
+					},
+				},
+			},
+			args: args{
+				mg: &v1alpha1.User{
+					ObjectMeta: v1.ObjectMeta{
+						Annotations: map[string]string{
+							meta.AnnotationKeyExternalName: "o'brien]",
+						},
+					},
+					Spec: v1alpha1.UserSpec{
+						ForProvider: v1alpha1.UserParameters{
+							PasswordSecretRef: &xpv1.SecretKeySelector{
+								SecretReference: xpv1.SecretReference{
+									Name: "example",
+								},
+								Key: "password",
+							},
+							DefaultSchema: pointer.String("sa]les"),
+						},
+					},
+				},
+			},
+			want: want{
+				err: nil,
+				c: managed.ExternalCreation{
+					ConnectionDetails: managed.ConnectionDetails{
+						xpv1.ResourceCredentialsSecretUserKey:     []byte("o'brien]"),
+						xpv1.ResourceCredentialsSecretPasswordKey: []byte("it's]"),
+						xpv1.ResourceCredentialsSecretEndpointKey: []byte("localhost"),
+						xpv1.ResourceCredentialsSecretPortKey:     []byte("3306"),
+					},
+				},
+			},
+		},
+		"UserFromExternalProvider": {
+			reason: "A user from an external provider should be created without connection details",
+			fields: fields{
+				db: &mockDB{
+					MockExec: func(ctx context.Context, q xsql.Query) error {
+						if q.String != "CREATE USER [data-engineers] FROM EXTERNAL PROVIDER" {
+							return errBoom
+						}
+						return nil
+					},
+				},
+			},
+			args: args{
+				mg: &v1alpha1.User{
+					ObjectMeta: v1.ObjectMeta{
+						Annotations: map[string]string{
+							meta.AnnotationKeyExternalName: "data-engineers",
+						},
+					},
+					Spec: v1alpha1.UserSpec{
+						ForProvider: v1alpha1.UserParameters{
+							Authentication: authenticationPtr(v1alpha1.UserAuthenticationExternalProvider),
+						},
+					},
+				},
+			},
+			want: want{
+				err: nil,
+				c:   managed.ExternalCreation{},
+			},
+		},
 	}
 
 	for name, tc := range cases {
@@ -517,6 +851,7 @@ func TestUpdate(t *testing.T) {
 			fields: fields{
 				db: &mockDB{
 					MockExec: func(ctx context.Context, q xsql.Query) error { return errBoom },
+					MockScan: scanUser(principal{authenticationType: "DATABASE"}),
 				},
 			},
 			args: args{
@@ -552,11 +887,26 @@ func TestUpdate(t *testing.T) {
 				err: errors.Wrap(errBoom, errUpdateUser),
 			},
 		},
+		"ErrSelectUser": {
+			reason: "Any errors encountered while observing the user should be returned",
+			fields: fields{
+				db: &mockDB{
+					MockScan: func(ctx context.Context, q xsql.Query, dest ...interface{}) error { return errBoom },
+				},
+			},
+			args: args{
+				mg: &v1alpha1.User{},
+			},
+			want: want{
+				err: errors.Wrap(errBoom, errSelectUser),
+			},
+		},
 		"Success": {
 			reason: "No error should be returned when we don't have to update a user",
 			fields: fields{
 				db: &mockDB{
 					MockExec: func(ctx context.Context, q xsql.Query) error { return nil },
+					MockScan: scanUser(principal{authenticationType: "DATABASE"}),
 				},
 			},
 			args: args{
@@ -576,7 +926,9 @@ func TestUpdate(t *testing.T) {
 		"SamePassword": {
 			reason: "No DB query should be executed if the password didn't change",
 			fields: fields{
-				db: &mockDB{},
+				db: &mockDB{
+					MockScan: scanUser(principal{authenticationType: "DATABASE"}),
+				},
 			},
 			args: args{
 				mg: &v1alpha1.User{
@@ -614,6 +966,7 @@ func TestUpdate(t *testing.T) {
 			fields: fields{
 				db: &mockDB{
 					MockExec: func(ctx context.Context, q xsql.Query) error { return nil },
+					MockScan: scanUser(principal{authenticationType: "DATABASE"}),
 				},
 			},
 			args: args{
@@ -674,6 +1027,147 @@ func TestUpdate(t *testing.T) {
 				},
 			},
 		},
+		"UserForLogin": {
+			reason: "A user for a login should be mapped to its login",
+			fields: fields{
+				db: &mockDB{
+					MockExec: func(ctx context.Context, q xsql.Query) error {
+						if q.String != "ALTER USER [example] WITH LOGIN=[example-login]" {
+							return errBoom
+						}
+						return nil
+					},
+					MockScan: scanUser(principal{login: pointer.String("other-login"), authenticationType: "INSTANCE"}),
+				},
+			},
+			args: args{
+				mg: &v1alpha1.User{
+					ObjectMeta: v1.ObjectMeta{
+						Annotations: map[string]string{
+							meta.AnnotationKeyExternalName: "example",
+						},
+					},
+					Spec: v1alpha1.UserSpec{
+						ForProvider: v1alpha1.UserParameters{
+							Login: pointer.String("example-login"),
+						},
+					},
+				},
+			},
+			want: want{
+				err: nil,
+				c:   managed.ExternalUpdate{},
+			},
+		},
+		"UserForSameLogin": {
+			reason: "A user already mapped to its login should not be altered",
+			fields: fields{
+				db: &mockDB{
+					MockExec: func(ctx context.Context, q xsql.Query) error { return errBoom },
+					MockScan: scanUser(principal{
+						login:              pointer.String("example-login"),
+						defaultSchema:      pointer.String("dbo"),
+						authenticationType: "INSTANCE",
+					}),
+				},
+			},
+			args: args{
+				mg: &v1alpha1.User{
+					ObjectMeta: v1.ObjectMeta{
+						Annotations: map[string]string{
+							meta.AnnotationKeyExternalName: "example",
+						},
+					},
+					Spec: v1alpha1.UserSpec{
+						ForProvider: v1alpha1.UserParameters{
+							Login:         pointer.String("example-login"),
+							DefaultSchema: pointer.String("dbo"),
+						},
+					},
+				},
+			},
+			want: want{
+				err: nil,
+				c:   managed.ExternalUpdate{},
+			},
+		},
+		"UpdateDefaults": {
+			reason: "Only the defaults that differ from the observed ones should be altered",
+			fields: fields{
+				db: &mockDB{
+					MockExec: func(ctx context.Context, q xsql.Query) error {
+						if q.String != "ALTER USER [example] WITH DEFAULT_SCHEMA=[sales]" {
+							return errBoom
+						}
+						return nil
+					},
+					MockScan: scanUser(principal{
+						defaultSchema:      pointer.String("dbo"),
+						defaultLanguage:    pointer.String("English"),
+						authenticationType: "NONE",
+					}),
+				},
+			},
+			args: args{
+				mg: &v1alpha1.User{
+					ObjectMeta: v1.ObjectMeta{
+						Annotations: map[string]string{
+							meta.AnnotationKeyExternalName: "example",
+						},
+					},
+					Spec: v1alpha1.UserSpec{
+						ForProvider: v1alpha1.UserParameters{
+							Authentication:  authenticationPtr(v1alpha1.UserAuthenticationNone),
+							DefaultSchema:   pointer.String("sales"),
+							DefaultLanguage: pointer.String("english"),
+						},
+					},
+				},
+			},
+			want: want{
+				err: nil,
+				c:   managed.ExternalUpdate{},
+			},
+		},
+		"UpdateRoles": {
+			reason: "The user should be added to desired roles and removed from other roles",
+			fields: fields{
+				db: &mockDB{
+					MockExec: func(ctx context.Context, q xsql.Query) error {
+						switch q.String {
+						case "ALTER ROLE [db_datawriter] ADD MEMBER [example]",
+							"ALTER ROLE [db_owner] DROP MEMBER [example]":
+							return nil
+						}
+						return errBoom
+					},
+					MockScan: scanUser(principal{authenticationType: "DATABASE"}),
+				},
+			},
+			args: args{
+				mg: &v1alpha1.User{
+					ObjectMeta: v1.ObjectMeta{
+						Annotations: map[string]string{
+							meta.AnnotationKeyExternalName: "example",
+						},
+					},
+					Spec: v1alpha1.UserSpec{
+						ForProvider: v1alpha1.UserParameters{
+							Roles: []string{"db_datareader", "db_datawriter"},
+						},
+					},
+					Status: v1alpha1.UserStatus{
+						AtProvider: v1alpha1.UserObservation{
+							Roles: []string{"db_datareader", "db_owner"},
+						},
+					},
+				},
+			},
+			want: want{
+				err: nil,
+				c:   managed.ExternalUpdate{},
+			},
+		},
 	}
 
 	for name, tc := range cases {
@@ -745,6 +1239,26 @@ func TestDelete(t *testing.T) {
 				mg: &v1alpha1.User{},
 			},
 		},
+		"SuccessQuotedName": {
+			reason: "A user whose name needs quoting should be dropped by a quoted identifier",
+			fields: fields{
+				db: &mockDB{
+					MockExec: func(ctx context.Context, q xsql.Query) error {
+						if q.String != "DROP USER IF EXISTS [o'brien]]]" {
+							return errBoom
+						}
+						return nil
+					},
+				},
+			},
+			args: args{
+				mg: &v1alpha1.User{
+					ObjectMeta: v1.ObjectMeta{
+						Annotations: map[string]string{meta.AnnotationKeyExternalName: "o'brien]"},
+					},
+				},
+			},
+		},
 	}
 
 	for name, tc := range cases {
@@ -757,3 +1271,29 @@ func TestDelete(t *testing.T) {
 		})
 	}
 }
+
+// scanPrincipal wraps a MockScan, observing a user with the supplied
+// authentication type.
+func scanPrincipal(authenticationType string, scan func(ctx context.Context, q xsql.Query, dest ...interface{}) error) func(ctx context.Context, q xsql.Query, dest ...interface{}) error {
+	return func(ctx context.Context, q xsql.Query, dest ...interface{}) error {
+		if strings.Contains(q.String, "authentication_type_desc") {
+			*dest[3].(*string) = authenticationType
+		}
+		return scan(ctx, q, dest...)
+	}
+}
+
+func authenticationPtr(a v1alpha1.UserAuthentication) *v1alpha1.UserAuthentication {
+	return &a
+}
+
+// scanUser returns a MockScan that observes the supplied user.
+func scanUser(p principal) func(ctx context.Context, q xsql.Query, dest ...interface{}) error {
+	return func(ctx context.Context, q xsql.Query, dest ...interface{}) error {
+		*dest[0].(**string) = p.login
+		*dest[1].(**string) = p.defaultSchema
+		*dest[2].(**string) = p.defaultLanguage
+		*dest[3].(*string) = p.authenticationType
+		return nil
+	}
+}
@@ -18,11 +18,13 @@ package database
 
 import (
 	"context"
+	"strings"
 	"time"
 
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/pointer"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
@@ -48,7 +50,13 @@ const (
 	errNotDatabase = "managed resource is not a Database custom resource"
 	errSelectDB    = "cannot select database"
 	errCreateDB    = "cannot create database"
+	errUpdateDB    = "cannot update database"
 	errDropDB      = "cannot drop database"
+	errSelectColl  = "cannot select collation"
+	errFmtColl     = "collation %s is not valid for character set %s"
+
+	// schemata_extensions reports this option for read only databases.
+	optionReadOnly = "READ ONLY=1"
 
 	maxConcurrency = 5
 )
@@ -115,15 +123,50 @@ func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.E
 
 type external struct{ db xsql.DB }
 
+func (c *external) observe(ctx context.Context, name string) (*v1alpha1.DatabaseParameters, error) {
+	observed := &v1alpha1.DatabaseParameters{}
+
+	query := "SELECT default_character_set_name, default_collation_name " +
+		"FROM information_schema.schemata WHERE schema_name = ?"
+	if err := c.db.Scan(ctx, xsql.Query{String: query, Parameters: []interface{}{name}},
+		&observed.CharacterSet,
+		&observed.Collation,
+	); err != nil {
+		return nil, err
+	}
+
+	// Default encryption and read only databases were introduced in MySQL
+	// 8.0.16 and 8.0.22 respectively. We leave them unset on older servers.
+	var encryption *string
+	query = "SELECT default_encryption FROM information_schema.schemata WHERE schema_name = ?"
+	err := c.db.Scan(ctx, xsql.Query{String: query, Parameters: []interface{}{name}}, &encryption)
+	if err != nil && !mysql.IsUnsupported(err) {
+		return nil, err
+	}
+	if encryption != nil {
+		observed.Encryption = pointer.Bool(*encryption == "YES")
+	}
+
+	var options *string
+	query = "SELECT options FROM information_schema.schemata_extensions WHERE schema_name = ?"
+	err = c.db.Scan(ctx, xsql.Query{String: query, Parameters: []interface{}{name}}, &options)
+	if err != nil && !mysql.IsUnsupported(err) && !xsql.IsNoRows(err) {
+		return nil, err
+	}
+	if options != nil {
+		observed.ReadOnly = pointer.Bool(strings.Contains(*options, optionReadOnly))
+	}
+
+	return observed, nil
+}
+
 func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
 	cr, ok := mg.(*v1alpha1.Database)
 	if !ok {
 		return managed.ExternalObservation{}, errors.New(errNotDatabase)
 	}
 
-	var name string
-	query := "SELECT schema_name FROM information_schema.schemata WHERE schema_name = ?"
-	err := c.db.Scan(ctx, xsql.Query{String: query, Parameters: []interface{}{meta.GetExternalName(cr)}}, &name)
+	observed, err := c.observe(ctx, meta.GetExternalName(cr))
 	if xsql.IsNoRows(err) {
 		return managed.ExternalObservation{ResourceExists: false}, nil
 	}
@@ -131,17 +174,56 @@ func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 		return managed.ExternalObservation{}, errors.Wrap(err, errSelectDB)
 	}
 
+	li := lateInit(observed, &cr.Spec.ForProvider)
+	derived, err := c.deriveCollation(ctx, observed, &cr.Spec.ForProvider)
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
+
 	cr.SetConditions(xpv1.Available())
 
 	return managed.ExternalObservation{
-		ResourceExists: true,
-
-		// TODO(negz): Support these when we have anything to update.
-		ResourceLateInitialized: false,
-		ResourceUpToDate:        true,
+		ResourceExists:          true,
+		ResourceLateInitialized: li || derived,
+		ResourceUpToDate:        len(changedOptions(observed, cr.Spec.ForProvider)) == 0,
 	}, nil
 }
 
+// deriveCollation makes sure the desired collation belongs to the desired
+// character set. A collation that was late initialized, or otherwise left as
+// observed while the character set changed, is replaced by the default
+// collation of the new character set. It returns true if the desired
+// collation was replaced.
+func (c *external) deriveCollation(ctx context.Context, observed *v1alpha1.DatabaseParameters, desired *v1alpha1.DatabaseParameters) (bool, error) {
+	if desired.CharacterSet == nil || desired.Collation == nil {
+		return false, nil
+	}
+	if equalFold(observed.CharacterSet, desired.CharacterSet) && equalFold(observed.Collation, desired.Collation) {
+		return false, nil
+	}
+
+	var charset string
+	query := "SELECT character_set_name FROM information_schema.collations WHERE collation_name = ?"
+	err := c.db.Scan(ctx, xsql.Query{String: query, Parameters: []interface{}{*desired.Collation}}, &charset)
+	if err != nil && !xsql.IsNoRows(err) {
+		return false, errors.Wrap(err, errSelectColl)
+	}
+	if err == nil && strings.EqualFold(charset, *desired.CharacterSet) {
+		return false, nil
+	}
+	if !equalFold(observed.Collation, desired.Collation) {
+		return false, errors.Errorf(errFmtColl, *desired.Collation, *desired.CharacterSet)
+	}
+
+	var collation string
+	query = "SELECT default_collate_name FROM information_schema.character_sets WHERE character_set_name = ?"
+	if err := c.db.Scan(ctx, xsql.Query{String: query, Parameters: []interface{}{*desired.CharacterSet}}, &collation); err != nil {
+		return false, errors.Wrap(err, errSelectColl)
+	}
+	desired.Collation = pointer.String(collation)
+	return true, nil
+}
+
 func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
 
 	cr, ok := mg.(*v1alpha1.Database)
@@ -149,13 +231,47 @@ func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.Ext
 		return managed.ExternalCreation{}, errors.New(errNotDatabase)
 	}
 
-	err := c.db.Exec(ctx, xsql.Query{String: "CREATE DATABASE " + mysql.QuoteIdentifier(meta.GetExternalName(cr))})
+	db := mysql.QuoteIdentifier(meta.GetExternalName(cr))
+	p := cr.Spec.ForProvider
+
+	// READ ONLY may only be specified by ALTER DATABASE.
+	opts := optionsToClauses(v1alpha1.DatabaseParameters{
+		CharacterSet: p.CharacterSet,
+		Collation:    p.Collation,
+		Encryption:   p.Encryption,
+	})
+	query := strings.TrimSpace("CREATE DATABASE " + db + " " + strings.Join(opts, " "))
+	if err := c.db.Exec(ctx, xsql.Query{String: query}); err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errCreateDB)
+	}
+
+	if p.ReadOnly == nil || !*p.ReadOnly {
+		return managed.ExternalCreation{}, nil
+	}
+	err := c.db.Exec(ctx, xsql.Query{String: "ALTER DATABASE " + db + " READ ONLY = 1"})
 	return managed.ExternalCreation{}, errors.Wrap(err, errCreateDB)
 }
 
 func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
-	// TODO(negz): Support updates once we have anything to update.
-	return managed.ExternalUpdate{}, nil
+	cr, ok := mg.(*v1alpha1.Database)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotDatabase)
+	}
+
+	observed, err := c.observe(ctx, meta.GetExternalName(cr))
+	if err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errSelectDB)
+	}
+
+	opts := changedOptions(observed, cr.Spec.ForProvider)
+	if len(opts) == 0 {
+		return managed.ExternalUpdate{}, nil
+	}
+
+	// A read only database can only be altered by the statement that makes it
+	// writable, so all options are changed in a single statement.
+	query := "ALTER DATABASE " + mysql.QuoteIdentifier(meta.GetExternalName(cr)) + " " + strings.Join(opts, " ")
+	return managed.ExternalUpdate{}, errors.Wrap(c.db.Exec(ctx, xsql.Query{String: query}), errUpdateDB)
 }
 
 func (c *external) Delete(ctx context.Context, mg resource.Managed) error {
@@ -167,3 +283,75 @@ func (c *external) Delete(ctx context.Context, mg resource.Managed) error {
 	err := c.db.Exec(ctx, xsql.Query{String: "DROP DATABASE IF EXISTS " + mysql.QuoteIdentifier(meta.GetExternalName(cr))})
 	return errors.Wrap(err, errDropDB)
 }
+
+// optionsToClauses returns the clauses of CREATE DATABASE or ALTER DATABASE
+// that set the supplied options.
+func optionsToClauses(p v1alpha1.DatabaseParameters) []string {
+	clauses := []string{}
+	if p.CharacterSet != nil {
+		clauses = append(clauses, "CHARACTER SET "+mysql.QuoteIdentifier(*p.CharacterSet))
+	}
+	if p.Collation != nil {
+		clauses = append(clauses, "COLLATE "+mysql.QuoteIdentifier(*p.Collation))
+	}
+	if p.Encryption != nil {
+		clauses = append(clauses, "ENCRYPTION "+yesNo(*p.Encryption, "'Y'", "'N'"))
+	}
+	if p.ReadOnly != nil {
+		clauses = append(clauses, "READ ONLY = "+yesNo(*p.ReadOnly, "1", "0"))
+	}
+	return clauses
+}
+
+// changedOptions returns the clauses of ALTER DATABASE that bring the observed
+// options in line with the desired ones. The character set and collation are
+// always changed together, so that neither is left as implied by the other.
+func changedOptions(observed *v1alpha1.DatabaseParameters, desired v1alpha1.DatabaseParameters) []string {
+	changed := v1alpha1.DatabaseParameters{}
+	if (desired.CharacterSet != nil && !equalFold(observed.CharacterSet, desired.CharacterSet)) ||
+		(desired.Collation != nil && !equalFold(observed.Collation, desired.Collation)) {
+		changed.CharacterSet = desired.CharacterSet
+		changed.Collation = desired.Collation
+	}
+	if desired.Encryption != nil && (observed.Encryption == nil || *observed.Encryption != *desired.Encryption) {
+		changed.Encryption = desired.Encryption
+	}
+	if desired.ReadOnly != nil && (observed.ReadOnly == nil || *observed.ReadOnly != *desired.ReadOnly) {
+		changed.ReadOnly = desired.ReadOnly
+	}
+	return optionsToClauses(changed)
+}
+
+func lateInit(observed *v1alpha1.DatabaseParameters, desired *v1alpha1.DatabaseParameters) bool {
+	li := false
+
+	if desired.CharacterSet == nil && observed.CharacterSet != nil {
+		desired.CharacterSet = observed.CharacterSet
+		li = true
+	}
+	if desired.Collation == nil && observed.Collation != nil {
+		desired.Collation = observed.Collation
+		li = true
+	}
+	if desired.Encryption == nil && observed.Encryption != nil {
+		desired.Encryption = observed.Encryption
+		li = true
+	}
+	if desired.ReadOnly == nil && observed.ReadOnly != nil {
+		desired.ReadOnly = observed.ReadOnly
+		li = true
+	}
+
+	return li
+}
+
+func equalFold(observed, desired *string) bool {
+	return observed != nil && desired != nil && strings.EqualFold(*observed, *desired)
+}
+
+func yesNo(b bool, yes, no string) string {
+	if b {
+		return yes
+	}
+	return no
+}
@@ -19,15 +19,20 @@ package database
 import (
 	"context"
 	"database/sql"
+	"strings"
 	"testing"
 
 	"github.com/crossplane-contrib/provider-sql/apis/mysql/v1alpha1"
+	"github.com/go-sql-driver/mysql"
 	"github.com/google/go-cmp/cmp"
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
 	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
 	"github.com/crossplane/crossplane-runtime/pkg/resource"
 	"github.com/crossplane/crossplane-runtime/pkg/test"
@@ -58,6 +63,31 @@ func (m mockDB) GetConnectionDetails(username, password string) managed.Connecti
 	return m.MockGetConnectionDetails(username, password)
 }
 
+// scanSchema returns a MockScan that reports a utf8mb4 database with the
+// supplied default encryption and schemata_extensions options. A nil options
+// value reports a server that predates schemata_extensions.
+func scanSchema(encryption string, options *string) func(ctx context.Context, q xsql.Query, dest ...interface{}) error {
+	return func(ctx context.Context, q xsql.Query, dest ...interface{}) error {
+		switch {
+		case strings.Contains(q.String, "information_schema.collations"):
+			*dest[0].(*string) = strings.SplitN(q.Parameters[0].(string), "_", 2)[0]
+		case strings.Contains(q.String, "information_schema.character_sets"):
+			*dest[0].(*string) = q.Parameters[0].(string) + "_general_ci"
+		case strings.Contains(q.String, "default_encryption"):
+			*dest[0].(**string) = &encryption
+		case strings.Contains(q.String, "schemata_extensions"):
+			if options == nil {
+				return &mysql.MySQLError{Number: 1109}
+			}
+			*dest[0].(**string) = options
+		default:
+			*dest[0].(**string) = pointer.String("utf8mb4")
+			*dest[1].(**string) = pointer.String("utf8mb4_0900_ai_ci")
+		}
+		return nil
+	}
+}
+
 func TestConnect(t *testing.T) {
 	errBoom := errors.New("boom")
 
@@ -255,6 +285,144 @@ func TestObserve(t *testing.T) {
 				err: nil,
 			},
 		},
+		"SuccessLateInit": {
+			reason: "Unset parameters should be late initialized from the observed database",
+			fields: fields{
+				db: mockDB{
+					MockScan: scanSchema("NO", pointer.String("")),
+				},
+			},
+			args: args{
+				mg: &v1alpha1.Database{},
+			},
+			want: want{
+				o: managed.ExternalObservation{
+					ResourceExists:          true,
+					ResourceUpToDate:        true,
+					ResourceLateInitialized: true,
+				},
+				err: nil,
+			},
+		},
+		"NotUpToDate": {
+			reason: "We should return ResourceUpToDate: false when the collation differs",
+			fields: fields{
+				db: mockDB{
+					MockScan: scanSchema("NO", nil),
+				},
+			},
+			args: args{
+				mg: &v1alpha1.Database{
+					Spec: v1alpha1.DatabaseSpec{
+						ForProvider: v1alpha1.DatabaseParameters{
+							CharacterSet: pointer.String("utf8mb4"),
+							Collation:    pointer.String("utf8mb4_bin"),
+							Encryption:   pointer.Bool(false),
+						},
+					},
+				},
+			},
+			want: want{
+				o: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: false,
+				},
+				err: nil,
+			},
+		},
+		"NotUpToDateDerivedCollation": {
+			reason: "A collation left as observed should be replaced by the default collation of a changed character set",
+			fields: fields{
+				db: mockDB{
+					MockScan: scanSchema("NO", nil),
+				},
+			},
+			args: args{
+				mg: &v1alpha1.Database{
+					Spec: v1alpha1.DatabaseSpec{
+						ForProvider: v1alpha1.DatabaseParameters{
+							CharacterSet: pointer.String("latin1"),
+							Collation:    pointer.String("utf8mb4_0900_ai_ci"),
+							Encryption:   pointer.Bool(false),
+						},
+					},
+				},
+			},
+			want: want{
+				o: managed.ExternalObservation{
+					ResourceExists:          true,
+					ResourceUpToDate:        false,
+					ResourceLateInitialized: true,
+				},
+				err: nil,
+			},
+		},
+		"ErrIncompatibleCollation": {
+			reason: "We should return an error if an explicitly changed collation does not belong to the character set",
+			fields: fields{
+				db: mockDB{
+					MockScan: scanSchema("NO", nil),
+				},
+			},
+			args: args{
+				mg: &v1alpha1.Database{
+					Spec: v1alpha1.DatabaseSpec{
+						ForProvider: v1alpha1.DatabaseParameters{
+							CharacterSet: pointer.String("latin1"),
+							Collation:    pointer.String("utf8mb4_bin"),
+						},
+					},
+				},
+			},
+			want: want{
+				err: errors.Errorf(errFmtColl, "utf8mb4_bin", "latin1"),
+			},
+		},
+		"ErrSelectCollation": {
+			reason: "We should return any errors encountered while selecting a collation",
+			fields: fields{
+				db: mockDB{
+					MockScan: func(ctx context.Context, q xsql.Query, dest ...interface{}) error {
+						if strings.Contains(q.String, "information_schema.collations") {
+							return errBoom
+						}
+						return scanSchema("NO", nil)(ctx, q, dest...)
+					},
+				},
+			},
+			args: args{
+				mg: &v1alpha1.Database{
+					Spec: v1alpha1.DatabaseSpec{
+						ForProvider: v1alpha1.DatabaseParameters{
+							CharacterSet: pointer.String("latin1"),
+							Collation:    pointer.String("latin1_bin"),
+						},
+					},
+				},
+			},
+			want: want{
+				err: errors.Wrap(errBoom, errSelectColl),
+			},
+		},
+		"ErrSelectEncryption": {
+			reason: "We should return errors selecting optional parameters other than those of older servers",
+			fields: fields{
+				db: mockDB{
+					MockScan: func(ctx context.Context, q xsql.Query, dest ...interface{}) error {
+						if strings.Contains(q.String, "default_encryption") {
+							return errBoom
+						}
+						return nil
+					},
+				},
+			},
+			args: args{
+				mg: &v1alpha1.Database{},
+			},
+			want: want{
+				err: errors.Wrap(errBoom, errSelectDB),
+			},
+		},
 	}
 
 	for name, tc := range cases {
@@ -331,6 +499,38 @@ func TestCreate(t *testing.T) {
 				err: nil,
 			},
 		},
+		"SuccessReadOnly": {
+			reason: "A read only database should be created with its options and then made read only",
+			fields: fields{
+				db: &mockDB{
+					MockExec: func(ctx context.Context, q xsql.Query) error {
+						switch q.String {
+						case "CREATE DATABASE `example` CHARACTER SET `utf8mb4` ENCRYPTION 'Y'",
+							"ALTER DATABASE `example` READ ONLY = 1":
+							return nil
+						}
+						return errors.Errorf("unexpected query: %s", q.String)
+					},
+				},
+			},
+			args: args{
+				mg: &v1alpha1.Database{
+					ObjectMeta: metav1.ObjectMeta{
+						Annotations: map[string]string{meta.AnnotationKeyExternalName: "example"},
+					},
+					Spec: v1alpha1.DatabaseSpec{
+						ForProvider: v1alpha1.DatabaseParameters{
+							CharacterSet: pointer.String("utf8mb4"),
+							Encryption:   pointer.Bool(true),
+							ReadOnly:     pointer.Bool(true),
+						},
+					},
+				},
+			},
+			want: want{
+				err: nil,
+			},
+		},
 	}
 
 	for name, tc := range cases {
@@ -347,6 +547,137 @@ func TestCreate(t *testing.T) {
 	}
 }
 
+func TestUpdate(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type fields struct {
+		db xsql.DB
+	}
+
+	type args struct {
+		ctx context.Context
+		mg  resource.Managed
+	}
+
+	type want struct {
+		c   managed.ExternalUpdate
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		fields fields
+		args   args
+		want   want
+	}{
+		"ErrNotDatabase": {
+			reason: "An error should be returned if the managed resource is not a *Database",
+			args: args{
+				mg: nil,
+			},
+			want: want{
+				err: errors.New(errNotDatabase),
+			},
+		},
+		"ErrSelectDatabase": {
+			reason: "Any errors encountered while selecting the database should be returned",
+			fields: fields{
+				db: &mockDB{
+					MockScan: func(ctx context.Context, q xsql.Query, dest ...interface{}) error { return errBoom },
+				},
+			},
+			args: args{
+				mg: &v1alpha1.Database{},
+			},
+			want: want{
+				err: errors.Wrap(errBoom, errSelectDB),
+			},
+		},
+		"ErrExec": {
+			reason: "Any errors encountered while altering the database should be returned",
+			fields: fields{
+				db: &mockDB{
+					MockScan: scanSchema("NO", nil),
+					MockExec: func(ctx context.Context, q xsql.Query) error { return errBoom },
+				},
+			},
+			args: args{
+				mg: &v1alpha1.Database{
+					Spec: v1alpha1.DatabaseSpec{
+						ForProvider: v1alpha1.DatabaseParameters{Collation: pointer.String("utf8mb4_bin")},
+					},
+				},
+			},
+			want: want{
+				err: errors.Wrap(errBoom, errUpdateDB),
+			},
+		},
+		"NoOp": {
+			reason: "No query should be executed if the database is up to date",
+			fields: fields{
+				db: &mockDB{
+					MockScan: scanSchema("NO", nil),
+				},
+			},
+			args: args{
+				mg: &v1alpha1.Database{
+					Spec: v1alpha1.DatabaseSpec{
+						ForProvider: v1alpha1.DatabaseParameters{CharacterSet: pointer.String("UTF8MB4")},
+					},
+				},
+			},
+			want: want{
+				err: nil,
+			},
+		},
+		"Success": {
+			reason: "Only the drifted options should be altered, in a single statement",
+			fields: fields{
+				db: &mockDB{
+					MockScan: scanSchema("NO", pointer.String("READ ONLY=1")),
+					MockExec: func(ctx context.Context, q xsql.Query) error {
+						if want := "ALTER DATABASE `example` CHARACTER SET `utf8mb4` COLLATE `utf8mb4_bin` READ ONLY = 0"; q.String != want {
+							return errors.Errorf("unexpected query: %s", q.String)
+						}
+						return nil
+					},
+				},
+			},
+			args: args{
+				mg: &v1alpha1.Database{
+					ObjectMeta: metav1.ObjectMeta{
+						Annotations: map[string]string{meta.AnnotationKeyExternalName: "example"},
+					},
+					Spec: v1alpha1.DatabaseSpec{
+						ForProvider: v1alpha1.DatabaseParameters{
+							CharacterSet: pointer.String("utf8mb4"),
+							Collation:    pointer.String("utf8mb4_bin"),
+							Encryption:   pointer.Bool(false),
+							ReadOnly:     pointer.Bool(false),
+						},
+					},
+				},
+			},
+			want: want{
+				err: nil,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{db: tc.fields.db}
+			got, err := e.Update(tc.args.ctx, tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Update(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.c, got); diff != "" {
+				t.Errorf("\n%s\ne.Update(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
 func TestDelete(t *testing.T) {
 	errBoom := errors.New("boom")
 
@@ -0,0 +1,285 @@
+/*
+Copyright 2022 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package globalvariable
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	mysqldriver "github.com/go-sql-driver/mysql"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/crossplane-contrib/provider-sql/apis/common"
+	"github.com/crossplane-contrib/provider-sql/apis/mysql/v1alpha1"
+	"github.com/crossplane-contrib/provider-sql/pkg/clients/mysql"
+	"github.com/crossplane-contrib/provider-sql/pkg/clients/xsql"
+)
+
+const (
+	errTrackPCUsage = "cannot track ProviderConfig usage"
+	errGetPC        = "cannot get ProviderConfig"
+	errNoSecretRef  = "ProviderConfig does not reference a credentials Secret"
+	errGetSecret    = "cannot get credentials Secret"
+
+	errNotGlobalVariable = "managed resource is not a GlobalVariable custom resource"
+	errSelectVariable    = "cannot select global variable"
+	errSetVariable       = "cannot set global variable"
+	errResetVariable     = "cannot reset global variable"
+	errFmtUnknown        = "unknown global variable %s"
+
+	// errCodeReadOnlyVariable is returned when setting a read only variable
+	// at runtime.
+	errCodeReadOnlyVariable = 1238
+
+	// sourceDynamic is the source of variables that were set at runtime.
+	sourceDynamic = "DYNAMIC"
+
+	maxConcurrency = 5
+)
+
+// Setup adds a controller that reconciles GlobalVariable managed resources.
+func Setup(mgr ctrl.Manager, l logging.Logger) error {
+	name := managed.ControllerName(v1alpha1.GlobalVariableGroupKind)
+
+	t := resource.NewProviderConfigUsageTracker(mgr.GetClient(), &v1alpha1.ProviderConfigUsage{})
+	r := managed.NewReconciler(mgr,
+		resource.ManagedKind(v1alpha1.GlobalVariableGroupVersionKind),
+		managed.WithExternalConnecter(&connector{kube: mgr.GetClient(), usage: t, newDB: mysql.New}),
+		managed.WithLogger(l.WithValues("controller", name)),
+		managed.WithPollInterval(10*time.Minute),
+		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))))
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		For(&v1alpha1.GlobalVariable{}).
+		WithOptions(controller.Options{
+			MaxConcurrentReconciles: maxConcurrency,
+		}).
+		Complete(r)
+}
+
+type connector struct {
+	kube  client.Client
+	usage resource.Tracker
+	newDB func(creds map[string][]byte, tls *string) xsql.DB
+}
+
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	cr, ok := mg.(*v1alpha1.GlobalVariable)
+	if !ok {
+		return nil, errors.New(errNotGlobalVariable)
+	}
+
+	if err := c.usage.Track(ctx, mg); err != nil {
+		return nil, errors.Wrap(err, errTrackPCUsage)
+	}
+
+	// ProviderConfigReference could theoretically be nil, but in practice the
+	// DefaultProviderConfig initializer will set it before we get here.
+	pc := &v1alpha1.ProviderConfig{}
+	if err := c.kube.Get(ctx, types.NamespacedName{Name: cr.GetProviderConfigReference().Name}, pc); err != nil {
+		return nil, errors.Wrap(err, errGetPC)
+	}
+
+	// We don't need to check the credentials source because we currently only
+	// support one source (MySQLConnectionSecret), which is required and
+	// enforced by the ProviderConfig schema.
+	ref := pc.Spec.Credentials.ConnectionSecretRef
+	if ref == nil {
+		return nil, errors.New(errNoSecretRef)
+	}
+
+	s := &corev1.Secret{}
+	if err := c.kube.Get(ctx, types.NamespacedName{Namespace: ref.Namespace, Name: ref.Name}, s); err != nil {
+		return nil, errors.Wrap(err, errGetSecret)
+	}
+
+	return &external{db: c.newDB(s.Data, pc.Spec.TLS)}, nil
+}
+
+type external struct{ db xsql.DB }
+
+// isReadOnly returns true if passed a MySQL error indicating that a variable
+// cannot be set at runtime.
+func isReadOnly(err error) bool {
+	var myErr *mysqldriver.MySQLError
+	return errors.As(err, &myErr) && myErr.Number == errCodeReadOnlyVariable
+}
+
+// valueToSQL returns the value as it must appear in a SET statement. Numeric
+// variables do not accept quoted values.
+func valueToSQL(v string) string {
+	if _, err := strconv.ParseFloat(v, 64); err == nil {
+		return v
+	}
+	return mysql.QuoteValue(v)
+}
+
+// valuesEqual returns true if the values are equal, ignoring case and the
+// formatting of numbers, e.g. 2 and 2.000000 for long_query_time.
+func valuesEqual(a, b string) bool {
+	fa, errA := strconv.ParseFloat(a, 64)
+	fb, errB := strconv.ParseFloat(b, 64)
+	if errA == nil && errB == nil {
+		return fa == fb
+	}
+	return strings.EqualFold(a, b)
+}
+
+// variable returns the value of the variable reported by the supplied
+// performance_schema table.
+func (c *external) variable(ctx context.Context, table, name string) (string, error) {
+	query := "SELECT VARIABLE_VALUE FROM performance_schema." + table + " WHERE VARIABLE_NAME = ?"
+
+	var v string
+	err := c.db.Scan(ctx, xsql.Query{String: query, Parameters: []interface{}{name}}, &v)
+	return v, err
+}
+
+// source returns the source of the value of the variable in effect, as
+// reported by performance_schema.variables_info, e.g. COMPILED, PERSISTED or
+// DYNAMIC. Variable names can't be supplied as parameters of SET statements,
+// so this is also used to make sure the variable exists before its name is
+// used in one.
+func (c *external) source(ctx context.Context, name string) (string, error) {
+	query := "SELECT VARIABLE_SOURCE FROM performance_schema.variables_info WHERE VARIABLE_NAME = ?"
+
+	var s string
+	err := c.db.Scan(ctx, xsql.Query{String: query, Parameters: []interface{}{name}}, &s)
+	if xsql.IsNoRows(err) {
+		return "", errors.Errorf(errFmtUnknown, name)
+	}
+	return s, err
+}
+
+func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.GlobalVariable)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotGlobalVariable)
+	}
+
+	persisted, err := c.variable(ctx, "persisted_variables", cr.Spec.ForProvider.Variable)
+	if xsql.IsNoRows(err) {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errSelectVariable)
+	}
+
+	source, err := c.source(ctx, cr.Spec.ForProvider.Variable)
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errSelectVariable)
+	}
+
+	current, err := c.variable(ctx, "global_variables", cr.Spec.ForProvider.Variable)
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errSelectVariable)
+	}
+
+	// A value persisted by SET PERSIST also takes effect at runtime, which
+	// makes it a DYNAMIC variable until the server is restarted. Any other
+	// persisted value that is not in effect was set by SET PERSIST_ONLY.
+	cr.Status.AtProvider = v1alpha1.GlobalVariableObservation{
+		Value:          current,
+		PersistedValue: persisted,
+		PendingRestart: source != sourceDynamic && !valuesEqual(current, persisted),
+	}
+	cr.SetConditions(xpv1.Available())
+	if cr.Status.AtProvider.PendingRestart {
+		cr.SetConditions(common.PendingRestart())
+	} else {
+		cr.SetConditions(common.Applied())
+	}
+
+	return managed.ExternalObservation{
+		ResourceExists:   true,
+		ResourceUpToDate: valuesEqual(persisted, cr.Spec.ForProvider.Value),
+	}, nil
+}
+
+func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1alpha1.GlobalVariable)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotGlobalVariable)
+	}
+
+	cr.SetConditions(xpv1.Creating())
+
+	return managed.ExternalCreation{}, c.set(ctx, cr.Spec.ForProvider)
+}
+
+func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*v1alpha1.GlobalVariable)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotGlobalVariable)
+	}
+
+	return managed.ExternalUpdate{}, c.set(ctx, cr.Spec.ForProvider)
+}
+
+func (c *external) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1alpha1.GlobalVariable)
+	if !ok {
+		return errors.New(errNotGlobalVariable)
+	}
+
+	cr.SetConditions(xpv1.Deleting())
+
+	if _, err := c.source(ctx, cr.Spec.ForProvider.Variable); err != nil {
+		return errors.Wrap(err, errResetVariable)
+	}
+
+	query := "RESET PERSIST IF EXISTS " + cr.Spec.ForProvider.Variable
+	if err := c.db.Exec(ctx, xsql.Query{String: query}); err != nil {
+		return errors.Wrap(err, errResetVariable)
+	}
+
+	// RESET PERSIST leaves the value in effect until the server is restarted,
+	// so we restore the default of variables that can be set at runtime.
+	query = "SET GLOBAL " + cr.Spec.ForProvider.Variable + " = DEFAULT"
+	if err := c.db.Exec(ctx, xsql.Query{String: query}); err != nil && !isReadOnly(err) {
+		return errors.Wrap(err, errResetVariable)
+	}
+	return nil
+}
+
+// set persists the variable and applies it at runtime. Read only variables
+// are only persisted, and take effect once the server has been restarted.
+func (c *external) set(ctx context.Context, p v1alpha1.GlobalVariableParameters) error {
+	if _, err := c.source(ctx, p.Variable); err != nil {
+		return errors.Wrap(err, errSetVariable)
+	}
+
+	err := c.db.Exec(ctx, xsql.Query{String: "SET PERSIST " + p.Variable + " = " + valueToSQL(p.Value)})
+	if isReadOnly(err) {
+		err = c.db.Exec(ctx, xsql.Query{String: "SET PERSIST_ONLY " + p.Variable + " = " + valueToSQL(p.Value)})
+	}
+	return errors.Wrap(err, errSetVariable)
+}
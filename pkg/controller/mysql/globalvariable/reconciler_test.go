@@ -0,0 +1,760 @@
+/*
+Copyright 2022 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package globalvariable
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"testing"
+
+	mysqldriver "github.com/go-sql-driver/mysql"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane-contrib/provider-sql/apis/common"
+	"github.com/crossplane-contrib/provider-sql/apis/mysql/v1alpha1"
+	"github.com/crossplane-contrib/provider-sql/pkg/clients/xsql"
+)
+
+type mockDB struct {
+	MockExec                 func(ctx context.Context, q xsql.Query) error
+	MockExecTx               func(ctx context.Context, ql []xsql.Query) error
+	MockScan                 func(ctx context.Context, q xsql.Query, dest ...interface{}) error
+	MockGetConnectionDetails func(username, password string) managed.ConnectionDetails
+}
+
+func (m mockDB) Exec(ctx context.Context, q xsql.Query) error {
+	return m.MockExec(ctx, q)
+}
+func (m mockDB) ExecTx(ctx context.Context, ql []xsql.Query) error {
+	return m.MockExecTx(ctx, ql)
+}
+func (m mockDB) Scan(ctx context.Context, q xsql.Query, dest ...interface{}) error {
+	return m.MockScan(ctx, q, dest...)
+}
+func (m mockDB) Query(ctx context.Context, q xsql.Query) (*sql.Rows, error) {
+	return &sql.Rows{}, nil
+}
+func (m mockDB) GetConnectionDetails(username, password string) managed.ConnectionDetails {
+	return m.MockGetConnectionDetails(username, password)
+}
+
+// scanVariable returns a MockScan that reports the supplied persisted and
+// current values, and source of a variable.
+func scanVariable(persisted, current, source string) func(ctx context.Context, q xsql.Query, dest ...interface{}) error {
+	return func(ctx context.Context, q xsql.Query, dest ...interface{}) error {
+		switch {
+		case strings.Contains(q.String, "variables_info"):
+			*dest[0].(*string) = source
+		case strings.Contains(q.String, "persisted_variables"):
+			*dest[0].(*string) = persisted
+		case strings.Contains(q.String, "global_variables"):
+			*dest[0].(*string) = current
+		default:
+			return errors.New("unexpected query")
+		}
+		return nil
+	}
+}
+
+func TestConnect(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type fields struct {
+		kube  client.Client
+		usage resource.Tracker
+		newDB func(creds map[string][]byte, tls *string) xsql.DB
+	}
+
+	type args struct {
+		ctx context.Context
+		mg  resource.Managed
+	}
+
+	cases := map[string]struct {
+		reason string
+		fields fields
+		args   args
+		want   error
+	}{
+		"ErrNotGlobalVariable": {
+			reason: "An error should be returned if the managed resource is not a GlobalVariable",
+			args: args{
+				mg: nil,
+			},
+			want: errors.New(errNotGlobalVariable),
+		},
+		"ErrTrackProviderConfigUsage": {
+			reason: "An error should be returned if we can't track our ProviderConfig usage",
+			fields: fields{
+				usage: resource.TrackerFn(func(ctx context.Context, mg resource.Managed) error { return errBoom }),
+			},
+			args: args{
+				mg: &v1alpha1.GlobalVariable{},
+			},
+			want: errors.Wrap(errBoom, errTrackPCUsage),
+		},
+		"ErrGetProviderConfig": {
+			reason: "An error should be returned if we can't get our ProviderConfig",
+			fields: fields{
+				kube: &test.MockClient{
+					MockGet: test.NewMockGetFn(errBoom),
+				},
+				usage: resource.TrackerFn(func(ctx context.Context, mg resource.Managed) error { return nil }),
+			},
+			args: args{
+				mg: &v1alpha1.GlobalVariable{
+					Spec: v1alpha1.GlobalVariableSpec{
+						ResourceSpec: xpv1.ResourceSpec{
+							ProviderConfigReference: &xpv1.Reference{},
+						},
+					},
+				},
+			},
+			want: errors.Wrap(errBoom, errGetPC),
+		},
+		"ErrMissingConnectionSecret": {
+			reason: "An error should be returned if our ProviderConfig doesn't specify a connection secret",
+			fields: fields{
+				kube: &test.MockClient{
+					// We call get to populate the Database struct, then again
+					// to populate the (empty) ProviderConfig struct, resulting
+					// in a ProviderConfig with a nil connection secret.
+					MockGet: test.NewMockGetFn(nil),
+				},
+				usage: resource.TrackerFn(func(ctx context.Context, mg resource.Managed) error { return nil }),
+			},
+			args: args{
+				mg: &v1alpha1.GlobalVariable{
+					Spec: v1alpha1.GlobalVariableSpec{
+						ResourceSpec: xpv1.ResourceSpec{
+							ProviderConfigReference: &xpv1.Reference{},
+						},
+					},
+				},
+			},
+			want: errors.New(errNoSecretRef),
+		},
+		"ErrGetConnectionSecret": {
+			reason: "An error should be returned if we can't get our ProviderConfig's connection secret",
+			fields: fields{
+				kube: &test.MockClient{
+					MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+						switch o := obj.(type) {
+						case *v1alpha1.ProviderConfig:
+							o.Spec.Credentials.ConnectionSecretRef = &xpv1.SecretReference{}
+						case *corev1.Secret:
+							return errBoom
+						}
+						return nil
+					}),
+				},
+				usage: resource.TrackerFn(func(ctx context.Context, mg resource.Managed) error { return nil }),
+			},
+			args: args{
+				mg: &v1alpha1.GlobalVariable{
+					Spec: v1alpha1.GlobalVariableSpec{
+						ResourceSpec: xpv1.ResourceSpec{
+							ProviderConfigReference: &xpv1.Reference{},
+						},
+					},
+				},
+			},
+			want: errors.Wrap(errBoom, errGetSecret),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := &connector{kube: tc.fields.kube, usage: tc.fields.usage, newDB: tc.fields.newDB}
+			_, err := e.Connect(tc.args.ctx, tc.args.mg)
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Connect(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestObserve(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type fields struct {
+		db xsql.DB
+	}
+
+	type args struct {
+		ctx context.Context
+		mg  resource.Managed
+	}
+
+	type want struct {
+		o   managed.ExternalObservation
+		mg  resource.Managed
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		fields fields
+		args   args
+		want   want
+	}{
+		"ErrNotGlobalVariable": {
+			reason: "An error should be returned if the managed resource is not a GlobalVariable",
+			args: args{
+				mg: nil,
+			},
+			want: want{
+				err: errors.New(errNotGlobalVariable),
+			},
+		},
+		"ErrNoVariable": {
+			reason: "We should return ResourceExists: false when the variable was not persisted",
+			fields: fields{
+				db: mockDB{
+					MockScan: func(ctx context.Context, q xsql.Query, dest ...interface{}) error { return sql.ErrNoRows },
+				},
+			},
+			args: args{
+				mg: &v1alpha1.GlobalVariable{},
+			},
+			want: want{
+				o:  managed.ExternalObservation{ResourceExists: false},
+				mg: &v1alpha1.GlobalVariable{},
+			},
+		},
+		"ErrSelectVariable": {
+			reason: "We should return any errors encountered while trying to select the variable",
+			fields: fields{
+				db: mockDB{
+					MockScan: func(ctx context.Context, q xsql.Query, dest ...interface{}) error { return errBoom },
+				},
+			},
+			args: args{
+				mg: &v1alpha1.GlobalVariable{},
+			},
+			want: want{
+				err: errors.Wrap(errBoom, errSelectVariable),
+				mg:  &v1alpha1.GlobalVariable{},
+			},
+		},
+		"SuccessPendingRestart": {
+			reason: "We should report a pending restart if the persisted value is not in effect",
+			fields: fields{
+				db: mockDB{
+					MockScan: scanVariable("1000", "151", "COMPILED"),
+				},
+			},
+			args: args{
+				mg: &v1alpha1.GlobalVariable{
+					Spec: v1alpha1.GlobalVariableSpec{
+						ForProvider: v1alpha1.GlobalVariableParameters{Variable: "max_connections", Value: "1000"},
+					},
+				},
+			},
+			want: want{
+				o: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: true,
+				},
+				mg: &v1alpha1.GlobalVariable{
+					Spec: v1alpha1.GlobalVariableSpec{
+						ForProvider: v1alpha1.GlobalVariableParameters{Variable: "max_connections", Value: "1000"},
+					},
+					Status: v1alpha1.GlobalVariableStatus{
+						ResourceStatus: xpv1.ResourceStatus{
+							ConditionedStatus: xpv1.ConditionedStatus{
+								Conditions: []xpv1.Condition{xpv1.Available(), common.PendingRestart()},
+							},
+						},
+						AtProvider: v1alpha1.GlobalVariableObservation{
+							Value:          "151",
+							PersistedValue: "1000",
+							PendingRestart: true,
+						},
+					},
+				},
+			},
+		},
+		"SuccessNumber": {
+			reason: "We should compare numeric values regardless of their formatting",
+			fields: fields{
+				db: mockDB{
+					MockScan: scanVariable("2.000000", "2.000000", "PERSISTED"),
+				},
+			},
+			args: args{
+				mg: &v1alpha1.GlobalVariable{
+					Spec: v1alpha1.GlobalVariableSpec{
+						ForProvider: v1alpha1.GlobalVariableParameters{Variable: "long_query_time", Value: "2"},
+					},
+				},
+			},
+			want: want{
+				o: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: true,
+				},
+				mg: &v1alpha1.GlobalVariable{
+					Spec: v1alpha1.GlobalVariableSpec{
+						ForProvider: v1alpha1.GlobalVariableParameters{Variable: "long_query_time", Value: "2"},
+					},
+					Status: v1alpha1.GlobalVariableStatus{
+						ResourceStatus: xpv1.ResourceStatus{
+							ConditionedStatus: xpv1.ConditionedStatus{
+								Conditions: []xpv1.Condition{xpv1.Available(), common.Applied()},
+							},
+						},
+						AtProvider: v1alpha1.GlobalVariableObservation{
+							Value:          "2.000000",
+							PersistedValue: "2.000000",
+						},
+					},
+				},
+			},
+		},
+		"SuccessSetAtRuntime": {
+			reason: "We should not report a pending restart if the value in effect was set at runtime",
+			fields: fields{
+				db: mockDB{
+					MockScan: scanVariable("1000", "500", "DYNAMIC"),
+				},
+			},
+			args: args{
+				mg: &v1alpha1.GlobalVariable{
+					Spec: v1alpha1.GlobalVariableSpec{
+						ForProvider: v1alpha1.GlobalVariableParameters{Variable: "max_connections", Value: "1000"},
+					},
+				},
+			},
+			want: want{
+				o: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: true,
+				},
+				mg: &v1alpha1.GlobalVariable{
+					Spec: v1alpha1.GlobalVariableSpec{
+						ForProvider: v1alpha1.GlobalVariableParameters{Variable: "max_connections", Value: "1000"},
+					},
+					Status: v1alpha1.GlobalVariableStatus{
+						ResourceStatus: xpv1.ResourceStatus{
+							ConditionedStatus: xpv1.ConditionedStatus{
+								Conditions: []xpv1.Condition{xpv1.Available(), common.Applied()},
+							},
+						},
+						AtProvider: v1alpha1.GlobalVariableObservation{
+							Value:          "500",
+							PersistedValue: "1000",
+						},
+					},
+				},
+			},
+		},
+		"ErrUnknownVariable": {
+			reason: "We should return an error if the server does not know the variable",
+			fields: fields{
+				db: mockDB{
+					MockScan: func(ctx context.Context, q xsql.Query, dest ...interface{}) error {
+						if strings.Contains(q.String, "variables_info") {
+							return sql.ErrNoRows
+						}
+						return nil
+					},
+				},
+			},
+			args: args{
+				mg: &v1alpha1.GlobalVariable{
+					Spec: v1alpha1.GlobalVariableSpec{
+						ForProvider: v1alpha1.GlobalVariableParameters{Variable: "max_connection"},
+					},
+				},
+			},
+			want: want{
+				err: errors.Wrap(errors.Errorf(errFmtUnknown, "max_connection"), errSelectVariable),
+				mg: &v1alpha1.GlobalVariable{
+					Spec: v1alpha1.GlobalVariableSpec{
+						ForProvider: v1alpha1.GlobalVariableParameters{Variable: "max_connection"},
+					},
+				},
+			},
+		},
+		"NotUpToDate": {
+			reason: "We should return ResourceUpToDate: false when another value was persisted",
+			fields: fields{
+				db: mockDB{
+					MockScan: scanVariable("STRICT_TRANS_TABLES", "STRICT_TRANS_TABLES", "DYNAMIC"),
+				},
+			},
+			args: args{
+				mg: &v1alpha1.GlobalVariable{
+					Spec: v1alpha1.GlobalVariableSpec{
+						ForProvider: v1alpha1.GlobalVariableParameters{Variable: "sql_mode", Value: "ANSI_QUOTES"},
+					},
+				},
+			},
+			want: want{
+				o: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: false,
+				},
+				mg: &v1alpha1.GlobalVariable{
+					Spec: v1alpha1.GlobalVariableSpec{
+						ForProvider: v1alpha1.GlobalVariableParameters{Variable: "sql_mode", Value: "ANSI_QUOTES"},
+					},
+					Status: v1alpha1.GlobalVariableStatus{
+						ResourceStatus: xpv1.ResourceStatus{
+							ConditionedStatus: xpv1.ConditionedStatus{
+								Conditions: []xpv1.Condition{xpv1.Available(), common.Applied()},
+							},
+						},
+						AtProvider: v1alpha1.GlobalVariableObservation{
+							Value:          "STRICT_TRANS_TABLES",
+							PersistedValue: "STRICT_TRANS_TABLES",
+						},
+					},
+				},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{db: tc.fields.db}
+			got, err := e.Observe(tc.args.ctx, tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, got); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.mg, tc.args.mg, test.EquateConditions()); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want managed resource, +got managed resource:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestCreate(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type fields struct {
+		db xsql.DB
+	}
+
+	type args struct {
+		ctx context.Context
+		mg  resource.Managed
+	}
+
+	type want struct {
+		c   managed.ExternalCreation
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		fields fields
+		args   args
+		want   want
+	}{
+		"ErrNotGlobalVariable": {
+			reason: "An error should be returned if the managed resource is not a GlobalVariable",
+			args: args{
+				mg: nil,
+			},
+			want: want{
+				err: errors.New(errNotGlobalVariable),
+			},
+		},
+		"ErrUnknownVariable": {
+			reason: "No statement should be executed for a variable the server does not know",
+			fields: fields{
+				db: &mockDB{
+					MockScan: func(ctx context.Context, q xsql.Query, dest ...interface{}) error { return sql.ErrNoRows },
+					MockExec: func(ctx context.Context, q xsql.Query) error { return errBoom },
+				},
+			},
+			args: args{
+				mg: &v1alpha1.GlobalVariable{
+					Spec: v1alpha1.GlobalVariableSpec{
+						ForProvider: v1alpha1.GlobalVariableParameters{Variable: "max_connections = 1; DROP DATABASE mysql"},
+					},
+				},
+			},
+			want: want{
+				err: errors.Wrap(errors.Errorf(errFmtUnknown, "max_connections = 1; DROP DATABASE mysql"), errSetVariable),
+			},
+		},
+		"ErrSetVariable": {
+			reason: "Any errors encountered while setting the variable should be returned",
+			fields: fields{
+				db: &mockDB{
+					MockScan: scanVariable("", "", "COMPILED"),
+					MockExec: func(ctx context.Context, q xsql.Query) error { return errBoom },
+				},
+			},
+			args: args{
+				mg: &v1alpha1.GlobalVariable{},
+			},
+			want: want{
+				err: errors.Wrap(errBoom, errSetVariable),
+			},
+		},
+		"Success": {
+			reason: "No error should be returned when we successfully persist the variable",
+			fields: fields{
+				db: &mockDB{
+					MockScan: scanVariable("", "", "COMPILED"),
+					MockExec: func(ctx context.Context, q xsql.Query) error {
+						if q.String != "SET PERSIST sql_mode = 'ANSI_QUOTES'" {
+							return errBoom
+						}
+						return nil
+					},
+				},
+			},
+			args: args{
+				mg: &v1alpha1.GlobalVariable{
+					Spec: v1alpha1.GlobalVariableSpec{
+						ForProvider: v1alpha1.GlobalVariableParameters{Variable: "sql_mode", Value: "ANSI_QUOTES"},
+					},
+				},
+			},
+			want: want{
+				err: nil,
+			},
+		},
+		"SuccessReadOnly": {
+			reason: "Read only variables should only be persisted",
+			fields: fields{
+				db: &mockDB{
+					MockScan: scanVariable("", "", "COMPILED"),
+					MockExec: func(ctx context.Context, q xsql.Query) error {
+						switch q.String {
+						case "SET PERSIST innodb_log_file_size = 1073741824":
+							return &mysqldriver.MySQLError{Number: errCodeReadOnlyVariable}
+						case "SET PERSIST_ONLY innodb_log_file_size = 1073741824":
+							return nil
+						}
+						return errBoom
+					},
+				},
+			},
+			args: args{
+				mg: &v1alpha1.GlobalVariable{
+					Spec: v1alpha1.GlobalVariableSpec{
+						ForProvider: v1alpha1.GlobalVariableParameters{Variable: "innodb_log_file_size", Value: "1073741824"},
+					},
+				},
+			},
+			want: want{
+				err: nil,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{db: tc.fields.db}
+			got, err := e.Create(tc.args.ctx, tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Create(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.c, got); diff != "" {
+				t.Errorf("\n%s\ne.Create(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type fields struct {
+		db xsql.DB
+	}
+
+	type args struct {
+		ctx context.Context
+		mg  resource.Managed
+	}
+
+	type want struct {
+		c   managed.ExternalUpdate
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		fields fields
+		args   args
+		want   want
+	}{
+		"ErrNotGlobalVariable": {
+			reason: "An error should be returned if the managed resource is not a GlobalVariable",
+			args: args{
+				mg: nil,
+			},
+			want: want{
+				err: errors.New(errNotGlobalVariable),
+			},
+		},
+		"ErrSetVariable": {
+			reason: "Any errors encountered while setting the variable should be returned",
+			fields: fields{
+				db: &mockDB{
+					MockScan: scanVariable("", "", "COMPILED"),
+					MockExec: func(ctx context.Context, q xsql.Query) error { return errBoom },
+				},
+			},
+			args: args{
+				mg: &v1alpha1.GlobalVariable{},
+			},
+			want: want{
+				err: errors.Wrap(errBoom, errSetVariable),
+			},
+		},
+		"Success": {
+			reason: "No error should be returned when we successfully set the variable",
+			fields: fields{
+				db: &mockDB{
+					MockScan: scanVariable("", "", "COMPILED"),
+					MockExec: func(ctx context.Context, q xsql.Query) error { return nil },
+				},
+			},
+			args: args{
+				mg: &v1alpha1.GlobalVariable{},
+			},
+			want: want{
+				err: nil,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{db: tc.fields.db}
+			got, err := e.Update(tc.args.ctx, tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Update(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.c, got); diff != "" {
+				t.Errorf("\n%s\ne.Update(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestDelete(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type fields struct {
+		db xsql.DB
+	}
+
+	type args struct {
+		ctx context.Context
+		mg  resource.Managed
+	}
+
+	cases := map[string]struct {
+		reason string
+		fields fields
+		args   args
+		want   error
+	}{
+		"ErrNotGlobalVariable": {
+			reason: "An error should be returned if the managed resource is not a GlobalVariable",
+			args: args{
+				mg: nil,
+			},
+			want: errors.New(errNotGlobalVariable),
+		},
+		"ErrResetVariable": {
+			reason: "Errors resetting a variable should be returned",
+			fields: fields{
+				db: &mockDB{
+					MockScan: scanVariable("", "", "COMPILED"),
+					MockExec: func(ctx context.Context, q xsql.Query) error { return errBoom },
+				},
+			},
+			args: args{
+				mg: &v1alpha1.GlobalVariable{},
+			},
+			want: errors.Wrap(errBoom, errResetVariable),
+		},
+		"Success": {
+			reason: "No error should be returned if the variable was reset",
+			fields: fields{
+				db: &mockDB{
+					MockScan: scanVariable("", "", "COMPILED"),
+					MockExec: func(ctx context.Context, q xsql.Query) error {
+						switch q.String {
+						case "RESET PERSIST IF EXISTS max_connections", "SET GLOBAL max_connections = DEFAULT":
+							return nil
+						}
+						return errBoom
+					},
+				},
+			},
+			args: args{
+				mg: &v1alpha1.GlobalVariable{
+					Spec: v1alpha1.GlobalVariableSpec{
+						ForProvider: v1alpha1.GlobalVariableParameters{Variable: "max_connections", Value: "1000"},
+					},
+				},
+			},
+			want: nil,
+		},
+		"SuccessReadOnly": {
+			reason: "No error should be returned if a read only variable cannot be reset at runtime",
+			fields: fields{
+				db: &mockDB{
+					MockScan: scanVariable("", "", "COMPILED"),
+					MockExec: func(ctx context.Context, q xsql.Query) error {
+						if strings.HasPrefix(q.String, "SET GLOBAL") {
+							return &mysqldriver.MySQLError{Number: errCodeReadOnlyVariable}
+						}
+						return nil
+					},
+				},
+			},
+			args: args{
+				mg: &v1alpha1.GlobalVariable{},
+			},
+			want: nil,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{db: tc.fields.db}
+			err := e.Delete(tc.args.ctx, tc.args.mg)
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Delete(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
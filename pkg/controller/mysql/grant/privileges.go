@@ -0,0 +1,494 @@
+/*
+Copyright 2022 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package grant
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/crossplane-contrib/provider-sql/pkg/clients/mysql"
+	"github.com/crossplane-contrib/provider-sql/pkg/clients/xsql"
+)
+
+const (
+	privilegeUsage       = "USAGE"
+	privilegeGrantOption = "GRANT OPTION"
+)
+
+// A privilegeScope is the level privileges are granted at.
+type privilegeScope int
+
+// Privileges are granted globally (*.*), on a database (db.*), on a table
+// (db.table) or on a stored procedure or function.
+const (
+	scopeGlobal privilegeScope = iota
+	scopeDatabase
+	scopeTable
+	scopeRoutine
+)
+
+// A privilegeColumn maps a privilege to the column of the mysql.user and
+// mysql.db tables that records whether it was granted.
+type privilegeColumn struct {
+	privilege string
+	column    string
+}
+
+var (
+	tablePrivileges = []privilegeColumn{
+		{"SELECT", "Select_priv"},
+		{"INSERT", "Insert_priv"},
+		{"UPDATE", "Update_priv"},
+		{"DELETE", "Delete_priv"},
+		{"CREATE", "Create_priv"},
+		{"DROP", "Drop_priv"},
+		{"REFERENCES", "References_priv"},
+		{"INDEX", "Index_priv"},
+		{"ALTER", "Alter_priv"},
+		{"CREATE VIEW", "Create_view_priv"},
+		{"SHOW VIEW", "Show_view_priv"},
+		{"TRIGGER", "Trigger_priv"},
+	}
+
+	routinePrivileges = []privilegeColumn{
+		{"EXECUTE", "Execute_priv"},
+		{"ALTER ROUTINE", "Alter_routine_priv"},
+	}
+
+	databasePrivileges = append(append([]privilegeColumn{}, tablePrivileges...), []privilegeColumn{
+		{"CREATE TEMPORARY TABLES", "Create_tmp_table_priv"},
+		{"LOCK TABLES", "Lock_tables_priv"},
+		{"EXECUTE", "Execute_priv"},
+		{"CREATE ROUTINE", "Create_routine_priv"},
+		{"ALTER ROUTINE", "Alter_routine_priv"},
+		{"EVENT", "Event_priv"},
+	}...)
+
+	globalPrivileges = append(append([]privilegeColumn{}, databasePrivileges...), []privilegeColumn{
+		{"RELOAD", "Reload_priv"},
+		{"SHUTDOWN", "Shutdown_priv"},
+		{"PROCESS", "Process_priv"},
+		{"FILE", "File_priv"},
+		{"SHOW DATABASES", "Show_db_priv"},
+		{"SUPER", "Super_priv"},
+		{"REPLICATION SLAVE", "Repl_slave_priv"},
+		{"CREATE USER", "Create_user_priv"},
+	}...)
+
+	// scopePrivileges are the static privileges ALL PRIVILEGES grants at a
+	// scope on every supported server. Servers may grant additional, e.g.
+	// dynamic, privileges.
+	scopePrivileges = map[privilegeScope][]privilegeColumn{
+		scopeGlobal:   globalPrivileges,
+		scopeDatabase: databasePrivileges,
+		scopeTable:    tablePrivileges,
+		scopeRoutine:  routinePrivileges,
+	}
+)
+
+func scopeOf(dbname, table string) privilegeScope {
+	switch {
+	case dbname == "*":
+		return scopeGlobal
+	case table == "*":
+		return scopeDatabase
+	}
+	return scopeTable
+}
+
+// normalizePrivileges returns the sorted set of the supplied privileges. The
+// privileges that make up ALL PRIVILEGES at the scope are collapsed into it,
+// as the information_schema privilege tables report them individually.
+func normalizePrivileges(privileges []string, scope privilegeScope) []string {
+	set := map[string]bool{}
+	for _, p := range privileges {
+		p = strings.ToUpper(strings.TrimSpace(p))
+		switch p {
+		case "", privilegeUsage:
+			continue
+		case "ALL":
+			p = allPrivileges
+		}
+		set[p] = true
+	}
+
+	all := set[allPrivileges]
+	if !all {
+		all = true
+		for _, p := range scopePrivileges[scope] {
+			all = all && set[p.privilege]
+		}
+	}
+	if all {
+		// ALL PRIVILEGES covers everything but the grant option.
+		set = map[string]bool{allPrivileges: true, privilegeGrantOption: set[privilegeGrantOption]}
+	}
+
+	out := make([]string, 0, len(set))
+	for p, ok := range set {
+		if ok {
+			out = append(out, p)
+		}
+	}
+	sort.Strings(out)
+	return out
+}
+
+// covers returns true if the privilege is part of the supplied set, either
+// explicitly or because the set includes ALL PRIVILEGES.
+func covers(set map[string]bool, privilege string) bool {
+	return set[privilege] || (set[allPrivileges] && privilege != privilegeGrantOption)
+}
+
+// diffPrivileges returns the privileges that must be granted and revoked for
+// the observed privileges to match the desired ones. Both must have been
+// normalized for the supplied scope.
+func diffPrivileges(desired, observed []string, scope privilegeScope) (toGrant, toRevoke []string) {
+	d := map[string]bool{}
+	for _, p := range desired {
+		d[p] = true
+	}
+	o := map[string]bool{}
+	for _, p := range observed {
+		o[p] = true
+	}
+
+	for _, p := range desired {
+		if !covers(o, p) {
+			toGrant = append(toGrant, p)
+		}
+	}
+
+	// Revoking ALL PRIVILEGES would revoke desired privileges too, so we
+	// revoke the privileges it is made up of that are no longer desired.
+	// Any privileges a server grants in addition are revoked once they are
+	// observed individually.
+	revocable := observed
+	if o[allPrivileges] && !d[allPrivileges] {
+		revocable = []string{}
+		for _, p := range scopePrivileges[scope] {
+			revocable = append(revocable, p.privilege)
+		}
+		if o[privilegeGrantOption] {
+			revocable = append(revocable, privilegeGrantOption)
+		}
+	}
+	for _, p := range revocable {
+		if !covers(d, p) {
+			toRevoke = append(toRevoke, p)
+		}
+	}
+
+	return toGrant, toRevoke
+}
+
+// columnPrivileges returns the privileges granted on each of the supplied
+// columns as a sorted set, e.g. SELECT (id).
+func columnPrivileges(privileges, columns []string) []string {
+	set := map[string]bool{}
+	for _, p := range privileges {
+		for _, col := range columns {
+			set[fmt.Sprintf("%s (%s)", strings.ToUpper(strings.TrimSpace(p)), col)] = true
+		}
+	}
+	out := make([]string, 0, len(set))
+	for p := range set {
+		out = append(out, p)
+	}
+	sort.Strings(out)
+	return out
+}
+
+// parseColumnPrivilege splits a column privilege, e.g. SELECT (id), into the
+// privilege and the column it is granted on.
+func parseColumnPrivilege(s string) (privilege, column string) {
+	i := strings.Index(s, " (")
+	if i < 0 || !strings.HasSuffix(s, ")") {
+		return s, ""
+	}
+	return s[:i], s[i+2 : len(s)-1]
+}
+
+// onColumns returns the column privileges, e.g. SELECT (id), that are granted
+// on any of the supplied columns.
+func onColumns(columnPrivileges, columns []string) []string {
+	set := map[string]bool{}
+	for _, col := range columns {
+		set[col] = true
+	}
+	out := []string{}
+	for _, cp := range columnPrivileges {
+		if _, col := parseColumnPrivilege(cp); set[col] {
+			out = append(out, cp)
+		}
+	}
+	return out
+}
+
+// privilegesClause returns the privileges and column privileges as they are
+// listed in a GRANT or REVOKE statement, e.g. SELECT (`id`, `name`), INSERT.
+func privilegesClause(privileges, columnPrivileges []string) string {
+	out := append([]string{}, privileges...)
+
+	var order []string
+	columns := map[string][]string{}
+	for _, cp := range columnPrivileges {
+		p, col := parseColumnPrivilege(cp)
+		if _, ok := columns[p]; !ok {
+			order = append(order, p)
+		}
+		columns[p] = append(columns[p], mysql.QuoteIdentifier(col))
+	}
+	for _, p := range order {
+		out = append(out, fmt.Sprintf("%s (%s)", p, strings.Join(columns[p], ", ")))
+	}
+	return strings.Join(out, ", ")
+}
+
+// difference returns the elements of a that are not in b.
+func difference(a, b []string) []string {
+	set := map[string]bool{}
+	for _, s := range b {
+		set[s] = true
+	}
+	var out []string
+	for _, s := range a {
+		if !set[s] {
+			out = append(out, s)
+		}
+	}
+	return out
+}
+
+func granteeValue(username string) string {
+	username, host := mysql.SplitUserHost(username)
+	return fmt.Sprintf("'%s'@'%s'", username, host)
+}
+
+// observedPrivileges are the privileges of a user on a scope.
+type observedPrivileges struct {
+	// privileges granted at the scope, as a normalized set.
+	privileges []string
+
+	// columns maps the columns of a table to the privileges granted on them.
+	columns map[string][]string
+}
+
+func (o *observedPrivileges) empty() bool {
+	return len(o.privileges) == 0 && len(o.columns) == 0
+}
+
+// getPrivileges returns the privileges the supplied user was granted on the
+// supplied database and table, which may be * to select the global or
+// database scope. Privileges are read from the information_schema privilege
+// tables, or the mysql grant tables if the former are unknown to the server,
+// may not be read by the connecting user, or report nothing. The
+// information_schema privilege tables only report the privileges of other
+// users to a connecting user that may read the mysql grant tables, so we
+// return an error rather than assume nothing was granted if it may not.
+func (c *external) getPrivileges(ctx context.Context, username, dbname, table string) (*observedPrivileges, error) {
+	o, err := c.getSchemaPrivileges(ctx, username, dbname, table)
+	if mysql.IsUnsupported(err) || mysql.IsAccessDenied(err) || (err == nil && o.empty()) {
+		o, err = c.getGrantTablePrivileges(ctx, username, dbname, table)
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, errCurrentGrant)
+	}
+	o.privileges = normalizePrivileges(o.privileges, scopeOf(dbname, table))
+	return o, nil
+}
+
+// getRoutinePrivileges returns the privileges the supplied user was granted
+// on a stored procedure or function. These are only recorded in the
+// mysql.procs_priv grant table.
+func (c *external) getRoutinePrivileges(ctx context.Context, username, dbname, routineType, routine string) (*observedPrivileges, error) {
+	username, host := mysql.SplitUserHost(username)
+
+	var privileges string
+	err := c.db.Scan(ctx, xsql.Query{
+		String:     "SELECT Proc_priv FROM mysql.procs_priv WHERE User = ? AND Host = ? AND Db = ? AND Routine_name = ? AND Routine_type = ?",
+		Parameters: []interface{}{username, host, dbname, routine, routineType},
+	}, &privileges)
+	if err != nil && !xsql.IsNoRows(err) {
+		return nil, errors.Wrap(err, errCurrentGrant)
+	}
+
+	return &observedPrivileges{
+		privileges: normalizePrivileges(grantTablePrivileges(privileges), scopeRoutine),
+		columns:    map[string][]string{},
+	}, nil
+}
+
+func (c *external) getSchemaPrivileges(ctx context.Context, username, dbname, table string) (*observedPrivileges, error) {
+	o := &observedPrivileges{columns: map[string][]string{}}
+	grantee := granteeValue(username)
+
+	var q xsql.Query
+	switch scopeOf(dbname, table) {
+	case scopeGlobal:
+		q = xsql.Query{
+			String:     "SELECT PRIVILEGE_TYPE, IS_GRANTABLE FROM information_schema.USER_PRIVILEGES WHERE GRANTEE = ?",
+			Parameters: []interface{}{grantee},
+		}
+	case scopeDatabase:
+		q = xsql.Query{
+			String:     "SELECT PRIVILEGE_TYPE, IS_GRANTABLE FROM information_schema.SCHEMA_PRIVILEGES WHERE GRANTEE = ? AND TABLE_SCHEMA = ?",
+			Parameters: []interface{}{grantee, dbname},
+		}
+	case scopeTable:
+		q = xsql.Query{
+			String:     "SELECT PRIVILEGE_TYPE, IS_GRANTABLE FROM information_schema.TABLE_PRIVILEGES WHERE GRANTEE = ? AND TABLE_SCHEMA = ? AND TABLE_NAME = ?",
+			Parameters: []interface{}{grantee, dbname, table},
+		}
+	}
+
+	grantable := false
+	if err := c.scanRows(ctx, q, func(dest ...interface{}) {
+		o.privileges = append(o.privileges, *dest[0].(*string))
+		grantable = grantable || *dest[1].(*string) == "YES"
+	}, new(string), new(string)); err != nil {
+		return nil, err
+	}
+
+	if scopeOf(dbname, table) == scopeTable {
+		// The grant option of column privileges is granted on the table,
+		// but only reported along with the column privileges.
+		q = xsql.Query{
+			String:     "SELECT COLUMN_NAME, PRIVILEGE_TYPE, IS_GRANTABLE FROM information_schema.COLUMN_PRIVILEGES WHERE GRANTEE = ? AND TABLE_SCHEMA = ? AND TABLE_NAME = ?",
+			Parameters: []interface{}{grantee, dbname, table},
+		}
+		if err := c.scanRows(ctx, q, func(dest ...interface{}) {
+			col := *dest[0].(*string)
+			o.columns[col] = append(o.columns[col], *dest[1].(*string))
+			grantable = grantable || *dest[2].(*string) == "YES"
+		}, new(string), new(string), new(string)); err != nil {
+			return nil, err
+		}
+	}
+
+	if grantable {
+		o.privileges = append(o.privileges, privilegeGrantOption)
+	}
+	return o, nil
+}
+
+// privilegeColumnsQuery returns a query selecting the privileges recorded in
+// the supplied columns as a comma separated list.
+func privilegeColumnsQuery(cols []privilegeColumn, from string) string {
+	s := make([]string, len(cols))
+	for i, c := range cols {
+		s[i] = fmt.Sprintf("IF(%s = 'Y', '%s', NULL)", c.column, c.privilege)
+	}
+	return fmt.Sprintf("SELECT CONCAT_WS(',', %s, IF(Grant_priv = 'Y', '%s', NULL)) FROM %s", strings.Join(s, ", "), privilegeGrantOption, from)
+}
+
+// grantTablePrivileges parses the Table_priv and Column_priv columns of the
+// mysql grant tables, e.g. Select,Create View,Grant.
+func grantTablePrivileges(s string) []string {
+	out := []string{}
+	for _, p := range strings.Split(s, ",") {
+		p = strings.ToUpper(strings.TrimSpace(p))
+		switch p {
+		case "":
+			continue
+		case "GRANT":
+			p = privilegeGrantOption
+		}
+		out = append(out, p)
+	}
+	return out
+}
+
+func (c *external) getGrantTablePrivileges(ctx context.Context, username, dbname, table string) (*observedPrivileges, error) {
+	o := &observedPrivileges{columns: map[string][]string{}}
+	username, host := mysql.SplitUserHost(username)
+
+	var q xsql.Query
+	switch scopeOf(dbname, table) {
+	case scopeGlobal:
+		q = xsql.Query{
+			String:     privilegeColumnsQuery(globalPrivileges, "mysql.user WHERE User = ? AND Host = ?"),
+			Parameters: []interface{}{username, host},
+		}
+	case scopeDatabase:
+		q = xsql.Query{
+			String:     privilegeColumnsQuery(databasePrivileges, "mysql.db WHERE User = ? AND Host = ? AND Db = ?"),
+			Parameters: []interface{}{username, host, dbname},
+		}
+	case scopeTable:
+		q = xsql.Query{
+			String:     "SELECT Table_priv FROM mysql.tables_priv WHERE User = ? AND Host = ? AND Db = ? AND Table_name = ?",
+			Parameters: []interface{}{username, host, dbname, table},
+		}
+	}
+
+	var privileges string
+	err := c.db.Scan(ctx, q, &privileges)
+	if err != nil && !xsql.IsNoRows(err) {
+		return nil, err
+	}
+	o.privileges = grantTablePrivileges(privileges)
+
+	if scopeOf(dbname, table) != scopeTable {
+		return o, nil
+	}
+
+	q = xsql.Query{
+		String:     "SELECT Column_name, Column_priv FROM mysql.columns_priv WHERE User = ? AND Host = ? AND Db = ? AND Table_name = ?",
+		Parameters: []interface{}{username, host, dbname, table},
+	}
+	err = c.scanRows(ctx, q, func(dest ...interface{}) {
+		col := *dest[0].(*string)
+		o.columns[col] = append(o.columns[col], grantTablePrivileges(*dest[1].(*string))...)
+	}, new(string), new(string))
+	return o, err
+}
+
+// scanRows runs the supplied query, scanning each row into dest before
+// passing it to fn.
+func (c *external) scanRows(ctx context.Context, q xsql.Query, fn func(dest ...interface{}), dest ...interface{}) error {
+	rows, err := c.db.Query(ctx, q)
+	if err != nil {
+		return err
+	}
+	defer rows.Close() //nolint:errcheck
+
+	for rows.Next() {
+		if err := rows.Scan(dest...); err != nil {
+			return err
+		}
+		fn(dest...)
+	}
+	return rows.Err()
+}
+
+// columnPrivilegesToStrings returns the column privileges as they are
+// reported in the status of a Grant, e.g. SELECT (id).
+func columnPrivilegesToStrings(columns map[string][]string) []string {
+	out := []string{}
+	for col, privileges := range columns {
+		for _, p := range privileges {
+			out = append(out, fmt.Sprintf("%s (%s)", strings.ToUpper(p), col))
+		}
+	}
+	sort.Strings(out)
+	return out
+}
@@ -0,0 +1,188 @@
+/*
+Copyright 2022 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package grant
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestNormalizePrivileges(t *testing.T) {
+	cases := map[string]struct {
+		reason     string
+		privileges []string
+		scope      privilegeScope
+		want       []string
+	}{
+		"Sorted": {
+			reason:     "Privileges should be returned as a sorted set without USAGE",
+			privileges: []string{"USAGE", "insert", "SELECT", "INSERT"},
+			scope:      scopeDatabase,
+			want:       []string{"INSERT", "SELECT"},
+		},
+		"All": {
+			reason:     "ALL should be an alias for ALL PRIVILEGES",
+			privileges: []string{"ALL", "SELECT", "GRANT OPTION"},
+			scope:      scopeDatabase,
+			want:       []string{"ALL PRIVILEGES", "GRANT OPTION"},
+		},
+		"CollapseTable": {
+			reason: "Privileges making up ALL PRIVILEGES on a table should be collapsed",
+			privileges: []string{
+				"SELECT", "INSERT", "UPDATE", "DELETE", "CREATE", "DROP", "REFERENCES",
+				"INDEX", "ALTER", "CREATE VIEW", "SHOW VIEW", "TRIGGER", "DELETE HISTORY",
+			},
+			scope: scopeTable,
+			want:  []string{"ALL PRIVILEGES"},
+		},
+		"NoCollapseDatabase": {
+			reason: "Privileges making up ALL PRIVILEGES on a table should not be collapsed on a database",
+			privileges: []string{
+				"SELECT", "INSERT", "UPDATE", "DELETE", "CREATE", "DROP", "REFERENCES",
+				"INDEX", "ALTER", "CREATE VIEW", "SHOW VIEW", "TRIGGER",
+			},
+			scope: scopeDatabase,
+			want: []string{
+				"ALTER", "CREATE", "CREATE VIEW", "DELETE", "DROP", "INDEX", "INSERT",
+				"REFERENCES", "SELECT", "SHOW VIEW", "TRIGGER", "UPDATE",
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := normalizePrivileges(tc.privileges, tc.scope)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nnormalizePrivileges(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestDiffPrivileges(t *testing.T) {
+	type want struct {
+		toGrant  []string
+		toRevoke []string
+	}
+
+	cases := map[string]struct {
+		reason   string
+		desired  []string
+		observed []string
+		scope    privilegeScope
+		want     want
+	}{
+		"UpToDate": {
+			reason:   "Nothing should be granted or revoked if the privileges match",
+			desired:  []string{"INSERT", "SELECT"},
+			observed: []string{"INSERT", "SELECT"},
+			scope:    scopeDatabase,
+			want:     want{},
+		},
+		"Changed": {
+			reason:   "Only the changed privileges should be granted and revoked",
+			desired:  []string{"INSERT", "SELECT"},
+			observed: []string{"DELETE", "SELECT"},
+			scope:    scopeDatabase,
+			want: want{
+				toGrant:  []string{"INSERT"},
+				toRevoke: []string{"DELETE"},
+			},
+		},
+		"ToAllPrivileges": {
+			reason:   "Privileges covered by ALL PRIVILEGES should not be revoked",
+			desired:  []string{"ALL PRIVILEGES"},
+			observed: []string{"SELECT"},
+			scope:    scopeDatabase,
+			want: want{
+				toGrant: []string{"ALL PRIVILEGES"},
+			},
+		},
+		"FromAllPrivileges": {
+			reason:   "The privileges making up ALL PRIVILEGES should be revoked individually",
+			desired:  []string{"SELECT"},
+			observed: []string{"ALL PRIVILEGES", "GRANT OPTION"},
+			scope:    scopeTable,
+			want: want{
+				toRevoke: []string{
+					"INSERT", "UPDATE", "DELETE", "CREATE", "DROP", "REFERENCES",
+					"INDEX", "ALTER", "CREATE VIEW", "SHOW VIEW", "TRIGGER", "GRANT OPTION",
+				},
+			},
+		},
+		"GrantOption": {
+			reason:   "The grant option should not be considered covered by ALL PRIVILEGES",
+			desired:  []string{"ALL PRIVILEGES", "GRANT OPTION"},
+			observed: []string{"ALL PRIVILEGES"},
+			scope:    scopeGlobal,
+			want: want{
+				toGrant: []string{"GRANT OPTION"},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			toGrant, toRevoke := diffPrivileges(tc.desired, tc.observed, tc.scope)
+			if diff := cmp.Diff(tc.want.toGrant, toGrant); diff != "" {
+				t.Errorf("\n%s\ndiffPrivileges(...): -want grant, +got grant:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.toRevoke, toRevoke); diff != "" {
+				t.Errorf("\n%s\ndiffPrivileges(...): -want revoke, +got revoke:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestPrivilegesClause(t *testing.T) {
+	cases := map[string]struct {
+		reason           string
+		privileges       []string
+		columnPrivileges []string
+		want             string
+	}{
+		"Privileges": {
+			reason:     "Privileges should be listed as is",
+			privileges: []string{"SELECT", "GRANT OPTION"},
+			want:       "SELECT, GRANT OPTION",
+		},
+		"Columns": {
+			reason:           "Column privileges should be grouped by privilege with quoted columns",
+			privileges:       []string{"GRANT OPTION"},
+			columnPrivileges: columnPrivileges([]string{"select", "UPDATE"}, []string{"id", "full name"}),
+			want:             "GRANT OPTION, SELECT (`full name`, `id`), UPDATE (`full name`, `id`)",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := privilegesClause(tc.privileges, tc.columnPrivileges)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nprivilegesClause(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestGrantTablePrivileges(t *testing.T) {
+	got := grantTablePrivileges("Select,Create View,Grant")
+	want := []string{"SELECT", "CREATE VIEW", "GRANT OPTION"}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("grantTablePrivileges(...): -want, +got:\n%s\n", diff)
+	}
+}
@@ -19,12 +19,12 @@ package grant
 import (
 	"context"
 	"fmt"
-	"regexp"
-	"sort"
 	"strings"
 	"time"
 
 	mysqldriver "github.com/go-sql-driver/mysql"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/types"
@@ -35,6 +35,7 @@ import (
 	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
 	"github.com/crossplane/crossplane-runtime/pkg/event"
 	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
 	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
 	"github.com/crossplane/crossplane-runtime/pkg/resource"
 
@@ -52,16 +53,20 @@ const (
 	errNotGrant     = "managed resource is not a Grant custom resource"
 	errCreateGrant  = "cannot create grant"
 	errRevokeGrant  = "cannot revoke grant"
-	errCurrentGrant = "cannot show current grants"
+	errCurrentGrant = "cannot select current grants"
 	errFlushPriv    = "cannot flush privileges"
-
-	allPrivileges      = "ALL PRIVILEGES"
-	errCodeNoSuchGrant = 1141
-	maxConcurrency     = 5
-)
-
-var (
-	grantRegex = regexp.MustCompile(`^GRANT (.+) ON (.+)\.(.+) TO .+`)
+	errNoGrantee    = "user or role not passed or could not be resolved"
+	errUserAndRole  = "user and role are mutually exclusive"
+	errNoPrivileges = "privileges, memberOf or proxy must be passed"
+	errGetUser      = "cannot get referenced User"
+
+	errSelectMembership = "cannot select role membership"
+	errSelectProxy      = "cannot select proxy grant"
+
+	allPrivileges          = "ALL PRIVILEGES"
+	errCodeNoSuchGrant     = 1141
+	errCodeNoSuchProcGrant = 1403
+	maxConcurrency         = 5
 )
 
 // Setup adds a controller that reconciles Grant managed resources.
@@ -139,103 +144,266 @@ func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 		return managed.ExternalObservation{}, errors.New(errNotGrant)
 	}
 
-	username := *cr.Spec.ForProvider.User
-	dbname := defaultIdentifier(cr.Spec.ForProvider.Database)
-	table := defaultIdentifier(cr.Spec.ForProvider.Table)
+	if err := validate(cr.Spec.ForProvider); err != nil {
+		if meta.WasDeleted(cr) {
+			// An invalid grant was never created, so there is nothing to
+			// revoke.
+			return managed.ExternalObservation{ResourceExists: false}, nil
+		}
+		return managed.ExternalObservation{}, err
+	}
 
-	privileges, result, err := c.getPrivileges(ctx, username, dbname, table)
+	grantees, err := c.grantees(ctx, cr.Spec.ForProvider)
 	if err != nil {
 		return managed.ExternalObservation{}, err
 	}
-	if result != nil {
-		return *result, nil
-	}
 
-	if !privilegesEqual(cr.Spec.ForProvider.Privileges.ToStringSlice(), privileges) {
-		return managed.ExternalObservation{
-			ResourceExists:   true,
-			ResourceUpToDate: false,
-		}, nil
+	// A grant for several accounts only exists and is up to date if it is
+	// for each of them. The status reflects the first account.
+	observation := managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true}
+	for i, g := range grantees {
+		var o managed.ExternalObservation
+		switch {
+		case cr.Spec.ForProvider.MemberOf != nil:
+			o, err = c.observeMembership(ctx, cr, g)
+		case cr.Spec.ForProvider.Proxy != nil:
+			o, err = c.observeProxy(ctx, cr, g)
+		default:
+			o, err = c.observePrivileges(ctx, cr, g, i == 0)
+		}
+		if err != nil || !o.ResourceExists {
+			return o, err
+		}
+		observation.ResourceUpToDate = observation.ResourceUpToDate && o.ResourceUpToDate
 	}
 
 	cr.SetConditions(xpv1.Available())
 
+	return observation, nil
+}
+
+func (c *external) observePrivileges(ctx context.Context, cr *v1alpha1.Grant, grantee string, status bool) (managed.ExternalObservation, error) {
+	gp := cr.Spec.ForProvider
+	observed, err := c.observedPrivileges(ctx, gp, grantee)
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
+	if len(observed.privileges) == 0 && len(observed.columns) == 0 {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+
+	if status {
+		cr.Status.AtProvider.Privileges = observed.privileges
+		cr.Status.AtProvider.ColumnPrivileges = columnPrivilegesToStrings(observed.columns)
+	}
+
+	_, columns := specPrivileges(gp)
+	desired := desiredPrivileges(gp, grantScope(gp))
 	return managed.ExternalObservation{
-		ResourceExists:   true,
-		ResourceUpToDate: true,
+		ResourceExists: true,
+		ResourceUpToDate: cmp.Equal(desired, managedPrivileges(gp, observed.privileges), cmpopts.EquateEmpty()) &&
+			(len(gp.Columns) == 0 || cmp.Equal(columns, onColumns(columnPrivilegesToStrings(observed.columns), gp.Columns), cmpopts.EquateEmpty())),
 	}, nil
 }
 
-func defaultIdentifier(identifier *string) string {
-	if identifier != nil && *identifier != "*" {
-		return mysql.QuoteIdentifier(*identifier)
+// observedPrivileges returns the privileges the grantee has on the object of
+// the grant.
+func (c *external) observedPrivileges(ctx context.Context, gp v1alpha1.GrantParameters, grantee string) (*observedPrivileges, error) {
+	dbname := defaultName(gp.Database)
+	if routineType, name, ok := routine(gp); ok {
+		return c.getRoutinePrivileges(ctx, grantee, dbname, routineType, name)
 	}
-
-	return "*"
+	return c.getPrivileges(ctx, grantee, dbname, defaultName(gp.Table))
 }
 
-func privilegesEqual(a, b []string) bool {
-	if len(a) != len(b) {
-		return false
+// grantees returns the accounts the grant is for. A grant for a User with
+// several hosts is made for its account on each of them.
+func (c *external) grantees(ctx context.Context, gp v1alpha1.GrantParameters) ([]string, error) {
+	g, err := grantee(gp)
+	if err != nil {
+		return nil, err
+	}
+	if gp.User == nil || gp.UserRef == nil {
+		return []string{g}, nil
 	}
-	sort.Strings(a)
-	sort.Strings(b)
 
-	for i := range a {
-		// Special case because ALL is an alias for "ALL PRIVILEGES"
-		strA := strings.ReplaceAll(a[i], allPrivileges, "ALL")
-		strB := strings.ReplaceAll(b[i], allPrivileges, "ALL")
-		if strA != strB {
-			return false
-		}
+	// The accounts of a User that no longer exists have been dropped, along
+	// with their grants.
+	u := &v1alpha1.User{}
+	if err := c.kube.Get(ctx, types.NamespacedName{Name: gp.UserRef.Name}, u); resource.IgnoreNotFound(err) != nil {
+		return nil, errors.Wrap(err, errGetUser)
 	}
-	return true
+	if len(u.Spec.ForProvider.Hosts) == 0 {
+		return []string{g}, nil
+	}
+
+	username, _ := mysql.SplitUserHost(g)
+	grantees := make([]string, len(u.Spec.ForProvider.Hosts))
+	for i, h := range u.Spec.ForProvider.Hosts {
+		grantees[i] = fmt.Sprintf("%s@%s", username, h)
+	}
+	return grantees, nil
 }
 
-func parseGrant(grant, dbname string, table string) (privileges []string) {
-	matches := grantRegex.FindStringSubmatch(grant)
-	if len(matches) == 4 && matches[2] == dbname && matches[3] == table {
-		return strings.Split(matches[1], ", ")
+// validate returns an error if the grant does not specify what it grants, or
+// whom it grants it to.
+func validate(gp v1alpha1.GrantParameters) error {
+	if _, err := grantee(gp); err != nil {
+		return err
+	}
+	if len(gp.Privileges) == 0 && gp.MemberOf == nil && gp.Proxy == nil {
+		return errors.New(errNoPrivileges)
 	}
 	return nil
 }
 
-func (c *external) getPrivileges(ctx context.Context, username, dbname string, table string) ([]string, *managed.ExternalObservation, error) {
-	username, host := mysql.SplitUserHost(username)
-	query := fmt.Sprintf("SHOW GRANTS FOR %s@%s", mysql.QuoteValue(username), mysql.QuoteValue(host))
-	rows, err := c.db.Query(ctx, xsql.Query{String: query})
-	if err != nil {
-		return nil, nil, errors.Wrap(err, errCurrentGrant)
+// grantee returns the user or role the grant is for.
+func grantee(gp v1alpha1.GrantParameters) (string, error) {
+	switch {
+	case gp.User != nil && gp.Role != nil:
+		return "", errors.New(errUserAndRole)
+	case gp.User != nil:
+		return *gp.User, nil
+	case gp.Role != nil:
+		return *gp.Role, nil
 	}
-	defer rows.Close() //nolint:errcheck
+	return "", errors.New(errNoGrantee)
+}
 
-	var privileges []string
-	for rows.Next() {
-		var grant string
-		if err := rows.Scan(&grant); err != nil {
-			return nil, nil, errors.Wrap(err, errCurrentGrant)
-		}
-		p := parseGrant(grant, dbname, table)
+func withAdminOption(gp v1alpha1.GrantParameters) bool {
+	return gp.WithOption != nil && *gp.WithOption == v1alpha1.GrantOptionAdmin
+}
 
-		if p != nil {
-			// found the grant we were looking for
-			privileges = p
-			break
-		}
+func withGrantOption(gp v1alpha1.GrantParameters) bool {
+	return gp.WithOption != nil && *gp.WithOption == v1alpha1.GrantOptionGrant
+}
+
+// routine returns the type and name of the stored procedure or function the
+// grant is for, if any.
+func routine(gp v1alpha1.GrantParameters) (string, string, bool) {
+	switch {
+	case gp.Procedure != nil:
+		return "PROCEDURE", *gp.Procedure, true
+	case gp.Function != nil:
+		return "FUNCTION", *gp.Function, true
 	}
+	return "", "", false
+}
 
-	if err := rows.Err(); err != nil {
-		var myErr *mysqldriver.MySQLError
-		if errors.As(err, &myErr) && myErr.Number == errCodeNoSuchGrant {
-			// The user doesn't (yet) exist and therefore no grants either
-			return nil, &managed.ExternalObservation{ResourceExists: false}, nil
+func grantScope(gp v1alpha1.GrantParameters) privilegeScope {
+	if _, _, ok := routine(gp); ok {
+		return scopeRoutine
+	}
+	return scopeOf(defaultName(gp.Database), defaultName(gp.Table))
+}
+
+// grantObject returns the object privileges are granted on, e.g. `db`.* or
+// PROCEDURE `db`.`routine`.
+func grantObject(gp v1alpha1.GrantParameters) string {
+	if routineType, name, ok := routine(gp); ok {
+		return fmt.Sprintf("%s %s.%s", routineType, defaultIdentifier(gp.Database), mysql.QuoteIdentifier(name))
+	}
+	return fmt.Sprintf("%s.%s", defaultIdentifier(gp.Database), defaultIdentifier(gp.Table))
+}
+
+// specPrivileges returns the privileges granted on the object of the grant,
+// or on the columns of its table if any were supplied.
+func specPrivileges(gp v1alpha1.GrantParameters) (privileges, columns []string) {
+	if len(gp.Columns) > 0 {
+		return nil, columnPrivileges(gp.Privileges.ToStringSlice(), gp.Columns)
+	}
+	return gp.Privileges.ToStringSlice(), nil
+}
+
+// desiredPrivileges returns the normalized privileges the grant manages on
+// its object, including the grant option.
+func desiredPrivileges(gp v1alpha1.GrantParameters, scope privilegeScope) []string {
+	privileges, _ := specPrivileges(gp)
+	if withGrantOption(gp) {
+		privileges = append(privileges, privilegeGrantOption)
+	}
+	return normalizePrivileges(privileges, scope)
+}
+
+// managedPrivileges returns the observed privileges that are managed by the
+// grant. A grant with columns only manages the grant option on its table.
+func managedPrivileges(gp v1alpha1.GrantParameters, observed []string) []string {
+	if len(gp.Columns) == 0 {
+		return observed
+	}
+	out := []string{}
+	for _, p := range observed {
+		if p == privilegeGrantOption {
+			out = append(out, p)
 		}
-		return nil, nil, errors.Wrap(err, errCurrentGrant)
 	}
-	if privileges == nil {
-		return nil, &managed.ExternalObservation{ResourceExists: false}, nil
+	return out
+}
+
+func (c *external) observeMembership(ctx context.Context, cr *v1alpha1.Grant, grantee string) (managed.ExternalObservation, error) {
+	role, roleHost := mysql.SplitUserHost(*cr.Spec.ForProvider.MemberOf)
+	username, host := mysql.SplitUserHost(grantee)
+
+	var admin string
+	query := "SELECT WITH_ADMIN_OPTION FROM mysql.role_edges " +
+		"WHERE FROM_USER = ? AND FROM_HOST = ? AND TO_USER = ? AND TO_HOST = ?"
+	err := c.db.Scan(ctx, xsql.Query{
+		String:     query,
+		Parameters: []interface{}{role, roleHost, username, host},
+	}, &admin)
+	if xsql.IsNoRows(err) {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errSelectMembership)
+	}
+
+	return managed.ExternalObservation{
+		ResourceExists:   true,
+		ResourceUpToDate: (admin == "Y") == withAdminOption(cr.Spec.ForProvider),
+	}, nil
+}
+
+func (c *external) observeProxy(ctx context.Context, cr *v1alpha1.Grant, grantee string) (managed.ExternalObservation, error) {
+	proxy, proxyHost := mysql.SplitUserHost(*cr.Spec.ForProvider.Proxy)
+	username, host := mysql.SplitUserHost(grantee)
+
+	var withGrant bool
+	query := "SELECT With_grant FROM mysql.proxies_priv " +
+		"WHERE User = ? AND Host = ? AND Proxied_user = ? AND Proxied_host = ?"
+	err := c.db.Scan(ctx, xsql.Query{
+		String:     query,
+		Parameters: []interface{}{username, host, proxy, proxyHost},
+	}, &withGrant)
+	if xsql.IsNoRows(err) {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errSelectProxy)
+	}
+
+	return managed.ExternalObservation{
+		ResourceExists:   true,
+		ResourceUpToDate: withGrant == withGrantOption(cr.Spec.ForProvider),
+	}, nil
+}
+
+// defaultName returns the name of a database or table, or * if none was
+// supplied.
+func defaultName(name *string) string {
+	if name != nil {
+		return *name
+	}
+
+	return "*"
+}
+
+func defaultIdentifier(identifier *string) string {
+	if identifier != nil && *identifier != "*" {
+		return mysql.QuoteIdentifier(*identifier)
 	}
-	return privileges, nil, nil
+
+	return "*"
 }
 
 func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
@@ -244,17 +412,37 @@ func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.Ext
 		return managed.ExternalCreation{}, errors.New(errNotGrant)
 	}
 
-	username := *cr.Spec.ForProvider.User
-	dbname := defaultIdentifier(cr.Spec.ForProvider.Database)
-	table := defaultIdentifier(cr.Spec.ForProvider.Table)
+	if err := validate(cr.Spec.ForProvider); err != nil {
+		return managed.ExternalCreation{}, err
+	}
 
-	privileges := strings.Join(cr.Spec.ForProvider.Privileges.ToStringSlice(), ", ")
+	grantees, err := c.grantees(ctx, cr.Spec.ForProvider)
+	if err != nil {
+		return managed.ExternalCreation{}, err
+	}
 
-	query := createGrantQuery(privileges, dbname, username, table)
+	if cr.Spec.ForProvider.MemberOf != nil {
+		query := createMembershipQuery(*cr.Spec.ForProvider.MemberOf, grantees, withAdminOption(cr.Spec.ForProvider))
+		if err := c.db.Exec(ctx, xsql.Query{String: query}); err != nil {
+			return managed.ExternalCreation{}, errors.Wrap(err, errCreateGrant)
+		}
+		return managed.ExternalCreation{}, nil
+	}
+	if cr.Spec.ForProvider.Proxy != nil {
+		query := createProxyQuery(*cr.Spec.ForProvider.Proxy, grantees, withGrantOption(cr.Spec.ForProvider))
+		if err := c.db.Exec(ctx, xsql.Query{String: query}); err != nil {
+			return managed.ExternalCreation{}, errors.Wrap(err, errCreateGrant)
+		}
+		return managed.ExternalCreation{}, nil
+	}
+
+	privileges, columns := specPrivileges(cr.Spec.ForProvider)
+
+	query := createGrantQuery(privilegesClause(privileges, columns), grantObject(cr.Spec.ForProvider), grantees, withGrantOption(cr.Spec.ForProvider))
 	if err := c.db.Exec(ctx, xsql.Query{String: query}); err != nil {
 		return managed.ExternalCreation{}, errors.Wrap(err, errCreateGrant)
 	}
-	err := c.db.Exec(ctx, xsql.Query{String: "FLUSH PRIVILEGES"})
+	err = c.db.Exec(ctx, xsql.Query{String: "FLUSH PRIVILEGES"})
 	return managed.ExternalCreation{}, errors.Wrap(err, errFlushPriv)
 }
 
@@ -264,44 +452,173 @@ func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.Ext
 		return managed.ExternalUpdate{}, errors.New(errNotGrant)
 	}
 
-	username := *cr.Spec.ForProvider.User
-	dbname := defaultIdentifier(cr.Spec.ForProvider.Database)
-	table := defaultIdentifier(cr.Spec.ForProvider.Table)
+	grantees, err := c.grantees(ctx, cr.Spec.ForProvider)
+	if err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+
+	gp := cr.Spec.ForProvider
+	if gp.MemberOf != nil {
+		admin := withAdminOption(gp)
+		return managed.ExternalUpdate{}, c.updateOption(ctx, revokeMembershipQuery(*gp.MemberOf, grantees), createMembershipQuery(*gp.MemberOf, grantees, admin), admin)
+	}
+	if gp.Proxy != nil {
+		grant := withGrantOption(gp)
+		return managed.ExternalUpdate{}, c.updateOption(ctx, revokeProxyQuery(*gp.Proxy, grantees), createProxyQuery(*gp.Proxy, grantees, grant), grant)
+	}
+
+	changed := false
+	for i, g := range grantees {
+		// The privileges of the first grantee were observed into the
+		// status, those of any other are observed again.
+		observed := &observedPrivileges{privileges: cr.Status.AtProvider.Privileges}
+		observedColumns := cr.Status.AtProvider.ColumnPrivileges
+		if i > 0 {
+			if observed, err = c.observedPrivileges(ctx, gp, g); err != nil {
+				return managed.ExternalUpdate{}, err
+			}
+			observedColumns = columnPrivilegesToStrings(observed.columns)
+		}
 
-	privileges := strings.Join(cr.Spec.ForProvider.Privileges.ToStringSlice(), ", ")
-	username, host := mysql.SplitUserHost(username)
+		updated, err := c.updatePrivileges(ctx, gp, g, observed.privileges, observedColumns)
+		if err != nil {
+			return managed.ExternalUpdate{}, err
+		}
+		changed = changed || updated
+	}
+	if !changed {
+		return managed.ExternalUpdate{}, nil
+	}
 
-	// Remove current grants since it's not possible to update grants.
-	// This might leave applications with no access to the DB for a short time
-	// until the privileges are granted again.
+	err = c.db.Exec(ctx, xsql.Query{String: "FLUSH PRIVILEGES"})
+	return managed.ExternalUpdate{}, errors.Wrap(err, errFlushPriv)
+}
+
+// updatePrivileges grants and revokes the privileges of the grantee that
+// differ from the observed ones, and returns whether any did.
+func (c *external) updatePrivileges(ctx context.Context, gp v1alpha1.GrantParameters, grantee string, observed, observedColumns []string) (bool, error) {
+	scope := grantScope(gp)
+	object := grantObject(gp)
+
+	// Only the privileges that changed since they were observed are granted
+	// and revoked. Privileges are granted before any are revoked so that
+	// applications never lose access to the DB in between.
 	// Using a transaction is unfortunately not possible because a GRANT triggers
 	// an implicit commit: https://dev.mysql.com/doc/refman/8.0/en/implicit-commit.html
-	query := fmt.Sprintf("REVOKE ALL ON %s.%s FROM %s@%s",
-		dbname,
-		table,
-		mysql.QuoteValue(username),
-		mysql.QuoteValue(host),
+	toGrant, toRevoke := diffPrivileges(desiredPrivileges(gp, scope), managedPrivileges(gp, observed), scope)
+
+	// A grant with columns only manages the privileges on its columns, so
+	// that several grants may be made on different columns of a table.
+	var columnsToGrant, columnsToRevoke []string
+	if len(gp.Columns) > 0 {
+		_, columns := specPrivileges(gp)
+		observedColumns = onColumns(observedColumns, gp.Columns)
+		columnsToGrant = difference(columns, observedColumns)
+		columnsToRevoke = difference(observedColumns, columns)
+	}
+
+	if len(toGrant) > 0 || len(columnsToGrant) > 0 {
+		query := createGrantQuery(privilegesClause(toGrant, columnsToGrant), object, []string{grantee}, false)
+		if err := c.db.Exec(ctx, xsql.Query{String: query}); err != nil {
+			return false, errors.Wrap(err, errCreateGrant)
+		}
+	}
+	if len(toRevoke) > 0 || len(columnsToRevoke) > 0 {
+		query := revokeGrantQuery(privilegesClause(toRevoke, columnsToRevoke), object, []string{grantee})
+		if err := c.db.Exec(ctx, xsql.Query{String: query}); err != nil {
+			return false, errors.Wrap(err, errRevokeGrant)
+		}
+	}
+	return len(toGrant)+len(columnsToGrant)+len(toRevoke)+len(columnsToRevoke) > 0, nil
+}
+
+// updateOption sets the admin option of a role membership, or the grant
+// option of a proxy grant. The option cannot be revoked on its own, so the
+// role or proxy is revoked and granted again without it.
+func (c *external) updateOption(ctx context.Context, revoke, grant string, option bool) error {
+	if !option {
+		if err := c.db.Exec(ctx, xsql.Query{String: revoke}); err != nil {
+			return errors.Wrap(err, errRevokeGrant)
+		}
+	}
+	return errors.Wrap(c.db.Exec(ctx, xsql.Query{String: grant}), errCreateGrant)
+}
+
+// granteesClause returns the accounts a grant is for, as listed in a GRANT
+// or REVOKE statement.
+func granteesClause(grantees []string) string {
+	accounts := make([]string, len(grantees))
+	for i, g := range grantees {
+		username, host := mysql.SplitUserHost(g)
+		accounts[i] = fmt.Sprintf("%s@%s", mysql.QuoteValue(username), mysql.QuoteValue(host))
+	}
+	return strings.Join(accounts, ", ")
+}
+
+func createMembershipQuery(role string, grantees []string, admin bool) string {
+	role, roleHost := mysql.SplitUserHost(role)
+	result := fmt.Sprintf("GRANT %s@%s TO %s",
+		mysql.QuoteValue(role),
+		mysql.QuoteValue(roleHost),
+		granteesClause(grantees),
 	)
-	if err := c.db.Exec(ctx, xsql.Query{String: query}); err != nil {
-		return managed.ExternalUpdate{}, errors.Wrap(err, errRevokeGrant)
+	if admin {
+		result += " WITH ADMIN OPTION"
 	}
 
-	query = createGrantQuery(privileges, dbname, username, table)
-	if err := c.db.Exec(ctx, xsql.Query{String: query}); err != nil {
-		return managed.ExternalUpdate{}, err
+	return result
+}
+
+func revokeMembershipQuery(role string, grantees []string) string {
+	role, roleHost := mysql.SplitUserHost(role)
+	return fmt.Sprintf("REVOKE %s@%s FROM %s",
+		mysql.QuoteValue(role),
+		mysql.QuoteValue(roleHost),
+		granteesClause(grantees),
+	)
+}
+
+func createProxyQuery(proxy string, grantees []string, grantOption bool) string {
+	proxy, proxyHost := mysql.SplitUserHost(proxy)
+	result := fmt.Sprintf("GRANT PROXY ON %s@%s TO %s",
+		mysql.QuoteValue(proxy),
+		mysql.QuoteValue(proxyHost),
+		granteesClause(grantees),
+	)
+	if grantOption {
+		result += " WITH GRANT OPTION"
 	}
-	err := c.db.Exec(ctx, xsql.Query{String: "FLUSH PRIVILEGES"})
-	return managed.ExternalUpdate{}, errors.Wrap(err, errFlushPriv)
+
+	return result
+}
+
+func revokeProxyQuery(proxy string, grantees []string) string {
+	proxy, proxyHost := mysql.SplitUserHost(proxy)
+	return fmt.Sprintf("REVOKE PROXY ON %s@%s FROM %s",
+		mysql.QuoteValue(proxy),
+		mysql.QuoteValue(proxyHost),
+		granteesClause(grantees),
+	)
 }
 
-func createGrantQuery(privileges, dbname, username string, table string) string {
-	username, host := mysql.SplitUserHost(username)
-	result := fmt.Sprintf("GRANT %s ON %s.%s TO %s@%s",
+func createGrantQuery(privileges, object string, grantees []string, grantOption bool) string {
+	result := fmt.Sprintf("GRANT %s ON %s TO %s",
 		privileges,
-		dbname,
-		table,
-		mysql.QuoteValue(username),
-		mysql.QuoteValue(host),
+		object,
+		granteesClause(grantees),
+	)
+	if grantOption {
+		result += " WITH GRANT OPTION"
+	}
+
+	return result
+}
+
+func revokeGrantQuery(privileges, object string, grantees []string) string {
+	result := fmt.Sprintf("REVOKE %s ON %s FROM %s",
+		privileges,
+		object,
+		granteesClause(grantees),
 	)
 
 	return result
@@ -313,29 +630,34 @@ func (c *external) Delete(ctx context.Context, mg resource.Managed) error {
 		return errors.New(errNotGrant)
 	}
 
-	username := *cr.Spec.ForProvider.User
-	dbname := defaultIdentifier(cr.Spec.ForProvider.Database)
-	table := defaultIdentifier(cr.Spec.ForProvider.Table)
-
-	privileges := strings.Join(cr.Spec.ForProvider.Privileges.ToStringSlice(), ", ")
-	username, host := mysql.SplitUserHost(username)
+	grantees, err := c.grantees(ctx, cr.Spec.ForProvider)
+	if err != nil {
+		return err
+	}
 
-	query := fmt.Sprintf("REVOKE %s ON %s.%s FROM %s@%s",
-		privileges,
-		dbname,
-		table,
-		mysql.QuoteValue(username),
-		mysql.QuoteValue(host),
-	)
+	var query string
+	switch {
+	case cr.Spec.ForProvider.MemberOf != nil:
+		query = revokeMembershipQuery(*cr.Spec.ForProvider.MemberOf, grantees)
+	case cr.Spec.ForProvider.Proxy != nil:
+		query = revokeProxyQuery(*cr.Spec.ForProvider.Proxy, grantees)
+	default:
+		privileges, columns := specPrivileges(cr.Spec.ForProvider)
+		if withGrantOption(cr.Spec.ForProvider) {
+			privileges = append(privileges, privilegeGrantOption)
+		}
+		query = revokeGrantQuery(privilegesClause(privileges, columns), grantObject(cr.Spec.ForProvider), grantees)
+	}
 
 	if err := c.db.Exec(ctx, xsql.Query{String: query}); err != nil {
 		var myErr *mysqldriver.MySQLError
-		if errors.As(err, &myErr) && myErr.Number == errCodeNoSuchGrant {
-			// MySQL automatically deletes related grants if the user has been deleted
+		if errors.As(err, &myErr) && (myErr.Number == errCodeNoSuchGrant || myErr.Number == errCodeNoSuchProcGrant) {
+			// MySQL automatically deletes related grants if the user, or
+			// the procedure or function, has been deleted
 			return nil
 		}
 		return errors.Wrap(err, errRevokeGrant)
 	}
-	err := c.db.Exec(ctx, xsql.Query{String: "FLUSH PRIVILEGES"})
+	err = c.db.Exec(ctx, xsql.Query{String: "FLUSH PRIVILEGES"})
 	return errors.Wrap(err, errFlushPriv)
 }
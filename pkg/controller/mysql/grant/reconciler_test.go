@@ -29,6 +29,7 @@ import (
 	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/utils/pointer"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -184,9 +185,13 @@ func TestConnect(t *testing.T) {
 
 func TestObserve(t *testing.T) {
 	errBoom := errors.New("boom")
+	now := metav1.Now()
+	goa := v1alpha1.GrantOptionAdmin
+	gog := v1alpha1.GrantOptionGrant
 
 	type fields struct {
-		db xsql.DB
+		db   xsql.DB
+		kube client.Client
 	}
 
 	type args struct {
@@ -214,21 +219,72 @@ func TestObserve(t *testing.T) {
 				err: errors.New(errNotGrant),
 			},
 		},
+		"ErrNoPrivileges": {
+			reason: "We should return an error if the grant does not grant anything",
+			args: args{
+				mg: &v1alpha1.Grant{
+					Spec: v1alpha1.GrantSpec{
+						ForProvider: v1alpha1.GrantParameters{
+							Database: pointer.StringPtr("test-example"),
+							User:     pointer.StringPtr("test-example"),
+						},
+					},
+				},
+			},
+			want: want{
+				err: errors.New(errNoPrivileges),
+			},
+		},
+		"ErrUserAndRole": {
+			reason: "We should return an error if the grant is for both a user and a role",
+			args: args{
+				mg: &v1alpha1.Grant{
+					Spec: v1alpha1.GrantSpec{
+						ForProvider: v1alpha1.GrantParameters{
+							Database:   pointer.StringPtr("test-example"),
+							User:       pointer.StringPtr("test-example"),
+							Role:       pointer.StringPtr("test-role"),
+							Privileges: v1alpha1.GrantPrivileges{"ALL"},
+						},
+					},
+				},
+			},
+			want: want{
+				err: errors.New(errUserAndRole),
+			},
+		},
+		"DeletedInvalid": {
+			reason: "We should return ResourceExists: false for an invalid grant that is being deleted",
+			args: args{
+				mg: &v1alpha1.Grant{
+					ObjectMeta: metav1.ObjectMeta{DeletionTimestamp: &now},
+					Spec: v1alpha1.GrantSpec{
+						ForProvider: v1alpha1.GrantParameters{
+							Database: pointer.StringPtr("test-example"),
+							User:     pointer.StringPtr("test-example"),
+						},
+					},
+				},
+			},
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: false},
+			},
+		},
 		"SuccessNoGrant": {
 			reason: "We should return ResourceExists: false when no grant is found",
 			fields: fields{
 				db: mockDB{
-					MockQuery: func(ctx context.Context, q xsql.Query) (*sql.Rows, error) {
-						return mockRowsToSQLRows(sqlmock.NewRows([]string{})), nil
-					},
+					MockQuery: privilegeRows("NO"),
+					MockScan:  func(ctx context.Context, q xsql.Query, dest ...interface{}) error { return sql.ErrNoRows },
 				},
 			},
 			args: args{
 				mg: &v1alpha1.Grant{
 					Spec: v1alpha1.GrantSpec{
 						ForProvider: v1alpha1.GrantParameters{
-							Database: pointer.StringPtr("test-example"),
-							User:     pointer.StringPtr("test-example"),
+							Database:   pointer.StringPtr("test-example"),
+							User:       pointer.StringPtr("test-example"),
+							Privileges: v1alpha1.GrantPrivileges{"ALL"},
 						},
 					},
 				},
@@ -237,8 +293,33 @@ func TestObserve(t *testing.T) {
 				o: managed.ExternalObservation{ResourceExists: false},
 			},
 		},
+		"ErrGrantTablesAccessDenied": {
+			reason: "We should return an error rather than assume nothing was granted if the information_schema privilege tables report nothing and the mysql grant tables may not be read",
+			fields: fields{
+				db: mockDB{
+					MockQuery: privilegeRows("NO"),
+					MockScan: func(ctx context.Context, q xsql.Query, dest ...interface{}) error {
+						return &mysql.MySQLError{Number: 1142}
+					},
+				},
+			},
+			args: args{
+				mg: &v1alpha1.Grant{
+					Spec: v1alpha1.GrantSpec{
+						ForProvider: v1alpha1.GrantParameters{
+							Database:   pointer.StringPtr("test-example"),
+							User:       pointer.StringPtr("test-example"),
+							Privileges: v1alpha1.GrantPrivileges{"ALL"},
+						},
+					},
+				},
+			},
+			want: want{
+				err: errors.Wrap(&mysql.MySQLError{Number: 1142}, errCurrentGrant),
+			},
+		},
 		"ErrSelectGrant": {
-			reason: "We should return any errors encountered while trying to show the grants",
+			reason: "We should return any errors encountered while trying to select the grants",
 			fields: fields{
 				db: mockDB{
 					MockQuery: func(ctx context.Context, q xsql.Query) (*sql.Rows, error) { return &sql.Rows{}, errBoom },
@@ -248,8 +329,9 @@ func TestObserve(t *testing.T) {
 				mg: &v1alpha1.Grant{
 					Spec: v1alpha1.GrantSpec{
 						ForProvider: v1alpha1.GrantParameters{
-							Database: pointer.StringPtr("test-example"),
-							User:     pointer.StringPtr("test-example"),
+							Database:   pointer.StringPtr("test-example"),
+							User:       pointer.StringPtr("test-example"),
+							Privileges: v1alpha1.GrantPrivileges{"ALL"},
 						},
 					},
 				},
@@ -258,16 +340,19 @@ func TestObserve(t *testing.T) {
 				err: errors.Wrap(errBoom, errCurrentGrant),
 			},
 		},
-		"SuccessNoUser": {
-			reason: "We should return no error if the user doesn't exist",
+		"SuccessGrantTables": {
+			reason: "We should read the mysql grant tables if the information_schema privilege tables are not available",
 			fields: fields{
 				db: mockDB{
 					MockQuery: func(ctx context.Context, q xsql.Query) (*sql.Rows, error) {
-						return mockRowsToSQLRows(
-							sqlmock.NewRows([]string{"Grants"}).
-								AddRow("GRANT CREATE, DROP ON `success-db`.* TO 'no-user'@%").
-								RowError(0, &mysql.MySQLError{Number: errCodeNoSuchGrant}),
-						), nil
+						return nil, &mysql.MySQLError{Number: 1109}
+					},
+					MockScan: func(ctx context.Context, q xsql.Query, dest ...interface{}) error {
+						if !strings.Contains(q.String, "FROM mysql.db") {
+							return errBoom
+						}
+						*dest[0].(*string) = "CREATE,DROP"
+						return nil
 					},
 				},
 			},
@@ -276,29 +361,69 @@ func TestObserve(t *testing.T) {
 					Spec: v1alpha1.GrantSpec{
 						ForProvider: v1alpha1.GrantParameters{
 							Database:   pointer.StringPtr("success-db"),
-							User:       pointer.StringPtr("no-user"),
+							User:       pointer.StringPtr("success-user"),
 							Privileges: v1alpha1.GrantPrivileges{"DROP", "CREATE"},
 						},
 					},
 				},
 			},
 			want: want{
-				o: managed.ExternalObservation{ResourceExists: false},
+				o: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: true,
+				},
 			},
 		},
-		"Success": {
-			reason: "We should return no error if we can successfully show our grants",
+		"SuccessGrantTablesAccessDenied": {
+			reason: "We should read the mysql grant tables if the information_schema privilege tables may not be read",
 			fields: fields{
 				db: mockDB{
 					MockQuery: func(ctx context.Context, q xsql.Query) (*sql.Rows, error) {
-						return mockRowsToSQLRows(
-							sqlmock.NewRows(
-								[]string{"Grants"},
-							).AddRow("GRANT " + allPrivileges + " ON `success-db`.* TO 'success-user'@%"),
-						), nil
+						if !strings.Contains(q.String, "FROM mysql.columns_priv") {
+							return nil, &mysql.MySQLError{Number: 1142}
+						}
+						rows := sqlmock.NewRows([]string{"Column_name", "Column_priv"}).
+							AddRow("id", "Select").
+							AddRow("email", "Select,Update")
+						return mockRowsToSQLRows(rows), nil
+					},
+					MockScan: func(ctx context.Context, q xsql.Query, dest ...interface{}) error {
+						if !strings.Contains(q.String, "FROM mysql.tables_priv") {
+							return errBoom
+						}
+						*dest[0].(*string) = "Grant"
+						return nil
+					},
+				},
+			},
+			args: args{
+				mg: &v1alpha1.Grant{
+					Spec: v1alpha1.GrantSpec{
+						ForProvider: v1alpha1.GrantParameters{
+							Database:   pointer.StringPtr("success-db"),
+							Table:      pointer.StringPtr("success-table"),
+							User:       pointer.StringPtr("success-user"),
+							Privileges: v1alpha1.GrantPrivileges{"SELECT"},
+							Columns:    []string{"id"},
+							WithOption: &gog,
+						},
 					},
 				},
 			},
+			want: want{
+				o: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: true,
+				},
+			},
+		},
+		"Success": {
+			reason: "We should return no error if we can successfully select our grants",
+			fields: fields{
+				db: mockDB{
+					MockQuery: privilegeRows("NO", "SELECT", "INSERT", "UPDATE", "DELETE", "CREATE", "DROP", "REFERENCES", "INDEX", "ALTER", "CREATE VIEW", "SHOW VIEW", "TRIGGER", "CREATE TEMPORARY TABLES", "LOCK TABLES", "EXECUTE", "CREATE ROUTINE", "ALTER ROUTINE", "EVENT"),
+				},
+			},
 			args: args{
 				mg: &v1alpha1.Grant{
 					Spec: v1alpha1.GrantSpec{
@@ -315,20 +440,13 @@ func TestObserve(t *testing.T) {
 					ResourceExists:   true,
 					ResourceUpToDate: true,
 				},
-				err: nil,
 			},
 		},
 		"SuccessDiffGrants": {
 			reason: "We should return no error if different grants exist",
 			fields: fields{
 				db: mockDB{
-					MockQuery: func(ctx context.Context, q xsql.Query) (*sql.Rows, error) {
-						return mockRowsToSQLRows(
-							sqlmock.NewRows(
-								[]string{"Grants"},
-							).AddRow("GRANT CREATE ON `success-db`.* TO 'diff-user'@%"),
-						), nil
-					},
+					MockQuery: privilegeRows("NO", "CREATE"),
 				},
 			},
 			args: args{
@@ -350,16 +468,10 @@ func TestObserve(t *testing.T) {
 			},
 		},
 		"SuccessManyGrants": {
-			reason: "We should return no error if there are more than one grant for a user",
+			reason: "We should merge the privileges reported in separate rows",
 			fields: fields{
 				db: mockDB{
-					MockQuery: func(ctx context.Context, q xsql.Query) (*sql.Rows, error) {
-						return mockRowsToSQLRows(
-							sqlmock.NewRows([]string{"Grants"}).
-								AddRow("GRANT CREATE, DROP ON `success-db`.* TO 'success-user'@%").
-								AddRow("GRANT EVENT ON `success-db`.* TO 'success-user'@%"),
-						), nil
-					},
+					MockQuery: privilegeRows("NO", "CREATE", "DROP"),
 				},
 			},
 			args: args{
@@ -378,7 +490,31 @@ func TestObserve(t *testing.T) {
 					ResourceExists:   true,
 					ResourceUpToDate: true,
 				},
-				err: nil,
+			},
+		},
+		"SuccessGrantOption": {
+			reason: "We should see the grants out of sync if the grant option was granted",
+			fields: fields{
+				db: mockDB{
+					MockQuery: privilegeRows("YES", "CREATE", "DROP"),
+				},
+			},
+			args: args{
+				mg: &v1alpha1.Grant{
+					Spec: v1alpha1.GrantSpec{
+						ForProvider: v1alpha1.GrantParameters{
+							Database:   pointer.StringPtr("success-db"),
+							User:       pointer.StringPtr("success-user"),
+							Privileges: v1alpha1.GrantPrivileges{"DROP", "CREATE"},
+						},
+					},
+				},
+			},
+			want: want{
+				o: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: false,
+				},
 			},
 		},
 		"SuccessGrantNoDatabaseNoTable": {
@@ -386,10 +522,10 @@ func TestObserve(t *testing.T) {
 			fields: fields{
 				db: mockDB{
 					MockQuery: func(ctx context.Context, q xsql.Query) (*sql.Rows, error) {
-						return mockRowsToSQLRows(
-							sqlmock.NewRows([]string{"Grants"}).
-								AddRow("GRANT CREATE, DROP ON *.* TO 'success-user'@%"),
-						), nil
+						if !strings.Contains(q.String, "USER_PRIVILEGES") {
+							return nil, errBoom
+						}
+						return privilegeRows("NO", "USAGE", "CREATE", "DROP")(ctx, q)
 					},
 				},
 			},
@@ -408,19 +544,13 @@ func TestObserve(t *testing.T) {
 					ResourceExists:   true,
 					ResourceUpToDate: true,
 				},
-				err: nil,
 			},
 		},
 		"SuccessGrantWithTables": {
 			reason: "We should see the grants in sync when using a table",
 			fields: fields{
 				db: mockDB{
-					MockQuery: func(ctx context.Context, q xsql.Query) (*sql.Rows, error) {
-						return mockRowsToSQLRows(
-							sqlmock.NewRows([]string{"Grants"}).
-								AddRow("GRANT CREATE, DROP ON `success-db`.`success-table` TO 'success-user'@%"),
-						), nil
-					},
+					MockQuery: privilegeRows("NO", "CREATE", "DROP"),
 				},
 			},
 			args: args{
@@ -440,19 +570,13 @@ func TestObserve(t *testing.T) {
 					ResourceExists:   true,
 					ResourceUpToDate: true,
 				},
-				err: nil,
 			},
 		},
 		"SuccessDiffGrantWithTables": {
 			reason: "We should see the grants out of sync when using a table",
 			fields: fields{
 				db: mockDB{
-					MockQuery: func(ctx context.Context, q xsql.Query) (*sql.Rows, error) {
-						return mockRowsToSQLRows(
-							sqlmock.NewRows([]string{"Grants"}).
-								AddRow("GRANT CREATE, DROP ON `success-db`.`success-table` TO 'success-user'@%"),
-						), nil
-					},
+					MockQuery: privilegeRows("NO", "CREATE", "DROP"),
 				},
 			},
 			args: args{
@@ -472,177 +596,1009 @@ func TestObserve(t *testing.T) {
 					ResourceExists:   true,
 					ResourceUpToDate: false,
 				},
-				err: nil,
 			},
 		},
-	}
-
-	for name, tc := range cases {
-		t.Run(name, func(t *testing.T) {
-			e := external{db: tc.fields.db}
-			got, err := e.Observe(tc.args.ctx, tc.args.mg)
-			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
-				t.Errorf("\n%s\ne.Observe(...): -want error, +got error:\n%s\n", tc.reason, diff)
-			}
-			if diff := cmp.Diff(tc.want.o, got); diff != "" {
-				t.Errorf("\n%s\ne.Observe(...): -want, +got:\n%s\n", tc.reason, diff)
-			}
-		})
-	}
-}
-
-func TestCreate(t *testing.T) {
-	errBoom := errors.New("boom")
-
-	type fields struct {
-		db xsql.DB
-	}
-
-	type args struct {
-		ctx context.Context
-		mg  resource.Managed
-	}
-
-	type want struct {
-		c   managed.ExternalCreation
-		err error
-	}
-
-	cases := map[string]struct {
-		reason string
-		fields fields
-		args   args
-		want   want
-	}{
-		"ErrNotGrant": {
-			reason: "An error should be returned if the managed resource is not a *Grant",
+		"ErrNoGrantee": {
+			reason: "An error should be returned if neither a user nor a role was passed",
 			args: args{
-				mg: nil,
+				mg: &v1alpha1.Grant{},
 			},
 			want: want{
-				err: errors.New(errNotGrant),
+				err: errors.New(errNoGrantee),
 			},
 		},
-		"ErrExec": {
-			reason: "Any errors encountered while creating the grant should be returned",
+		"SuccessNoMembership": {
+			reason: "We should return ResourceExists: false when the role was not granted",
 			fields: fields{
-				db: &mockDB{
-					MockExec: func(ctx context.Context, q xsql.Query) error { return errBoom },
+				db: mockDB{
+					MockScan: func(ctx context.Context, q xsql.Query, dest ...interface{}) error { return sql.ErrNoRows },
 				},
 			},
 			args: args{
 				mg: &v1alpha1.Grant{
 					Spec: v1alpha1.GrantSpec{
 						ForProvider: v1alpha1.GrantParameters{
-							Database: pointer.StringPtr("test-example"),
 							User:     pointer.StringPtr("test-example"),
+							MemberOf: pointer.StringPtr("test-role"),
 						},
 					},
 				},
 			},
 			want: want{
-				err: errors.Wrap(errBoom, errCreateGrant),
+				o: managed.ExternalObservation{ResourceExists: false},
 			},
 		},
-		"Success": {
-			reason: "No error should be returned when we successfully create a grant",
+		"ErrSelectMembership": {
+			reason: "We should return any errors encountered while trying to select the role membership",
 			fields: fields{
-				db: &mockDB{
-					MockExec: func(ctx context.Context, q xsql.Query) error { return nil },
+				db: mockDB{
+					MockScan: func(ctx context.Context, q xsql.Query, dest ...interface{}) error { return errBoom },
 				},
 			},
 			args: args{
 				mg: &v1alpha1.Grant{
 					Spec: v1alpha1.GrantSpec{
 						ForProvider: v1alpha1.GrantParameters{
-							Database: pointer.StringPtr("test-example"),
 							User:     pointer.StringPtr("test-example"),
+							MemberOf: pointer.StringPtr("test-role"),
 						},
 					},
 				},
 			},
 			want: want{
-				err: nil,
+				err: errors.Wrap(errBoom, errSelectMembership),
 			},
 		},
-	}
-
-	for name, tc := range cases {
-		t.Run(name, func(t *testing.T) {
-			e := external{db: tc.fields.db}
-			got, err := e.Create(tc.args.ctx, tc.args.mg)
-			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
-				t.Errorf("\n%s\ne.Create(...): -want error, +got error:\n%s\n", tc.reason, diff)
-			}
-			if diff := cmp.Diff(tc.want.c, got); diff != "" {
-				t.Errorf("\n%s\ne.Create(...): -want, +got:\n%s\n", tc.reason, diff)
-			}
-		})
-	}
-}
-
-func TestUpdate(t *testing.T) {
-	errBoom := errors.New("boom")
-
-	type fields struct {
-		db xsql.DB
-	}
-
-	type args struct {
-		ctx context.Context
-		mg  resource.Managed
-	}
-
-	type want struct {
-		c   managed.ExternalUpdate
-		err error
-	}
-
-	cases := map[string]struct {
-		reason string
-		fields fields
-		args   args
-		want   want
-	}{
-		"ErrNotGrant": {
-			reason: "An error should be returned if the managed resource is not a *Grant",
+		"SuccessMembership": {
+			reason: "We should return ResourceUpToDate: true if the role was granted with the desired admin option",
+			fields: fields{
+				db: mockDB{
+					MockScan: func(ctx context.Context, q xsql.Query, dest ...interface{}) error {
+						*dest[0].(*string) = "Y"
+						return nil
+					},
+				},
+			},
 			args: args{
-				mg: nil,
+				mg: &v1alpha1.Grant{
+					Spec: v1alpha1.GrantSpec{
+						ForProvider: v1alpha1.GrantParameters{
+							Role:       pointer.StringPtr("test-example"),
+							MemberOf:   pointer.StringPtr("test-role"),
+							WithOption: &goa,
+						},
+					},
+				},
+			},
+			want: want{
+				o: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: true,
+				},
+			},
+		},
+		"SuccessMembershipDiffAdminOption": {
+			reason: "We should return ResourceUpToDate: false if the role was granted without the desired admin option",
+			fields: fields{
+				db: mockDB{
+					MockScan: func(ctx context.Context, q xsql.Query, dest ...interface{}) error {
+						*dest[0].(*string) = "N"
+						return nil
+					},
+				},
+			},
+			args: args{
+				mg: &v1alpha1.Grant{
+					Spec: v1alpha1.GrantSpec{
+						ForProvider: v1alpha1.GrantParameters{
+							User:       pointer.StringPtr("test-example"),
+							MemberOf:   pointer.StringPtr("test-role"),
+							WithOption: &goa,
+						},
+					},
+				},
+			},
+			want: want{
+				o: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: false,
+				},
+			},
+		},
+		"SuccessWithGrantOption": {
+			reason: "We should see the grants in sync if the desired grant option was granted",
+			fields: fields{
+				db: mockDB{
+					MockQuery: privilegeRows("YES", "CREATE", "DROP"),
+				},
+			},
+			args: args{
+				mg: &v1alpha1.Grant{
+					Spec: v1alpha1.GrantSpec{
+						ForProvider: v1alpha1.GrantParameters{
+							Database:   pointer.StringPtr("success-db"),
+							User:       pointer.StringPtr("success-user"),
+							Privileges: v1alpha1.GrantPrivileges{"DROP", "CREATE"},
+							WithOption: &gog,
+						},
+					},
+				},
+			},
+			want: want{
+				o: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: true,
+				},
+			},
+		},
+		"SuccessColumns": {
+			reason: "We should only compare the column privileges of a grant with columns",
+			fields: fields{
+				db: mockDB{
+					MockQuery: func(ctx context.Context, q xsql.Query) (*sql.Rows, error) {
+						if !strings.Contains(q.String, "COLUMN_PRIVILEGES") {
+							return privilegeRows("NO", "DROP")(ctx, q)
+						}
+						rows := sqlmock.NewRows([]string{"COLUMN_NAME", "PRIVILEGE_TYPE", "IS_GRANTABLE"}).
+							AddRow("id", "SELECT", "NO").
+							AddRow("name", "SELECT", "NO")
+						return mockRowsToSQLRows(rows), nil
+					},
+				},
+			},
+			args: args{
+				mg: &v1alpha1.Grant{
+					Spec: v1alpha1.GrantSpec{
+						ForProvider: v1alpha1.GrantParameters{
+							Database:   pointer.StringPtr("success-db"),
+							Table:      pointer.StringPtr("success-table"),
+							User:       pointer.StringPtr("success-user"),
+							Privileges: v1alpha1.GrantPrivileges{"SELECT"},
+							Columns:    []string{"name", "id"},
+						},
+					},
+				},
+			},
+			want: want{
+				o: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: true,
+				},
+			},
+		},
+		"SuccessDiffColumns": {
+			reason: "We should see the grant out of sync if the column privileges differ",
+			fields: fields{
+				db: mockDB{
+					MockQuery: func(ctx context.Context, q xsql.Query) (*sql.Rows, error) {
+						if !strings.Contains(q.String, "COLUMN_PRIVILEGES") {
+							return privilegeRows("NO")(ctx, q)
+						}
+						rows := sqlmock.NewRows([]string{"COLUMN_NAME", "PRIVILEGE_TYPE", "IS_GRANTABLE"}).
+							AddRow("id", "SELECT", "NO")
+						return mockRowsToSQLRows(rows), nil
+					},
+				},
+			},
+			args: args{
+				mg: &v1alpha1.Grant{
+					Spec: v1alpha1.GrantSpec{
+						ForProvider: v1alpha1.GrantParameters{
+							Database:   pointer.StringPtr("success-db"),
+							Table:      pointer.StringPtr("success-table"),
+							User:       pointer.StringPtr("success-user"),
+							Privileges: v1alpha1.GrantPrivileges{"SELECT"},
+							Columns:    []string{"id", "name"},
+						},
+					},
+				},
+			},
+			want: want{
+				o: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: false,
+				},
+			},
+		},
+		"SuccessOtherColumns": {
+			reason: "We should ignore privileges on columns the grant is not for",
+			fields: fields{
+				db: mockDB{
+					MockQuery: func(ctx context.Context, q xsql.Query) (*sql.Rows, error) {
+						if !strings.Contains(q.String, "COLUMN_PRIVILEGES") {
+							return privilegeRows("NO")(ctx, q)
+						}
+						rows := sqlmock.NewRows([]string{"COLUMN_NAME", "PRIVILEGE_TYPE", "IS_GRANTABLE"}).
+							AddRow("id", "SELECT", "NO").
+							AddRow("email", "SELECT", "NO").
+							AddRow("email", "UPDATE", "NO")
+						return mockRowsToSQLRows(rows), nil
+					},
+				},
+			},
+			args: args{
+				mg: &v1alpha1.Grant{
+					Spec: v1alpha1.GrantSpec{
+						ForProvider: v1alpha1.GrantParameters{
+							Database:   pointer.StringPtr("success-db"),
+							Table:      pointer.StringPtr("success-table"),
+							User:       pointer.StringPtr("success-user"),
+							Privileges: v1alpha1.GrantPrivileges{"SELECT"},
+							Columns:    []string{"id"},
+						},
+					},
+				},
+			},
+			want: want{
+				o: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: true,
+				},
+			},
+		},
+		"SuccessProcedure": {
+			reason: "We should read the privileges on a procedure from mysql.procs_priv",
+			fields: fields{
+				db: mockDB{
+					MockScan: func(ctx context.Context, q xsql.Query, dest ...interface{}) error {
+						if !strings.Contains(q.String, "mysql.procs_priv") || q.Parameters[4] != "PROCEDURE" {
+							return errBoom
+						}
+						*dest[0].(*string) = "Execute"
+						return nil
+					},
+				},
+			},
+			args: args{
+				mg: &v1alpha1.Grant{
+					Spec: v1alpha1.GrantSpec{
+						ForProvider: v1alpha1.GrantParameters{
+							Database:   pointer.StringPtr("success-db"),
+							Procedure:  pointer.StringPtr("success-procedure"),
+							User:       pointer.StringPtr("success-user"),
+							Privileges: v1alpha1.GrantPrivileges{"EXECUTE"},
+						},
+					},
+				},
+			},
+			want: want{
+				o: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: true,
+				},
+			},
+		},
+		"SuccessNoFunctionGrant": {
+			reason: "We should return ResourceExists: false if nothing was granted on a function",
+			fields: fields{
+				db: mockDB{
+					MockScan: func(ctx context.Context, q xsql.Query, dest ...interface{}) error { return sql.ErrNoRows },
+				},
+			},
+			args: args{
+				mg: &v1alpha1.Grant{
+					Spec: v1alpha1.GrantSpec{
+						ForProvider: v1alpha1.GrantParameters{
+							Database:   pointer.StringPtr("success-db"),
+							Function:   pointer.StringPtr("success-function"),
+							User:       pointer.StringPtr("success-user"),
+							Privileges: v1alpha1.GrantPrivileges{"EXECUTE"},
+						},
+					},
+				},
+			},
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: false},
+			},
+		},
+		"ErrSelectProxy": {
+			reason: "We should return any errors encountered while trying to select the proxy grant",
+			fields: fields{
+				db: mockDB{
+					MockScan: func(ctx context.Context, q xsql.Query, dest ...interface{}) error { return errBoom },
+				},
+			},
+			args: args{
+				mg: &v1alpha1.Grant{
+					Spec: v1alpha1.GrantSpec{
+						ForProvider: v1alpha1.GrantParameters{
+							User:  pointer.StringPtr("test-example"),
+							Proxy: pointer.StringPtr("test-proxied"),
+						},
+					},
+				},
+			},
+			want: want{
+				err: errors.Wrap(errBoom, errSelectProxy),
+			},
+		},
+		"SuccessProxyDiffGrantOption": {
+			reason: "We should return ResourceUpToDate: false if the proxy was granted without the desired grant option",
+			fields: fields{
+				db: mockDB{
+					MockScan: func(ctx context.Context, q xsql.Query, dest ...interface{}) error {
+						if !strings.Contains(q.String, "mysql.proxies_priv") {
+							return errBoom
+						}
+						*dest[0].(*bool) = false
+						return nil
+					},
+				},
+			},
+			args: args{
+				mg: &v1alpha1.Grant{
+					Spec: v1alpha1.GrantSpec{
+						ForProvider: v1alpha1.GrantParameters{
+							User:       pointer.StringPtr("test-example"),
+							Proxy:      pointer.StringPtr("test-proxied"),
+							WithOption: &gog,
+						},
+					},
+				},
+			},
+			want: want{
+				o: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: false,
+				},
+			},
+		},
+		"ErrGetUser": {
+			reason: "We should return any errors encountered while trying to get the referenced User",
+			fields: fields{
+				kube: &test.MockClient{
+					MockGet: test.NewMockGetFn(errBoom),
+				},
+			},
+			args: args{
+				mg: &v1alpha1.Grant{
+					Spec: v1alpha1.GrantSpec{
+						ForProvider: v1alpha1.GrantParameters{
+							Database:   pointer.StringPtr("test-example"),
+							User:       pointer.StringPtr("test-example"),
+							UserRef:    &xpv1.Reference{Name: "test-example"},
+							Privileges: v1alpha1.GrantPrivileges{"SELECT"},
+						},
+					},
+				},
+			},
+			want: want{
+				err: errors.Wrap(errBoom, errGetUser),
+			},
+		},
+		"SuccessHostsMissingGrant": {
+			reason: "We should return ResourceExists: false when the grant is missing on any host of the referenced User",
+			fields: fields{
+				db: mockDB{
+					MockQuery: func(ctx context.Context, q xsql.Query) (*sql.Rows, error) {
+						if q.Parameters[0] == "'test-example'@'10.0.0.2'" {
+							return privilegeRows("NO")(ctx, q)
+						}
+						return privilegeRows("NO", "SELECT")(ctx, q)
+					},
+					MockScan: func(ctx context.Context, q xsql.Query, dest ...interface{}) error { return sql.ErrNoRows },
+				},
+				kube: userWithHosts("10.0.0.1", "10.0.0.2"),
+			},
+			args: args{
+				mg: &v1alpha1.Grant{
+					Spec: v1alpha1.GrantSpec{
+						ForProvider: v1alpha1.GrantParameters{
+							Database:   pointer.StringPtr("test-example"),
+							User:       pointer.StringPtr("test-example"),
+							UserRef:    &xpv1.Reference{Name: "test-example"},
+							Privileges: v1alpha1.GrantPrivileges{"SELECT"},
+						},
+					},
+				},
+			},
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: false},
+			},
+		},
+		"SuccessHosts": {
+			reason: "We should return ResourceUpToDate: true when the grant is up to date on all hosts of the referenced User",
+			fields: fields{
+				db: mockDB{
+					MockQuery: privilegeRows("NO", "SELECT"),
+				},
+				kube: userWithHosts("10.0.0.1", "10.0.0.2"),
+			},
+			args: args{
+				mg: &v1alpha1.Grant{
+					Spec: v1alpha1.GrantSpec{
+						ForProvider: v1alpha1.GrantParameters{
+							Database:   pointer.StringPtr("test-example"),
+							User:       pointer.StringPtr("test-example"),
+							UserRef:    &xpv1.Reference{Name: "test-example"},
+							Privileges: v1alpha1.GrantPrivileges{"SELECT"},
+						},
+					},
+				},
+			},
+			want: want{
+				o: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: true,
+				},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{db: tc.fields.db, kube: tc.fields.kube}
+			got, err := e.Observe(tc.args.ctx, tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, got); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestCreate(t *testing.T) {
+	errBoom := errors.New("boom")
+	goa := v1alpha1.GrantOptionAdmin
+	gog := v1alpha1.GrantOptionGrant
+
+	type fields struct {
+		db   xsql.DB
+		kube client.Client
+	}
+
+	type args struct {
+		ctx context.Context
+		mg  resource.Managed
+	}
+
+	type want struct {
+		c   managed.ExternalCreation
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		fields fields
+		args   args
+		want   want
+	}{
+		"ErrNotGrant": {
+			reason: "An error should be returned if the managed resource is not a *Grant",
+			args: args{
+				mg: nil,
+			},
+			want: want{
+				err: errors.New(errNotGrant),
+			},
+		},
+		"ErrExec": {
+			reason: "Any errors encountered while creating the grant should be returned",
+			fields: fields{
+				db: &mockDB{
+					MockExec: func(ctx context.Context, q xsql.Query) error { return errBoom },
+				},
+			},
+			args: args{
+				mg: &v1alpha1.Grant{
+					Spec: v1alpha1.GrantSpec{
+						ForProvider: v1alpha1.GrantParameters{
+							Database:   pointer.StringPtr("test-example"),
+							User:       pointer.StringPtr("test-example"),
+							Privileges: v1alpha1.GrantPrivileges{"ALL"},
+						},
+					},
+				},
+			},
+			want: want{
+				err: errors.Wrap(errBoom, errCreateGrant),
+			},
+		},
+		"Success": {
+			reason: "No error should be returned when we successfully create a grant",
+			fields: fields{
+				db: &mockDB{
+					MockExec: func(ctx context.Context, q xsql.Query) error { return nil },
+				},
+			},
+			args: args{
+				mg: &v1alpha1.Grant{
+					Spec: v1alpha1.GrantSpec{
+						ForProvider: v1alpha1.GrantParameters{
+							Database:   pointer.StringPtr("test-example"),
+							User:       pointer.StringPtr("test-example"),
+							Privileges: v1alpha1.GrantPrivileges{"ALL"},
+						},
+					},
+				},
+			},
+			want: want{
+				err: nil,
+			},
+		},
+		"SuccessRole": {
+			reason: "Privileges should be granted to a role",
+			fields: fields{
+				db: &mockDB{
+					MockExec: func(ctx context.Context, q xsql.Query) error {
+						if strings.HasPrefix(q.String, "FLUSH") || q.String == "GRANT SELECT ON `test-example`.* TO 'test-role'@'%'" {
+							return nil
+						}
+						return errBoom
+					},
+				},
+			},
+			args: args{
+				mg: &v1alpha1.Grant{
+					Spec: v1alpha1.GrantSpec{
+						ForProvider: v1alpha1.GrantParameters{
+							Database:   pointer.StringPtr("test-example"),
+							Role:       pointer.StringPtr("test-role"),
+							Privileges: v1alpha1.GrantPrivileges{"SELECT"},
+						},
+					},
+				},
+			},
+			want: want{
+				err: nil,
+			},
+		},
+		"SuccessMembership": {
+			reason: "The role should be granted if memberOf is set",
+			fields: fields{
+				db: &mockDB{
+					MockExec: func(ctx context.Context, q xsql.Query) error {
+						if q.String != "GRANT 'test-role'@'%' TO 'test-example'@'localhost' WITH ADMIN OPTION" {
+							return errBoom
+						}
+						return nil
+					},
+				},
+			},
+			args: args{
+				mg: &v1alpha1.Grant{
+					Spec: v1alpha1.GrantSpec{
+						ForProvider: v1alpha1.GrantParameters{
+							User:       pointer.StringPtr("test-example@localhost"),
+							MemberOf:   pointer.StringPtr("test-role"),
+							WithOption: &goa,
+						},
+					},
+				},
+			},
+			want: want{
+				err: nil,
+			},
+		},
+		"SuccessWithGrantOption": {
+			reason: "Privileges should be granted with the grant option",
+			fields: fields{
+				db: &mockDB{
+					MockExec: func(ctx context.Context, q xsql.Query) error {
+						if strings.HasPrefix(q.String, "FLUSH") || q.String == "GRANT SELECT ON `test-example`.* TO 'test-example'@'%' WITH GRANT OPTION" {
+							return nil
+						}
+						return errBoom
+					},
+				},
+			},
+			args: args{
+				mg: &v1alpha1.Grant{
+					Spec: v1alpha1.GrantSpec{
+						ForProvider: v1alpha1.GrantParameters{
+							Database:   pointer.StringPtr("test-example"),
+							User:       pointer.StringPtr("test-example"),
+							Privileges: v1alpha1.GrantPrivileges{"SELECT"},
+							WithOption: &gog,
+						},
+					},
+				},
+			},
+			want: want{
+				err: nil,
+			},
+		},
+		"SuccessColumns": {
+			reason: "Privileges should be granted on the columns of a table",
+			fields: fields{
+				db: &mockDB{
+					MockExec: func(ctx context.Context, q xsql.Query) error {
+						if strings.HasPrefix(q.String, "FLUSH") || q.String == "GRANT INSERT (`id`, `name`), SELECT (`id`, `name`) ON `test-example`.`test-table` TO 'test-example'@'%'" {
+							return nil
+						}
+						return errBoom
+					},
+				},
+			},
+			args: args{
+				mg: &v1alpha1.Grant{
+					Spec: v1alpha1.GrantSpec{
+						ForProvider: v1alpha1.GrantParameters{
+							Database:   pointer.StringPtr("test-example"),
+							Table:      pointer.StringPtr("test-table"),
+							User:       pointer.StringPtr("test-example"),
+							Privileges: v1alpha1.GrantPrivileges{"SELECT", "INSERT"},
+							Columns:    []string{"id", "name"},
+						},
+					},
+				},
+			},
+			want: want{
+				err: nil,
+			},
+		},
+		"SuccessProcedure": {
+			reason: "Privileges should be granted on a procedure",
+			fields: fields{
+				db: &mockDB{
+					MockExec: func(ctx context.Context, q xsql.Query) error {
+						if strings.HasPrefix(q.String, "FLUSH") || q.String == "GRANT EXECUTE ON PROCEDURE `test-example`.`test-procedure` TO 'test-example'@'%'" {
+							return nil
+						}
+						return errBoom
+					},
+				},
+			},
+			args: args{
+				mg: &v1alpha1.Grant{
+					Spec: v1alpha1.GrantSpec{
+						ForProvider: v1alpha1.GrantParameters{
+							Database:   pointer.StringPtr("test-example"),
+							Procedure:  pointer.StringPtr("test-procedure"),
+							User:       pointer.StringPtr("test-example"),
+							Privileges: v1alpha1.GrantPrivileges{"EXECUTE"},
+						},
+					},
+				},
+			},
+			want: want{
+				err: nil,
+			},
+		},
+		"SuccessFunction": {
+			reason: "Privileges should be granted on a function",
+			fields: fields{
+				db: &mockDB{
+					MockExec: func(ctx context.Context, q xsql.Query) error {
+						if strings.HasPrefix(q.String, "FLUSH") || q.String == "GRANT EXECUTE ON FUNCTION `test-example`.`test-function` TO 'test-example'@'%'" {
+							return nil
+						}
+						return errBoom
+					},
+				},
+			},
+			args: args{
+				mg: &v1alpha1.Grant{
+					Spec: v1alpha1.GrantSpec{
+						ForProvider: v1alpha1.GrantParameters{
+							Database:   pointer.StringPtr("test-example"),
+							Function:   pointer.StringPtr("test-function"),
+							User:       pointer.StringPtr("test-example"),
+							Privileges: v1alpha1.GrantPrivileges{"EXECUTE"},
+						},
+					},
+				},
+			},
+			want: want{
+				err: nil,
+			},
+		},
+		"SuccessProxy": {
+			reason: "The proxy should be granted if proxy is set",
+			fields: fields{
+				db: &mockDB{
+					MockExec: func(ctx context.Context, q xsql.Query) error {
+						if strings.HasPrefix(q.String, "FLUSH") || q.String == "GRANT PROXY ON 'test-proxied'@'%' TO 'test-example'@'%' WITH GRANT OPTION" {
+							return nil
+						}
+						return errBoom
+					},
+				},
+			},
+			args: args{
+				mg: &v1alpha1.Grant{
+					Spec: v1alpha1.GrantSpec{
+						ForProvider: v1alpha1.GrantParameters{
+							User:       pointer.StringPtr("test-example"),
+							Proxy:      pointer.StringPtr("test-proxied"),
+							WithOption: &gog,
+						},
+					},
+				},
+			},
+			want: want{
+				err: nil,
+			},
+		},
+		"SuccessHosts": {
+			reason: "The grant should be made for each host of the referenced User",
+			fields: fields{
+				db: &mockDB{
+					MockExec: func(ctx context.Context, q xsql.Query) error {
+						if q.String != "GRANT SELECT ON `test-example`.* TO 'test-example'@'10.0.0.1', 'test-example'@'10.0.0.2'" && q.String != "FLUSH PRIVILEGES" {
+							return errBoom
+						}
+						return nil
+					},
+				},
+				kube: userWithHosts("10.0.0.1", "10.0.0.2"),
+			},
+			args: args{
+				mg: &v1alpha1.Grant{
+					Spec: v1alpha1.GrantSpec{
+						ForProvider: v1alpha1.GrantParameters{
+							Database:   pointer.StringPtr("test-example"),
+							User:       pointer.StringPtr("test-example"),
+							UserRef:    &xpv1.Reference{Name: "test-example"},
+							Privileges: v1alpha1.GrantPrivileges{"SELECT"},
+						},
+					},
+				},
+			},
+			want: want{
+				err: nil,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{db: tc.fields.db, kube: tc.fields.kube}
+			got, err := e.Create(tc.args.ctx, tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Create(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.c, got); diff != "" {
+				t.Errorf("\n%s\ne.Create(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	errBoom := errors.New("boom")
+	gog := v1alpha1.GrantOptionGrant
+
+	type fields struct {
+		db   xsql.DB
+		kube client.Client
+	}
+
+	type args struct {
+		ctx context.Context
+		mg  resource.Managed
+	}
+
+	type want struct {
+		c   managed.ExternalUpdate
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		fields fields
+		args   args
+		want   want
+	}{
+		"ErrNotGrant": {
+			reason: "An error should be returned if the managed resource is not a *Grant",
+			args: args{
+				mg: nil,
 			},
 			want: want{
 				err: errors.New(errNotGrant),
 			},
 		},
-		"ErrExec": {
-			reason: "Any errors encountered while updating the grant should be returned",
+		"ErrExec": {
+			reason: "Any errors encountered while updating the grant should be returned",
+			fields: fields{
+				db: &mockDB{
+					MockExec: func(ctx context.Context, q xsql.Query) error { return errBoom },
+				},
+			},
+			args: args{
+				mg: &v1alpha1.Grant{
+					Spec: v1alpha1.GrantSpec{
+						ForProvider: v1alpha1.GrantParameters{
+							Database:   pointer.StringPtr("test-example"),
+							User:       pointer.StringPtr("test-example"),
+							Privileges: v1alpha1.GrantPrivileges{"SELECT"},
+						},
+					},
+				},
+			},
+			want: want{
+				err: errors.Wrap(errBoom, errCreateGrant),
+			},
+		},
+		"ErrRevoke": {
+			reason: "Any errors encountered while revoking privileges should be returned",
+			fields: fields{
+				db: &mockDB{
+					MockExec: func(ctx context.Context, q xsql.Query) error {
+						if strings.HasPrefix(q.String, "REVOKE") {
+							return errBoom
+						}
+						return nil
+					},
+				},
+			},
+			args: args{
+				mg: &v1alpha1.Grant{
+					Spec: v1alpha1.GrantSpec{
+						ForProvider: v1alpha1.GrantParameters{
+							Database:   pointer.StringPtr("test-example"),
+							User:       pointer.StringPtr("test-example"),
+							Privileges: v1alpha1.GrantPrivileges{"SELECT"},
+						},
+					},
+					Status: v1alpha1.GrantStatus{
+						AtProvider: v1alpha1.GrantObservation{
+							Privileges: []string{"DROP"},
+						},
+					},
+				},
+			},
+			want: want{
+				err: errors.Wrap(errBoom, errRevokeGrant),
+			},
+		},
+		"Success": {
+			reason: "No error should be returned when we update a grant",
 			fields: fields{
 				db: &mockDB{
-					MockExec: func(ctx context.Context, q xsql.Query) error { return errBoom },
+					MockExec: func() func(ctx context.Context, q xsql.Query) error {
+						// Privileges must be granted before any are revoked.
+						queries := []string{
+							"GRANT DROP ON `test-example`.* TO 'test-example'@'%'",
+							"REVOKE SELECT ON `test-example`.* FROM 'test-example'@'%'",
+							"FLUSH PRIVILEGES",
+						}
+						return func(ctx context.Context, q xsql.Query) error {
+							if len(queries) == 0 || q.String != queries[0] {
+								return errBoom
+							}
+							queries = queries[1:]
+							return nil
+						}
+					}(),
 				},
 			},
 			args: args{
 				mg: &v1alpha1.Grant{
 					Spec: v1alpha1.GrantSpec{
 						ForProvider: v1alpha1.GrantParameters{
-							Database: pointer.StringPtr("test-example"),
-							User:     pointer.StringPtr("test-example"),
+							Database:   pointer.StringPtr("test-example"),
+							User:       pointer.StringPtr("test-example"),
+							Privileges: v1alpha1.GrantPrivileges{"CREATE", "DROP"},
+						},
+					},
+					Status: v1alpha1.GrantStatus{
+						AtProvider: v1alpha1.GrantObservation{
+							Privileges: []string{"CREATE", "SELECT"},
 						},
 					},
 				},
 			},
 			want: want{
-				err: errors.Wrap(errBoom, errRevokeGrant),
+				err: nil,
+				c:   managed.ExternalUpdate{},
 			},
 		},
-		"Success": {
-			reason: "No error should be returned when we update a grant",
+		"SuccessFromAllPrivileges": {
+			reason: "Only the privileges that are no longer desired should be revoked from ALL PRIVILEGES",
+			fields: fields{
+				db: &mockDB{
+					MockExec: func(ctx context.Context, q xsql.Query) error {
+						switch q.String {
+						case "REVOKE INSERT, UPDATE, DELETE, CREATE, DROP, REFERENCES, INDEX, ALTER, CREATE VIEW, SHOW VIEW, TRIGGER ON `test-example`.`test-table` FROM 'test-example'@'%'",
+							"FLUSH PRIVILEGES":
+							return nil
+						}
+						return errBoom
+					},
+				},
+			},
+			args: args{
+				mg: &v1alpha1.Grant{
+					Spec: v1alpha1.GrantSpec{
+						ForProvider: v1alpha1.GrantParameters{
+							Database:   pointer.StringPtr("test-example"),
+							Table:      pointer.StringPtr("test-table"),
+							User:       pointer.StringPtr("test-example"),
+							Privileges: v1alpha1.GrantPrivileges{"SELECT"},
+						},
+					},
+					Status: v1alpha1.GrantStatus{
+						AtProvider: v1alpha1.GrantObservation{
+							Privileges: []string{"ALL PRIVILEGES"},
+						},
+					},
+				},
+			},
+			want: want{
+				err: nil,
+			},
+		},
+		"SuccessMembershipRevokeAdminOption": {
+			reason: "The role should be granted again to revoke the admin option",
 			fields: fields{
 				db: &mockDB{
 					MockExec: func(ctx context.Context, q xsql.Query) error {
-						if strings.HasPrefix(q.String, "REVOKE") || strings.HasPrefix(q.String, "FLUSH") {
+						switch q.String {
+						case "REVOKE 'test-role'@'%' FROM 'test-example'@'%'", "GRANT 'test-role'@'%' TO 'test-example'@'%'":
+							return nil
+						}
+						return errBoom
+					},
+				},
+			},
+			args: args{
+				mg: &v1alpha1.Grant{
+					Spec: v1alpha1.GrantSpec{
+						ForProvider: v1alpha1.GrantParameters{
+							User:     pointer.StringPtr("test-example"),
+							MemberOf: pointer.StringPtr("test-role"),
+						},
+					},
+				},
+			},
+			want: want{
+				err: nil,
+			},
+		},
+		"SuccessColumns": {
+			reason: "Only the changed column privileges on the columns of the grant should be granted and revoked",
+			fields: fields{
+				db: &mockDB{
+					MockExec: func() func(ctx context.Context, q xsql.Query) error {
+						queries := []string{
+							"GRANT SELECT (`name`) ON `test-example`.`test-table` TO 'test-example'@'%'",
+							"REVOKE INSERT (`id`) ON `test-example`.`test-table` FROM 'test-example'@'%'",
+							"FLUSH PRIVILEGES",
+						}
+						return func(ctx context.Context, q xsql.Query) error {
+							if len(queries) == 0 || q.String != queries[0] {
+								return errBoom
+							}
+							queries = queries[1:]
 							return nil
 						}
-						if strings.Contains(q.String, "CREATE, DROP") {
+					}(),
+				},
+			},
+			args: args{
+				mg: &v1alpha1.Grant{
+					Spec: v1alpha1.GrantSpec{
+						ForProvider: v1alpha1.GrantParameters{
+							Database:   pointer.StringPtr("test-example"),
+							Table:      pointer.StringPtr("test-table"),
+							User:       pointer.StringPtr("test-example"),
+							Privileges: v1alpha1.GrantPrivileges{"SELECT"},
+							Columns:    []string{"id", "name"},
+						},
+					},
+					Status: v1alpha1.GrantStatus{
+						AtProvider: v1alpha1.GrantObservation{
+							Privileges:       []string{"DROP"},
+							ColumnPrivileges: []string{"INSERT (id)", "SELECT (email)", "SELECT (id)"},
+						},
+					},
+				},
+			},
+			want: want{
+				err: nil,
+			},
+		},
+		"SuccessGrantOption": {
+			reason: "The grant option should be granted like any other privilege",
+			fields: fields{
+				db: &mockDB{
+					MockExec: func(ctx context.Context, q xsql.Query) error {
+						switch q.String {
+						case "GRANT GRANT OPTION ON PROCEDURE `test-example`.`test-procedure` TO 'test-example'@'%'", "FLUSH PRIVILEGES":
 							return nil
 						}
 						return errBoom
@@ -654,15 +1610,83 @@ func TestUpdate(t *testing.T) {
 					Spec: v1alpha1.GrantSpec{
 						ForProvider: v1alpha1.GrantParameters{
 							Database:   pointer.StringPtr("test-example"),
+							Procedure:  pointer.StringPtr("test-procedure"),
 							User:       pointer.StringPtr("test-example"),
-							Privileges: v1alpha1.GrantPrivileges{"CREATE", "DROP"},
+							Privileges: v1alpha1.GrantPrivileges{"EXECUTE"},
+							WithOption: &gog,
+						},
+					},
+					Status: v1alpha1.GrantStatus{
+						AtProvider: v1alpha1.GrantObservation{
+							Privileges: []string{"EXECUTE"},
+						},
+					},
+				},
+			},
+			want: want{
+				err: nil,
+			},
+		},
+		"SuccessProxyGrantOption": {
+			reason: "The proxy should be granted again to add the grant option",
+			fields: fields{
+				db: &mockDB{
+					MockExec: func(ctx context.Context, q xsql.Query) error {
+						if q.String != "GRANT PROXY ON 'test-proxied'@'%' TO 'test-example'@'%' WITH GRANT OPTION" {
+							return errBoom
+						}
+						return nil
+					},
+				},
+			},
+			args: args{
+				mg: &v1alpha1.Grant{
+					Spec: v1alpha1.GrantSpec{
+						ForProvider: v1alpha1.GrantParameters{
+							User:       pointer.StringPtr("test-example"),
+							Proxy:      pointer.StringPtr("test-proxied"),
+							WithOption: &gog,
+						},
+					},
+				},
+			},
+			want: want{
+				err: nil,
+			},
+		},
+		"SuccessHosts": {
+			reason: "Privileges should be granted on each host of the referenced User that lacks them",
+			fields: fields{
+				db: &mockDB{
+					MockQuery: privilegeRows("NO", "SELECT", "INSERT"),
+					MockExec: func(ctx context.Context, q xsql.Query) error {
+						if q.String != "GRANT INSERT ON `test-example`.* TO 'test-example'@'10.0.0.1'" && q.String != "FLUSH PRIVILEGES" {
+							return errBoom
+						}
+						return nil
+					},
+				},
+				kube: userWithHosts("10.0.0.1", "10.0.0.2"),
+			},
+			args: args{
+				mg: &v1alpha1.Grant{
+					Spec: v1alpha1.GrantSpec{
+						ForProvider: v1alpha1.GrantParameters{
+							Database:   pointer.StringPtr("test-example"),
+							User:       pointer.StringPtr("test-example"),
+							UserRef:    &xpv1.Reference{Name: "test-example"},
+							Privileges: v1alpha1.GrantPrivileges{"SELECT", "INSERT"},
+						},
+					},
+					Status: v1alpha1.GrantStatus{
+						AtProvider: v1alpha1.GrantObservation{
+							Privileges: []string{"SELECT"},
 						},
 					},
 				},
 			},
 			want: want{
 				err: nil,
-				c:   managed.ExternalUpdate{},
 			},
 		},
 	}
@@ -670,7 +1694,8 @@ func TestUpdate(t *testing.T) {
 	for name, tc := range cases {
 		t.Run(name, func(t *testing.T) {
 			e := external{
-				db: tc.fields.db,
+				db:   tc.fields.db,
+				kube: tc.fields.kube,
 			}
 			got, err := e.Update(tc.args.ctx, tc.args.mg)
 			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
@@ -685,9 +1710,11 @@ func TestUpdate(t *testing.T) {
 
 func TestDelete(t *testing.T) {
 	errBoom := errors.New("boom")
+	gog := v1alpha1.GrantOptionGrant
 
 	type fields struct {
-		db xsql.DB
+		db   xsql.DB
+		kube client.Client
 	}
 
 	type args struct {
@@ -769,11 +1796,140 @@ func TestDelete(t *testing.T) {
 			},
 			want: nil,
 		},
+		"SuccessMembership": {
+			reason: "No error should be returned if the role was revoked",
+			args: args{
+				mg: &v1alpha1.Grant{
+					Spec: v1alpha1.GrantSpec{
+						ForProvider: v1alpha1.GrantParameters{
+							Role:     pointer.StringPtr("test-example"),
+							MemberOf: pointer.StringPtr("test-role"),
+						},
+					},
+				},
+			},
+			fields: fields{
+				db: &mockDB{
+					MockExec: func(ctx context.Context, q xsql.Query) error {
+						if strings.HasPrefix(q.String, "FLUSH") || q.String == "REVOKE 'test-role'@'%' FROM 'test-example'@'%'" {
+							return nil
+						}
+						return errBoom
+					},
+				},
+			},
+			want: nil,
+		},
+		"SuccessColumnsWithGrantOption": {
+			reason: "The column privileges and the grant option should be revoked",
+			args: args{
+				mg: &v1alpha1.Grant{
+					Spec: v1alpha1.GrantSpec{
+						ForProvider: v1alpha1.GrantParameters{
+							Database:   pointer.StringPtr("test-example"),
+							Table:      pointer.StringPtr("test-table"),
+							User:       pointer.StringPtr("test-example"),
+							Privileges: v1alpha1.GrantPrivileges{"SELECT"},
+							Columns:    []string{"id"},
+							WithOption: &gog,
+						},
+					},
+				},
+			},
+			fields: fields{
+				db: &mockDB{
+					MockExec: func(ctx context.Context, q xsql.Query) error {
+						if strings.HasPrefix(q.String, "FLUSH") || q.String == "REVOKE GRANT OPTION, SELECT (`id`) ON `test-example`.`test-table` FROM 'test-example'@'%'" {
+							return nil
+						}
+						return errBoom
+					},
+				},
+			},
+			want: nil,
+		},
+		"SuccessProcedureGone": {
+			reason: "No error should be returned if the procedure was dropped along with its grants",
+			args: args{
+				mg: &v1alpha1.Grant{
+					Spec: v1alpha1.GrantSpec{
+						ForProvider: v1alpha1.GrantParameters{
+							Database:   pointer.StringPtr("test-example"),
+							Procedure:  pointer.StringPtr("test-procedure"),
+							User:       pointer.StringPtr("test-example"),
+							Privileges: v1alpha1.GrantPrivileges{"EXECUTE"},
+						},
+					},
+				},
+			},
+			fields: fields{
+				db: &mockDB{
+					MockExec: func(ctx context.Context, q xsql.Query) error {
+						if strings.HasPrefix(q.String, "FLUSH") || q.String == "-" {
+							return nil
+						}
+						return &mysql.MySQLError{Number: errCodeNoSuchProcGrant}
+					},
+				},
+			},
+			want: nil,
+		},
+		"SuccessProxy": {
+			reason: "No error should be returned if the proxy was revoked",
+			args: args{
+				mg: &v1alpha1.Grant{
+					Spec: v1alpha1.GrantSpec{
+						ForProvider: v1alpha1.GrantParameters{
+							User:  pointer.StringPtr("test-example"),
+							Proxy: pointer.StringPtr("test-proxied"),
+						},
+					},
+				},
+			},
+			fields: fields{
+				db: &mockDB{
+					MockExec: func(ctx context.Context, q xsql.Query) error {
+						if strings.HasPrefix(q.String, "FLUSH") || q.String == "REVOKE PROXY ON 'test-proxied'@'%' FROM 'test-example'@'%'" {
+							return nil
+						}
+						return errBoom
+					},
+				},
+			},
+			want: nil,
+		},
+		"SuccessHosts": {
+			reason: "The grant should be revoked on each host of the referenced User",
+			fields: fields{
+				db: &mockDB{
+					MockExec: func(ctx context.Context, q xsql.Query) error {
+						if q.String != "REVOKE SELECT ON `test-example`.* FROM 'test-example'@'10.0.0.1', 'test-example'@'10.0.0.2'" && q.String != "FLUSH PRIVILEGES" {
+							return errBoom
+						}
+						return nil
+					},
+				},
+				kube: userWithHosts("10.0.0.1", "10.0.0.2"),
+			},
+			args: args{
+				mg: &v1alpha1.Grant{
+					Spec: v1alpha1.GrantSpec{
+						ForProvider: v1alpha1.GrantParameters{
+							Database:   pointer.StringPtr("test-example"),
+							User:       pointer.StringPtr("test-example"),
+							UserRef:    &xpv1.Reference{Name: "test-example"},
+							Privileges: v1alpha1.GrantPrivileges{"SELECT"},
+						},
+					},
+				},
+			},
+			want: nil,
+		},
 	}
 
 	for name, tc := range cases {
 		t.Run(name, func(t *testing.T) {
-			e := external{db: tc.fields.db}
+			e := external{db: tc.fields.db, kube: tc.fields.kube}
 			err := e.Delete(tc.args.ctx, tc.args.mg)
 			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
 				t.Errorf("\n%s\ne.Delete(...): -want error, +got error:\n%s\n", tc.reason, diff)
@@ -782,6 +1938,31 @@ func TestDelete(t *testing.T) {
 	}
 }
 
+// privilegeRows returns the supplied privileges as rows of an
+// information_schema privilege table, and no column privileges.
+// userWithHosts returns a client that gets a User with the supplied hosts.
+func userWithHosts(hosts ...string) client.Client {
+	return &test.MockClient{
+		MockGet: func(_ context.Context, _ client.ObjectKey, obj client.Object) error {
+			obj.(*v1alpha1.User).Spec.ForProvider.Hosts = hosts
+			return nil
+		},
+	}
+}
+
+func privilegeRows(grantable string, privileges ...string) func(ctx context.Context, q xsql.Query) (*sql.Rows, error) {
+	return func(ctx context.Context, q xsql.Query) (*sql.Rows, error) {
+		if strings.Contains(q.String, "COLUMN_PRIVILEGES") {
+			return mockRowsToSQLRows(sqlmock.NewRows([]string{"COLUMN_NAME", "PRIVILEGE_TYPE", "IS_GRANTABLE"})), nil
+		}
+		rows := sqlmock.NewRows([]string{"PRIVILEGE_TYPE", "IS_GRANTABLE"})
+		for _, p := range privileges {
+			rows.AddRow(p, grantable)
+		}
+		return mockRowsToSQLRows(rows), nil
+	}
+}
+
 func mockRowsToSQLRows(mockRows *sqlmock.Rows) *sql.Rows {
 	db, mock, _ := sqlmock.New()
 	mock.ExpectQuery("select").WillReturnRows(mockRows)
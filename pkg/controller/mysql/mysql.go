@@ -23,7 +23,9 @@ import (
 
 	"github.com/crossplane-contrib/provider-sql/pkg/controller/mysql/config"
 	"github.com/crossplane-contrib/provider-sql/pkg/controller/mysql/database"
+	"github.com/crossplane-contrib/provider-sql/pkg/controller/mysql/globalvariable"
 	"github.com/crossplane-contrib/provider-sql/pkg/controller/mysql/grant"
+	"github.com/crossplane-contrib/provider-sql/pkg/controller/mysql/role"
 	"github.com/crossplane-contrib/provider-sql/pkg/controller/mysql/user"
 )
 
@@ -35,6 +37,8 @@ func Setup(mgr ctrl.Manager, l logging.Logger) error {
 		database.Setup,
 		user.Setup,
 		grant.Setup,
+		role.Setup,
+		globalvariable.Setup,
 	} {
 		if err := setup(mgr, l); err != nil {
 			return err
@@ -18,13 +18,19 @@ package user
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"sort"
+	"strconv"
 	"strings"
 	"time"
 
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/pointer"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
@@ -38,6 +44,7 @@ import (
 	"github.com/crossplane/crossplane-runtime/pkg/resource"
 
 	"github.com/crossplane-contrib/provider-sql/apis/mysql/v1alpha1"
+	"github.com/crossplane-contrib/provider-sql/pkg/clients"
 	"github.com/crossplane-contrib/provider-sql/pkg/clients/mysql"
 	"github.com/crossplane-contrib/provider-sql/pkg/clients/xsql"
 )
@@ -50,16 +57,30 @@ const (
 
 	errNotUser                 = "managed resource is not a User custom resource"
 	errSelectUser              = "cannot select user"
+	errSelectPasswordOptions   = "cannot select password options"
+	errSelectDefaultRoles      = "cannot select default roles"
+	errSelectAttributes        = "cannot select user attributes"
 	errCreateUser              = "cannot create user"
 	errDropUser                = "cannot drop user"
 	errUpdateUser              = "cannot update user"
 	errFlushPriv               = "cannot flush privileges"
 	errGetPasswordSecretFailed = "cannot get password secret"
 	errCompareResourceOptions  = "cannot compare desired and observed resource options"
+	errMarshalAttributes       = "cannot marshal user attributes"
+	errPasswordUnsupported     = "password options and account locking are not supported by the server"
+	errLoginUnsupported        = "failed login tracking is not supported by the server"
 
 	maxConcurrency = 5
 )
 
+// passwordPlugins are the authentication plugins that authenticate a user with
+// the password of the User.
+var passwordPlugins = map[string]bool{
+	"caching_sha2_password": true,
+	"mysql_native_password": true,
+	"sha256_password":       true,
+}
+
 // Setup adds a controller that reconciles User managed resources.
 func Setup(mgr ctrl.Manager, l logging.Logger) error {
 	name := managed.ControllerName(v1alpha1.UserGroupKind)
@@ -155,6 +176,67 @@ func resourceOptionsToClauses(r *v1alpha1.ResourceOptions) []string {
 	return ro
 }
 
+func passwordOptionsToClauses(p *v1alpha1.PasswordOptions) []string {
+	// The string values of the password options are validated by the CRD
+	// schema to be either a number or one of the keywords below.
+	po := []string{}
+
+	if p == nil {
+		return po
+	}
+
+	if p.Expire != nil {
+		switch *p.Expire {
+		case "DEFAULT", "NEVER":
+			po = append(po, fmt.Sprintf("PASSWORD EXPIRE %s", *p.Expire))
+		default:
+			po = append(po, fmt.Sprintf("PASSWORD EXPIRE INTERVAL %s DAY", *p.Expire))
+		}
+	}
+	if p.History != nil {
+		po = append(po, fmt.Sprintf("PASSWORD HISTORY %s", *p.History))
+	}
+	if p.ReuseInterval != nil {
+		switch *p.ReuseInterval {
+		case "DEFAULT":
+			po = append(po, "PASSWORD REUSE INTERVAL DEFAULT")
+		default:
+			po = append(po, fmt.Sprintf("PASSWORD REUSE INTERVAL %s DAY", *p.ReuseInterval))
+		}
+	}
+	handleClause("FAILED_LOGIN_ATTEMPTS", p.FailedLoginAttempts, &po)
+	if p.PasswordLockTime != nil {
+		po = append(po, fmt.Sprintf("PASSWORD_LOCK_TIME %s", *p.PasswordLockTime))
+	}
+
+	return po
+}
+
+func lockToClause(locked bool) string {
+	if locked {
+		return "ACCOUNT LOCK"
+	}
+	return "ACCOUNT UNLOCK"
+}
+
+// changedClauses returns the desired clauses that were not observed. Unlike
+// changedResourceOptions it does not rely on the position of the clauses, as
+// the desired password options are usually a subset of the observed ones.
+func changedClauses(observed []string, desired []string) []string {
+	o := make(map[string]bool, len(observed))
+	for _, v := range observed {
+		o[v] = true
+	}
+
+	out := []string{}
+	for _, v := range desired {
+		if !o[v] {
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
 func changedResourceOptions(existing []string, desired []string) ([]string, error) {
 	out := []string{}
 
@@ -178,23 +260,395 @@ func changedResourceOptions(existing []string, desired []string) ([]string, erro
 	return out, nil
 }
 
+// usesPassword returns true if the user authenticates with a password.
+func usesPassword(p v1alpha1.UserParameters) bool {
+	return p.AuthPlugin == nil || passwordPlugins[*p.AuthPlugin]
+}
+
+func authToClause(p v1alpha1.UserParameters, pw string) string {
+	switch {
+	case p.AuthPlugin == nil:
+		return fmt.Sprintf("IDENTIFIED BY %s", mysql.QuoteValue(pw))
+	case passwordPlugins[*p.AuthPlugin]:
+		return fmt.Sprintf("IDENTIFIED WITH %s BY %s", mysql.QuoteValue(*p.AuthPlugin), mysql.QuoteValue(pw))
+	case p.AuthString != nil:
+		return fmt.Sprintf("IDENTIFIED WITH %s AS %s", mysql.QuoteValue(*p.AuthPlugin), mysql.QuoteValue(*p.AuthString))
+	}
+	return fmt.Sprintf("IDENTIFIED WITH %s", mysql.QuoteValue(*p.AuthPlugin))
+}
+
+func certificateToClause(issuer, subject string) string {
+	return fmt.Sprintf("REQUIRE %s", strings.Join(certificateRequirements(issuer, subject), " AND "))
+}
+
+// certificateRequirements returns the ISSUER and SUBJECT options of a REQUIRE
+// clause for the non-empty issuer and subject.
+func certificateRequirements(issuer, subject string) []string {
+	r := []string{}
+	if issuer != "" {
+		r = append(r, fmt.Sprintf("ISSUER %s", mysql.QuoteValue(issuer)))
+	}
+	if subject != "" {
+		r = append(r, fmt.Sprintf("SUBJECT %s", mysql.QuoteValue(subject)))
+	}
+	return r
+}
+
+func requireToClause(r *v1alpha1.TLSRequirement) string {
+	// An empty clause means the TLS requirements are not managed.
+	if r == nil {
+		return ""
+	}
+
+	issuer, subject := clients.ToString(r.Issuer), clients.ToString(r.Subject)
+	if issuer != "" || subject != "" {
+		return certificateToClause(issuer, subject)
+	}
+	if r.Type == nil {
+		return ""
+	}
+	return fmt.Sprintf("REQUIRE %s", *r.Type)
+}
+
+// observedRequireClause returns the REQUIRE clause matching the ssl_type,
+// x509_issuer, x509_subject and ssl_cipher columns of mysql.user. A User can't
+// require a cipher, so an account that does never matches the desired clause.
+func observedRequireClause(sslType, issuer, subject, cipher string) string {
+	switch sslType {
+	case "ANY":
+		return "REQUIRE SSL"
+	case "X509":
+		return "REQUIRE X509"
+	case "SPECIFIED":
+		r := certificateRequirements(issuer, subject)
+		if cipher != "" {
+			r = append(r, fmt.Sprintf("CIPHER %s", mysql.QuoteValue(cipher)))
+		}
+		if len(r) == 0 {
+			// Any of the above implies an encrypted connection.
+			return "REQUIRE SSL"
+		}
+		return fmt.Sprintf("REQUIRE %s", strings.Join(r, " AND "))
+	}
+	return "REQUIRE NONE"
+}
+
+// keywordOrNumber returns the keyword if v is nil, and v otherwise.
+func keywordOrNumber(v *int, keyword string) *string {
+	if v == nil {
+		return &keyword
+	}
+	s := strconv.Itoa(*v)
+	return &s
+}
+
+// observePasswordOptions returns the password options and lock state of a
+// user. Servers that predate these options report none, or return an error if
+// the desired parameters manage them, so that they don't appear to drift.
+func (c *external) observePasswordOptions(ctx context.Context, username, host string, desired v1alpha1.UserParameters) ([]string, bool, error) {
+	var lifetime, history, reuse, attempts, lockTime *int
+	var locked string
+
+	query := "SELECT " +
+		"password_lifetime, " +
+		"password_reuse_history, " +
+		"password_reuse_time, " +
+		"account_locked " +
+		"FROM mysql.user WHERE User = ? AND Host = ?"
+	err := c.db.Scan(ctx, xsql.Query{String: query, Parameters: []interface{}{username, host}},
+		&lifetime, &history, &reuse, &locked)
+	if mysql.IsUnsupported(err) {
+		if desired.PasswordOptions != nil || desired.AccountLocked != nil {
+			return nil, false, errors.New(errPasswordUnsupported)
+		}
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+
+	// Failed-login tracking was added in MySQL 8.0.19 and is stored in the
+	// User_attributes column.
+	query = "SELECT " +
+		"JSON_EXTRACT(User_attributes, '$.Password_locking.failed_login_attempts'), " +
+		"JSON_EXTRACT(User_attributes, '$.Password_locking.password_lock_time_days') " +
+		"FROM mysql.user WHERE User = ? AND Host = ?"
+	err = c.db.Scan(ctx, xsql.Query{String: query, Parameters: []interface{}{username, host}},
+		&attempts, &lockTime)
+	loginTracking := !mysql.IsUnsupported(err)
+	if err != nil && loginTracking {
+		return nil, false, err
+	}
+	if dp := desired.PasswordOptions; !loginTracking && dp != nil && (dp.FailedLoginAttempts != nil || dp.PasswordLockTime != nil) {
+		return nil, false, errors.New(errLoginUnsupported)
+	}
+
+	observed := &v1alpha1.PasswordOptions{
+		Expire:              keywordOrNumber(lifetime, "DEFAULT"),
+		History:             keywordOrNumber(history, "DEFAULT"),
+		ReuseInterval:       keywordOrNumber(reuse, "DEFAULT"),
+		FailedLoginAttempts: attempts,
+		PasswordLockTime:    keywordOrNumber(lockTime, "0"),
+	}
+	if lifetime != nil && *lifetime == 0 {
+		observed.Expire = pointer.String("NEVER")
+	}
+	if attempts == nil {
+		observed.FailedLoginAttempts = new(int)
+	}
+	if lockTime != nil && *lockTime < 0 {
+		observed.PasswordLockTime = pointer.String("UNBOUNDED")
+	}
+	if !loginTracking {
+		observed.FailedLoginAttempts = nil
+		observed.PasswordLockTime = nil
+	}
+
+	return passwordOptionsToClauses(observed), locked == "Y", nil
+}
+
+// observeAccountOptions returns the password options and lock state of the
+// first account of the user on the supplied hosts whose options differ from
+// the desired ones, or of the last account if none do. Failed logins may lock
+// the account on a single host.
+func (c *external) observeAccountOptions(ctx context.Context, username string, hosts []string, desired v1alpha1.UserParameters) ([]string, bool, error) {
+	var po []string
+	var locked bool
+	for _, h := range hosts {
+		var err error
+		po, locked, err = c.observePasswordOptions(ctx, username, h, desired)
+		if err != nil {
+			return nil, false, err
+		}
+		if !passwordOptionsUpToDate(po, locked, desired) {
+			break
+		}
+	}
+	return po, locked, nil
+}
+
+// defaultRoles returns the desired default roles as reported by
+// mysql.default_roles.
+func defaultRoles(roles []string) []string {
+	out := make([]string, len(roles))
+	for i, r := range roles {
+		name, host := mysql.SplitUserHost(r)
+		out[i] = name + "@" + host
+	}
+	sort.Strings(out)
+	return out
+}
+
+func defaultRolesToClause(roles []string) string {
+	out := make([]string, len(roles))
+	for i, r := range roles {
+		name, host := mysql.SplitUserHost(r)
+		out[i] = fmt.Sprintf("%s@%s", mysql.QuoteValue(name), mysql.QuoteValue(host))
+	}
+	return strings.Join(out, ", ")
+}
+
+// observeDefaultRoles returns the default roles of a user. Servers that
+// predate roles report none.
+func (c *external) observeDefaultRoles(ctx context.Context, username, host string) ([]string, error) {
+	var roles *string
+	query := "SELECT GROUP_CONCAT(CONCAT(DEFAULT_ROLE_USER, '@', DEFAULT_ROLE_HOST) " +
+		"ORDER BY DEFAULT_ROLE_USER, DEFAULT_ROLE_HOST SEPARATOR '\\n') " +
+		"FROM mysql.default_roles WHERE USER = ? AND HOST = ?"
+	err := c.db.Scan(ctx, xsql.Query{String: query, Parameters: []interface{}{username, host}}, &roles)
+	if mysql.IsUnsupported(err) {
+		return nil, nil
+	}
+	if err != nil || roles == nil {
+		return nil, err
+	}
+	return defaultRoles(strings.Split(*roles, "\n")), nil
+}
+
+// desiredAttributes returns the desired attributes of the account, including
+// its comment, or nil if they are not managed.
+func desiredAttributes(cr *v1alpha1.User) map[string]string {
+	p := cr.Spec.ForProvider
+	if p.Comment == nil && p.Attributes == nil && p.AttributesFrom == nil {
+		return nil
+	}
+
+	out := map[string]string{}
+	if from := p.AttributesFrom; from != nil {
+		for _, k := range from.Labels {
+			if v, ok := cr.GetLabels()[k]; ok {
+				out[k] = v
+			}
+		}
+		for _, k := range from.Annotations {
+			if v, ok := cr.GetAnnotations()[k]; ok {
+				out[k] = v
+			}
+		}
+	}
+	for k, v := range p.Attributes {
+		out[k] = v
+	}
+	if p.Comment != nil {
+		out["comment"] = *p.Comment
+	}
+	return out
+}
+
+// attributesToClause returns an ATTRIBUTE clause setting the desired
+// attributes. Observed attributes that are not desired are removed by setting
+// them to null.
+func attributesToClause(desired, observed map[string]string) (string, error) {
+	attrs := map[string]interface{}{}
+	for k := range observed {
+		attrs[k] = nil
+	}
+	for k, v := range desired {
+		attrs[k] = v
+	}
+	b, err := json.Marshal(attrs)
+	if err != nil {
+		return "", errors.Wrap(err, errMarshalAttributes)
+	}
+	return fmt.Sprintf("ATTRIBUTE %s", mysql.QuoteValue(string(b))), nil
+}
+
+// observeAttributes returns the attributes of an account. Values that are not
+// strings are returned as JSON. Servers that predate attributes report none.
+func (c *external) observeAttributes(ctx context.Context, username, host string) (map[string]string, error) {
+	var attributes *string
+	query := "SELECT ATTRIBUTE FROM information_schema.USER_ATTRIBUTES WHERE USER = ? AND HOST = ?"
+	err := c.db.Scan(ctx, xsql.Query{String: query, Parameters: []interface{}{username, host}}, &attributes)
+	if mysql.IsUnsupported(err) || xsql.IsNoRows(err) {
+		return nil, nil
+	}
+	if err != nil || attributes == nil {
+		return nil, err
+	}
+
+	raw := map[string]json.RawMessage{}
+	if err := json.Unmarshal([]byte(*attributes), &raw); err != nil {
+		return nil, err
+	}
+	out := make(map[string]string, len(raw))
+	for k, v := range raw {
+		var str string
+		if json.Unmarshal(v, &str) != nil {
+			str = string(v)
+		}
+		out[k] = str
+	}
+	return out, nil
+}
+
+// accounts returns the name of the user and the hosts it has an account for.
+func accounts(cr *v1alpha1.User) (string, []string) {
+	username, host := mysql.SplitUserHost(meta.GetExternalName(cr))
+	if len(cr.Spec.ForProvider.Hosts) > 0 {
+		return username, cr.Spec.ForProvider.Hosts
+	}
+	return username, []string{host}
+}
+
+// accountsClause returns the accounts of the user on the supplied hosts, as
+// listed in a CREATE, ALTER or DROP USER statement. Each account is followed
+// by the supplied clause, if any.
+func accountsClause(username string, hosts []string, clause string) string {
+	a := make([]string, len(hosts))
+	for i, h := range hosts {
+		a[i] = fmt.Sprintf("%s@%s", mysql.QuoteValue(username), mysql.QuoteValue(h))
+		if clause != "" {
+			a[i] += " " + clause
+		}
+	}
+	return strings.Join(a, ", ")
+}
+
+// difference returns the elements of a that are not in b.
+func difference(a, b []string) []string {
+	set := map[string]bool{}
+	for _, s := range b {
+		set[s] = true
+	}
+	var out []string
+	for _, s := range a {
+		if !set[s] {
+			out = append(out, s)
+		}
+	}
+	return out
+}
+
+// observeHosts returns the hosts the user has an account for, out of the
+// desired hosts and those it had an account for when last observed. A user
+// that was ready before its hosts were set had an account on the host of its
+// external name, which is dropped unless it is one of the desired hosts.
+func (c *external) observeHosts(ctx context.Context, cr *v1alpha1.User, username string) ([]string, error) {
+	var hosts *string
+	query := "SELECT GROUP_CONCAT(Host ORDER BY Host SEPARATOR '\\n') FROM mysql.user WHERE User = ?"
+	if err := c.db.Scan(ctx, xsql.Query{String: query, Parameters: []interface{}{username}}, &hosts); err != nil || hosts == nil {
+		return nil, err
+	}
+
+	known := map[string]bool{}
+	for _, h := range cr.Spec.ForProvider.Hosts {
+		known[h] = true
+	}
+	for _, h := range cr.Status.AtProvider.Hosts {
+		known[h] = true
+	}
+	if len(cr.Status.AtProvider.Hosts) == 0 && cr.GetCondition(xpv1.TypeReady).Status == corev1.ConditionTrue {
+		_, host := mysql.SplitUserHost(meta.GetExternalName(cr))
+		known[host] = true
+	}
+	out := []string{}
+	for _, h := range strings.Split(*hosts, "\n") {
+		if known[h] {
+			out = append(out, h)
+		}
+	}
+	return out, nil
+}
+
 func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
 	cr, ok := mg.(*v1alpha1.User)
 	if !ok {
 		return managed.ExternalObservation{}, errors.New(errNotUser)
 	}
 
-	username, host := mysql.SplitUserHost(meta.GetExternalName(cr))
+	username, hosts := accounts(cr)
+	if len(cr.Spec.ForProvider.Hosts) > 0 {
+		observed, err := c.observeHosts(ctx, cr, username)
+		if err != nil {
+			return managed.ExternalObservation{}, errors.Wrap(err, errSelectUser)
+		}
+		cr.Status.AtProvider.Hosts = observed
+		if len(observed) == 0 {
+			return managed.ExternalObservation{ResourceExists: false}, nil
+		}
+		hosts = observed
+	}
+
+	// The options of all accounts are set together, so we observe most of
+	// them on any one of them.
+	host := hosts[0]
 
 	observed := &v1alpha1.UserParameters{
 		ResourceOptions: &v1alpha1.ResourceOptions{},
 	}
 
+	var plugin, sslType, issuer, subject, cipher string
+
 	query := "SELECT " +
 		"max_questions, " +
 		"max_updates, " +
 		"max_connections, " +
-		"max_user_connections " +
+		"max_user_connections, " +
+		"plugin, " +
+		"ssl_type, " +
+		"x509_issuer, " +
+		"x509_subject, " +
+		"ssl_cipher " +
 		"FROM mysql.user WHERE User = ? AND Host = ?"
 	err := c.db.Scan(ctx,
 		xsql.Query{
@@ -208,6 +662,11 @@ func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 		&observed.ResourceOptions.MaxUpdatesPerHour,
 		&observed.ResourceOptions.MaxConnectionsPerHour,
 		&observed.ResourceOptions.MaxUserConnections,
+		&plugin,
+		&sslType,
+		&issuer,
+		&subject,
+		&cipher,
 	)
 	if xsql.IsNoRows(err) {
 		return managed.ExternalObservation{ResourceExists: false}, nil
@@ -221,13 +680,34 @@ func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 		return managed.ExternalObservation{}, err
 	}
 
+	po, locked, err := c.observeAccountOptions(ctx, username, hosts, cr.Spec.ForProvider)
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errSelectPasswordOptions)
+	}
+
+	dr, err := c.observeDefaultRoles(ctx, username, host)
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errSelectDefaultRoles)
+	}
+
+	attrs, err := c.observeAttributes(ctx, username, host)
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errSelectAttributes)
+	}
+
 	cr.Status.AtProvider.ResourceOptionsAsClauses = resourceOptionsToClauses(observed.ResourceOptions)
+	cr.Status.AtProvider.AuthPlugin = plugin
+	cr.Status.AtProvider.RequireAsClause = observedRequireClause(sslType, issuer, subject, cipher)
+	cr.Status.AtProvider.PasswordOptionsAsClauses = po
+	cr.Status.AtProvider.AccountLocked = locked
+	cr.Status.AtProvider.DefaultRoles = dr
+	cr.Status.AtProvider.Attributes = attrs
 
 	cr.SetConditions(xpv1.Available())
 
 	return managed.ExternalObservation{
 		ResourceExists:   true,
-		ResourceUpToDate: !pwdChanged && upToDate(observed, &cr.Spec.ForProvider),
+		ResourceUpToDate: !pwdChanged && upToDate(observed, &cr.Spec.ForProvider) && authUpToDate(cr) && accountUpToDate(cr) && hostsUpToDate(cr) && attributesUpToDate(cr),
 	}, nil
 }
 
@@ -239,31 +719,22 @@ func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.Ext
 
 	cr.SetConditions(xpv1.Creating())
 
-	username, host := mysql.SplitUserHost(meta.GetExternalName(cr))
+	username, hosts := accounts(cr)
 	pw, _, err := c.getPassword(ctx, cr)
 	if err != nil {
 		return managed.ExternalCreation{}, err
 	}
-	if pw == "" {
+	if pw == "" && usesPassword(cr.Spec.ForProvider) {
 		pw, err = password.Generate()
 		if err != nil {
 			return managed.ExternalCreation{}, err
 		}
 	}
 
-	var resourceOptions string
-	ro := resourceOptionsToClauses(cr.Spec.ForProvider.ResourceOptions)
-	if len(ro) != 0 {
-		resourceOptions = fmt.Sprintf(" WITH %s", strings.Join(ro, " "))
+	query, err := createUserQuery(cr.Spec.ForProvider, username, hosts, pw, desiredAttributes(cr))
+	if err != nil {
+		return managed.ExternalCreation{}, err
 	}
-
-	query := fmt.Sprintf(
-		"CREATE USER %s@%s IDENTIFIED BY %s%s",
-		mysql.QuoteValue(username),
-		mysql.QuoteValue(host),
-		mysql.QuoteValue(pw),
-		resourceOptions,
-	)
 	if err := c.db.Exec(ctx, xsql.Query{
 		String: query,
 	}); err != nil {
@@ -275,27 +746,95 @@ func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.Ext
 		return managed.ExternalCreation{}, errors.Wrap(err, errFlushPriv)
 	}
 
-	if len(ro) != 0 {
+	if ro := resourceOptionsToClauses(cr.Spec.ForProvider.ResourceOptions); len(ro) != 0 {
 		cr.Status.AtProvider.ResourceOptionsAsClauses = ro
 	}
+	if cr.Spec.ForProvider.AuthPlugin != nil {
+		cr.Status.AtProvider.AuthPlugin = *cr.Spec.ForProvider.AuthPlugin
+	}
+	if rq := requireToClause(cr.Spec.ForProvider.Require); rq != "" {
+		cr.Status.AtProvider.RequireAsClause = rq
+	}
+	if po := passwordOptionsToClauses(cr.Spec.ForProvider.PasswordOptions); len(po) != 0 {
+		cr.Status.AtProvider.PasswordOptionsAsClauses = po
+	}
+	if l := cr.Spec.ForProvider.AccountLocked; l != nil {
+		cr.Status.AtProvider.AccountLocked = *l
+	}
+	if len(cr.Spec.ForProvider.Hosts) > 0 {
+		cr.Status.AtProvider.Hosts = hosts
+	}
+	if attrs := desiredAttributes(cr); len(attrs) != 0 {
+		cr.Status.AtProvider.Attributes = attrs
+	}
 
 	return managed.ExternalCreation{
 		ConnectionDetails: c.db.GetConnectionDetails(username, pw),
 	}, nil
 }
 
+// createUserQuery returns a query creating the accounts of the user on the
+// supplied hosts, with the supplied password and attributes and the options
+// of the User.
+func createUserQuery(p v1alpha1.UserParameters, username string, hosts []string, pw string, attrs map[string]string) (string, error) {
+	var require string
+	if rq := requireToClause(p.Require); rq != "" {
+		require = fmt.Sprintf(" %s", rq)
+	}
+
+	var resourceOptions string
+	if ro := resourceOptionsToClauses(p.ResourceOptions); len(ro) != 0 {
+		resourceOptions = fmt.Sprintf(" WITH %s", strings.Join(ro, " "))
+	}
+
+	var accountOptions string
+	ao := passwordOptionsToClauses(p.PasswordOptions)
+	if l := p.AccountLocked; l != nil {
+		ao = append(ao, lockToClause(*l))
+	}
+	if len(ao) != 0 {
+		accountOptions = fmt.Sprintf(" %s", strings.Join(ao, " "))
+	}
+
+	var attributes string
+	if len(attrs) != 0 {
+		a, err := attributesToClause(attrs, nil)
+		if err != nil {
+			return "", err
+		}
+		attributes = fmt.Sprintf(" %s", a)
+	}
+
+	return fmt.Sprintf(
+		"CREATE USER %s%s%s%s%s",
+		accountsClause(username, hosts, authToClause(p, pw)),
+		require,
+		resourceOptions,
+		accountOptions,
+		attributes,
+	), nil
+}
+
 func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
 	cr, ok := mg.(*v1alpha1.User)
 	if !ok {
 		return managed.ExternalUpdate{}, errors.New(errNotUser)
 	}
 
-	username, host := mysql.SplitUserHost(meta.GetExternalName(cr))
+	username, hosts := accounts(cr)
 	pw, pwchanged, err := c.getPassword(ctx, cr)
 	if err != nil {
 		return managed.ExternalUpdate{}, err
 	}
 
+	if len(cr.Spec.ForProvider.Hosts) > 0 {
+		generated, err := c.updateHosts(ctx, cr, username, &pw)
+		if err != nil {
+			return managed.ExternalUpdate{}, err
+		}
+		pwchanged = pwchanged || generated
+	}
+
 	ro := resourceOptionsToClauses(cr.Spec.ForProvider.ResourceOptions)
 	rochanged, err := changedResourceOptions(cr.Status.AtProvider.ResourceOptionsAsClauses, ro)
 	if err != nil {
@@ -306,9 +845,8 @@ func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.Ext
 		resourceOptions := fmt.Sprintf("WITH %s", strings.Join(ro, " "))
 
 		query := fmt.Sprintf(
-			"ALTER USER %s@%s %s",
-			mysql.QuoteValue(username),
-			mysql.QuoteValue(host),
+			"ALTER USER %s %s",
+			accountsClause(username, hosts, ""),
 			resourceOptions,
 		)
 		if err := c.db.Exec(ctx, xsql.Query{
@@ -325,8 +863,85 @@ func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.Ext
 		cr.Status.AtProvider.ResourceOptionsAsClauses = ro
 	}
 
-	if pwchanged {
-		query := fmt.Sprintf("ALTER USER %s@%s IDENTIFIED BY %s", mysql.QuoteValue(username), mysql.QuoteValue(host), mysql.QuoteValue(pw))
+	rq := requireToClause(cr.Spec.ForProvider.Require)
+	if rq != "" && rq != cr.Status.AtProvider.RequireAsClause {
+		query := fmt.Sprintf("ALTER USER %s %s", accountsClause(username, hosts, ""), rq)
+		if err := c.db.Exec(ctx, xsql.Query{
+			String: query,
+		}); err != nil {
+			return managed.ExternalUpdate{}, errors.Wrap(err, errUpdateUser)
+		}
+		if err := c.db.Exec(ctx, xsql.Query{
+			String: "FLUSH PRIVILEGES",
+		}); err != nil {
+			return managed.ExternalUpdate{}, errors.Wrap(err, errFlushPriv)
+		}
+
+		cr.Status.AtProvider.RequireAsClause = rq
+	}
+
+	ao := changedClauses(cr.Status.AtProvider.PasswordOptionsAsClauses, passwordOptionsToClauses(cr.Spec.ForProvider.PasswordOptions))
+	if l := cr.Spec.ForProvider.AccountLocked; l != nil && *l != cr.Status.AtProvider.AccountLocked {
+		ao = append(ao, lockToClause(*l))
+	}
+	if len(ao) > 0 {
+		query := fmt.Sprintf("ALTER USER %s %s", accountsClause(username, hosts, ""), strings.Join(ao, " "))
+		if err := c.db.Exec(ctx, xsql.Query{
+			String: query,
+		}); err != nil {
+			return managed.ExternalUpdate{}, errors.Wrap(err, errUpdateUser)
+		}
+		if err := c.db.Exec(ctx, xsql.Query{
+			String: "FLUSH PRIVILEGES",
+		}); err != nil {
+			return managed.ExternalUpdate{}, errors.Wrap(err, errFlushPriv)
+		}
+	}
+
+	// Default roles are not set on creation, as the roles are usually granted
+	// to the user after it was created.
+	if dr := cr.Spec.ForProvider.DefaultRoles; len(dr) > 0 && !cmp.Equal(defaultRoles(dr), cr.Status.AtProvider.DefaultRoles, cmpopts.EquateEmpty()) {
+		query := fmt.Sprintf("SET DEFAULT ROLE %s TO %s", defaultRolesToClause(dr), accountsClause(username, hosts, ""))
+		if err := c.db.Exec(ctx, xsql.Query{
+			String: query,
+		}); err != nil {
+			return managed.ExternalUpdate{}, errors.Wrap(err, errUpdateUser)
+		}
+
+		cr.Status.AtProvider.DefaultRoles = defaultRoles(dr)
+	}
+
+	if !attributesUpToDate(cr) {
+		attrs := desiredAttributes(cr)
+		clause, err := attributesToClause(attrs, cr.Status.AtProvider.Attributes)
+		if err != nil {
+			return managed.ExternalUpdate{}, err
+		}
+		query := fmt.Sprintf("ALTER USER %s %s", accountsClause(username, hosts, ""), clause)
+		if err := c.db.Exec(ctx, xsql.Query{
+			String: query,
+		}); err != nil {
+			return managed.ExternalUpdate{}, errors.Wrap(err, errUpdateUser)
+		}
+
+		cr.Status.AtProvider.Attributes = attrs
+	}
+
+	plugin := cr.Spec.ForProvider.AuthPlugin
+	pluginchanged := plugin != nil && *plugin != cr.Status.AtProvider.AuthPlugin
+
+	// Changing the authentication plugin of a user that authenticates with a
+	// password requires setting the password again. If the password is not
+	// read from a secret we generate a new one.
+	if pluginchanged && pw == "" && usesPassword(cr.Spec.ForProvider) {
+		pw, err = password.Generate()
+		if err != nil {
+			return managed.ExternalUpdate{}, err
+		}
+	}
+
+	if pwchanged || pluginchanged {
+		query := fmt.Sprintf("ALTER USER %s", accountsClause(username, hosts, authToClause(cr.Spec.ForProvider, pw)))
 		if err := c.db.Exec(ctx, xsql.Query{
 			String: query,
 		}); err != nil {
@@ -338,6 +953,10 @@ func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.Ext
 			return managed.ExternalUpdate{}, errors.Wrap(err, errFlushPriv)
 		}
 
+		if plugin != nil {
+			cr.Status.AtProvider.AuthPlugin = *plugin
+		}
+
 		return managed.ExternalUpdate{
 			ConnectionDetails: c.db.GetConnectionDetails(username, pw),
 		}, nil
@@ -345,6 +964,49 @@ func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.Ext
 	return managed.ExternalUpdate{}, nil
 }
 
+// updateHosts creates the accounts of the user on hosts that were added, and
+// drops those on hosts that were removed. New accounts must share the
+// password of the existing ones, so if the password is not read from a
+// secret a new one is generated, and true is returned to set it on all
+// accounts.
+func (c *external) updateHosts(ctx context.Context, cr *v1alpha1.User, username string, pw *string) (bool, error) {
+	var err error
+	generated := false
+	added := difference(cr.Spec.ForProvider.Hosts, cr.Status.AtProvider.Hosts)
+	removed := difference(cr.Status.AtProvider.Hosts, cr.Spec.ForProvider.Hosts)
+	if len(added) == 0 && len(removed) == 0 {
+		return false, nil
+	}
+
+	if len(added) > 0 {
+		if *pw == "" && usesPassword(cr.Spec.ForProvider) {
+			if *pw, err = password.Generate(); err != nil {
+				return false, err
+			}
+			generated = true
+		}
+		query, err := createUserQuery(cr.Spec.ForProvider, username, added, *pw, desiredAttributes(cr))
+		if err != nil {
+			return false, err
+		}
+		if err := c.db.Exec(ctx, xsql.Query{String: query}); err != nil {
+			return false, errors.Wrap(err, errCreateUser)
+		}
+	}
+	if len(removed) > 0 {
+		query := fmt.Sprintf("DROP USER IF EXISTS %s", accountsClause(username, removed, ""))
+		if err := c.db.Exec(ctx, xsql.Query{String: query}); err != nil {
+			return false, errors.Wrap(err, errDropUser)
+		}
+	}
+	if err := c.db.Exec(ctx, xsql.Query{String: "FLUSH PRIVILEGES"}); err != nil {
+		return false, errors.Wrap(err, errFlushPriv)
+	}
+
+	cr.Status.AtProvider.Hosts = cr.Spec.ForProvider.Hosts
+	return generated, nil
+}
+
 func (c *external) Delete(ctx context.Context, mg resource.Managed) error {
 	cr, ok := mg.(*v1alpha1.User)
 	if !ok {
@@ -353,9 +1015,10 @@ func (c *external) Delete(ctx context.Context, mg resource.Managed) error {
 
 	cr.SetConditions(xpv1.Deleting())
 
-	username, host := mysql.SplitUserHost(meta.GetExternalName(cr))
+	username, hosts := accounts(cr)
+	hosts = append(hosts, difference(cr.Status.AtProvider.Hosts, hosts)...)
 	if err := c.db.Exec(ctx, xsql.Query{
-		String: fmt.Sprintf("DROP USER IF EXISTS %s@%s", mysql.QuoteValue(username), mysql.QuoteValue(host)),
+		String: fmt.Sprintf("DROP USER IF EXISTS %s", accountsClause(username, hosts, "")),
 	}); err != nil {
 		return errors.Wrap(err, errDropUser)
 	}
@@ -367,6 +1030,51 @@ func (c *external) Delete(ctx context.Context, mg resource.Managed) error {
 	return nil
 }
 
+func authUpToDate(cr *v1alpha1.User) bool {
+	if p := cr.Spec.ForProvider.AuthPlugin; p != nil && *p != cr.Status.AtProvider.AuthPlugin {
+		return false
+	}
+	if rq := requireToClause(cr.Spec.ForProvider.Require); rq != "" && rq != cr.Status.AtProvider.RequireAsClause {
+		return false
+	}
+	return true
+}
+
+// passwordOptionsUpToDate returns true if the observed password options and
+// lock state match the desired ones.
+func passwordOptionsUpToDate(observed []string, locked bool, desired v1alpha1.UserParameters) bool {
+	if len(changedClauses(observed, passwordOptionsToClauses(desired.PasswordOptions))) > 0 {
+		return false
+	}
+	return desired.AccountLocked == nil || *desired.AccountLocked == locked
+}
+
+func accountUpToDate(cr *v1alpha1.User) bool {
+	if !passwordOptionsUpToDate(cr.Status.AtProvider.PasswordOptionsAsClauses, cr.Status.AtProvider.AccountLocked, cr.Spec.ForProvider) {
+		return false
+	}
+	if dr := cr.Spec.ForProvider.DefaultRoles; len(dr) > 0 && !cmp.Equal(defaultRoles(dr), cr.Status.AtProvider.DefaultRoles, cmpopts.EquateEmpty()) {
+		return false
+	}
+	return true
+}
+
+// attributesUpToDate returns true if the account has exactly the desired
+// attributes, or they are not managed.
+func attributesUpToDate(cr *v1alpha1.User) bool {
+	desired := desiredAttributes(cr)
+	return desired == nil || cmp.Equal(desired, cr.Status.AtProvider.Attributes, cmpopts.EquateEmpty())
+}
+
+// hostsUpToDate returns true if the user has an account for exactly the
+// desired hosts.
+func hostsUpToDate(cr *v1alpha1.User) bool {
+	if len(cr.Spec.ForProvider.Hosts) == 0 {
+		return true
+	}
+	return cmp.Equal(cr.Spec.ForProvider.Hosts, cr.Status.AtProvider.Hosts, cmpopts.SortSlices(func(a, b string) bool { return a < b }))
+}
+
 func upToDate(observed *v1alpha1.UserParameters, desired *v1alpha1.UserParameters) bool {
 	if desired.ResourceOptions == nil {
 		// Return true if there are no desired ResourceOptions
@@ -19,14 +19,17 @@ package user
 import (
 	"context"
 	"database/sql"
+	"strings"
 	"testing"
 
 	"github.com/crossplane-contrib/provider-sql/apis/mysql/v1alpha1"
+	"github.com/go-sql-driver/mysql"
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
@@ -65,6 +68,39 @@ func (m mockDB) GetConnectionDetails(username, password string) managed.Connecti
 	}
 }
 
+// scanAuth populates the plugin and TLS columns selected from mysql.user.
+func scanAuth(plugin, sslType, issuer, subject, cipher string) func(ctx context.Context, q xsql.Query, dest ...interface{}) error {
+	return func(ctx context.Context, q xsql.Query, dest ...interface{}) error {
+		if !strings.Contains(q.String, "ssl_type") {
+			return nil
+		}
+		*dest[4].(*string) = plugin
+		*dest[5].(*string) = sslType
+		*dest[6].(*string) = issuer
+		*dest[7].(*string) = subject
+		*dest[8].(*string) = cipher
+		return nil
+	}
+}
+
+// scanPasswordOptions populates the password option columns selected from
+// mysql.user.
+func scanPasswordOptions(lifetime, history, reuse *int, locked string, attempts, lockTime *int) func(ctx context.Context, q xsql.Query, dest ...interface{}) error {
+	return func(ctx context.Context, q xsql.Query, dest ...interface{}) error {
+		switch {
+		case strings.Contains(q.String, "password_lifetime"):
+			*dest[0].(**int) = lifetime
+			*dest[1].(**int) = history
+			*dest[2].(**int) = reuse
+			*dest[3].(*string) = locked
+		case strings.Contains(q.String, "User_attributes"):
+			*dest[0].(**int) = attempts
+			*dest[1].(**int) = lockTime
+		}
+		return nil
+	}
+}
+
 func TestConnect(t *testing.T) {
 	errBoom := errors.New("boom")
 
@@ -197,8 +233,9 @@ func TestObserve(t *testing.T) {
 	}
 
 	type want struct {
-		o   managed.ExternalObservation
-		err error
+		o     managed.ExternalObservation
+		hosts []string
+		err   error
 	}
 
 	cases := map[string]struct {
@@ -270,37 +307,42 @@ func TestObserve(t *testing.T) {
 				err: nil,
 			},
 		},
-		"PasswordChanged": {
-			reason: "We should return ResourceUpToDate=false if the password changed",
+		"AuthPluginChanged": {
+			reason: "We should return ResourceUpToDate=false if the authentication plugin changed",
 			fields: fields{
 				db: mockDB{
-					MockScan: func(ctx context.Context, q xsql.Query, dest ...interface{}) error { return nil },
+					MockScan: scanAuth("mysql_native_password", "", "", "", ""),
 				},
-				kube: &test.MockClient{
-					MockGet: func(_ context.Context, key client.ObjectKey, obj client.Object) error {
-						secret := corev1.Secret{
-							Data: map[string][]byte{},
-						}
-						secret.Data[xpv1.ResourceCredentialsSecretPasswordKey] = []byte(key.Name)
-						secret.DeepCopyInto(obj.(*corev1.Secret))
-						return nil
+			},
+			args: args{
+				mg: &v1alpha1.User{
+					Spec: v1alpha1.UserSpec{
+						ForProvider: v1alpha1.UserParameters{
+							AuthPlugin: pointer.String("caching_sha2_password"),
+						},
 					},
 				},
 			},
+			want: want{
+				o: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: false,
+				},
+			},
+		},
+		"RequireChanged": {
+			reason: "We should return ResourceUpToDate=false if the TLS requirements changed",
+			fields: fields{
+				db: mockDB{
+					MockScan: scanAuth("caching_sha2_password", "ANY", "", "", ""),
+				},
+			},
 			args: args{
 				mg: &v1alpha1.User{
 					Spec: v1alpha1.UserSpec{
 						ForProvider: v1alpha1.UserParameters{
-							PasswordSecretRef: &xpv1.SecretKeySelector{
-								SecretReference: xpv1.SecretReference{
-									Name: "example",
-								},
-								Key: "password",
-							},
-						},
-						ResourceSpec: xpv1.ResourceSpec{
-							WriteConnectionSecretToReference: &xpv1.SecretReference{
-								Name: "connection-secret",
+							Require: &v1alpha1.TLSRequirement{
+								Subject: pointer.String("/CN=example"),
 							},
 						},
 					},
@@ -311,190 +353,799 @@ func TestObserve(t *testing.T) {
 					ResourceExists:   true,
 					ResourceUpToDate: false,
 				},
-				err: nil,
 			},
 		},
-	}
-
-	for name, tc := range cases {
-		t.Run(name, func(t *testing.T) {
-			e := external{
-				db:   tc.fields.db,
-				kube: tc.fields.kube,
-			}
-			got, err := e.Observe(tc.args.ctx, tc.args.mg)
-			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
-				t.Errorf("\n%s\ne.Observe(...): -want error, +got error:\n%s\n", tc.reason, diff)
-			}
-			if diff := cmp.Diff(tc.want.o, got); diff != "" {
-				t.Errorf("\n%s\ne.Observe(...): -want, +got:\n%s\n", tc.reason, diff)
-			}
-		})
-	}
-}
-
-func TestCreate(t *testing.T) {
-	errBoom := errors.New("boom")
-
-	type fields struct {
-		db   xsql.DB
-		kube client.Client
-	}
-
-	type args struct {
-		ctx context.Context
-		mg  resource.Managed
-	}
-
-	type want struct {
-		c   managed.ExternalCreation
-		err error
-	}
-
-	cases := map[string]struct {
-		reason    string
-		comparePw bool
-		fields    fields
-		args      args
-		want      want
-	}{
-		"ErrNotUser": {
-			reason: "An error should be returned if the managed resource is not a *User",
+		"RequireUpToDate": {
+			reason: "We should return ResourceUpToDate=true if the observed TLS requirements match",
+			fields: fields{
+				db: mockDB{
+					MockScan: scanAuth("caching_sha2_password", "SPECIFIED", "/CN=ca", "/CN=example", ""),
+				},
+			},
 			args: args{
-				mg: nil,
+				mg: &v1alpha1.User{
+					Spec: v1alpha1.UserSpec{
+						ForProvider: v1alpha1.UserParameters{
+							AuthPlugin: pointer.String("caching_sha2_password"),
+							Require: &v1alpha1.TLSRequirement{
+								Type:    pointer.String("SSL"),
+								Issuer:  pointer.String("/CN=ca"),
+								Subject: pointer.String("/CN=example"),
+							},
+						},
+					},
+				},
 			},
 			want: want{
-				err: errors.New(errNotUser),
+				o: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: true,
+				},
 			},
 		},
-		"ErrExec": {
-			reason: "Any errors encountered while creating the user should be returned",
+		"RequireCipher": {
+			reason: "We should return ResourceUpToDate=false if the account requires a cipher",
 			fields: fields{
-				db: &mockDB{
-					MockExec: func(ctx context.Context, q xsql.Query) error { return errBoom },
+				db: mockDB{
+					MockScan: scanAuth("caching_sha2_password", "SPECIFIED", "", "", "ECDHE-RSA-AES256-GCM-SHA384"),
 				},
 			},
 			args: args{
-				mg: &v1alpha1.User{},
+				mg: &v1alpha1.User{
+					Spec: v1alpha1.UserSpec{
+						ForProvider: v1alpha1.UserParameters{
+							Require: &v1alpha1.TLSRequirement{
+								Type: pointer.String("SSL"),
+							},
+						},
+					},
+				},
 			},
 			want: want{
-				err: errors.Wrap(errBoom, errCreateUser),
+				o: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: false,
+				},
 			},
 		},
-		"Success": {
-			reason: "No error should be returned when we successfully create a user",
+		"PasswordOptionsChanged": {
+			reason: "We should return ResourceUpToDate=false if the password options changed",
 			fields: fields{
-				db: &mockDB{
-					MockExec: func(ctx context.Context, q xsql.Query) error { return nil },
+				db: mockDB{
+					MockScan: scanPasswordOptions(nil, nil, nil, "N", nil, nil),
 				},
 			},
 			args: args{
 				mg: &v1alpha1.User{
-					ObjectMeta: v1.ObjectMeta{
-						Annotations: map[string]string{
-							meta.AnnotationKeyExternalName: "example",
-						},
-					},
 					Spec: v1alpha1.UserSpec{
-						ForProvider: v1alpha1.UserParameters{},
+						ForProvider: v1alpha1.UserParameters{
+							PasswordOptions: &v1alpha1.PasswordOptions{
+								Expire: pointer.String("90"),
+							},
+						},
 					},
 				},
 			},
 			want: want{
-				err: nil,
-				c: managed.ExternalCreation{
-					ConnectionDetails: managed.ConnectionDetails{
-						xpv1.ResourceCredentialsSecretUserKey:     []byte("example"),
-						xpv1.ResourceCredentialsSecretPasswordKey: []byte(""),
-						xpv1.ResourceCredentialsSecretEndpointKey: []byte("localhost"),
-						xpv1.ResourceCredentialsSecretPortKey:     []byte("3306"),
-					},
+				o: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: false,
 				},
 			},
 		},
-		"UserWithHost": {
-			reason: "The username must be split if it contains a host",
+		"ErrPasswordOptionsUnsupported": {
+			reason: "We should return an error if the server does not support the desired password options",
 			fields: fields{
-				db: &mockDB{
-					MockExec: func(ctx context.Context, q xsql.Query) error { return nil },
+				db: mockDB{
+					MockScan: func(ctx context.Context, q xsql.Query, dest ...interface{}) error {
+						if strings.Contains(q.String, "password_lifetime") {
+							return &mysql.MySQLError{Number: 1054}
+						}
+						return nil
+					},
 				},
 			},
 			args: args{
 				mg: &v1alpha1.User{
-					ObjectMeta: v1.ObjectMeta{
-						Annotations: map[string]string{
-							meta.AnnotationKeyExternalName: "example@127.0.0.1",
+					Spec: v1alpha1.UserSpec{
+						ForProvider: v1alpha1.UserParameters{
+							AccountLocked: pointer.Bool(true),
 						},
 					},
 				},
 			},
 			want: want{
-				err: nil,
-				c: managed.ExternalCreation{
-					ConnectionDetails: managed.ConnectionDetails{
-						xpv1.ResourceCredentialsSecretUserKey:     []byte("example"),
-						xpv1.ResourceCredentialsSecretPasswordKey: []byte(""),
-						xpv1.ResourceCredentialsSecretEndpointKey: []byte("localhost"),
-						xpv1.ResourceCredentialsSecretPortKey:     []byte("3306"),
-					},
-				},
+				err: errors.Wrap(errors.New(errPasswordUnsupported), errSelectPasswordOptions),
 			},
 		},
-		"UserWithPasswordRef": {
-			reason:    "The password must be read from the secret",
-			comparePw: true,
+		"ErrLoginTrackingUnsupported": {
+			reason: "We should return an error if the server does not support failed login tracking",
 			fields: fields{
-				db: &mockDB{
-					MockExec: func(ctx context.Context, q xsql.Query) error { return nil },
-				},
-				kube: &test.MockClient{
-					MockGet: func(_ context.Context, key client.ObjectKey, obj client.Object) error {
-						switch key.Name {
-						case "example":
-							secret := corev1.Secret{
-								Data: map[string][]byte{},
-							}
-							secret.Data["password-custom"] = []byte("test1234")
-							secret.DeepCopyInto(obj.(*corev1.Secret))
-							return nil
-						default:
-							return nil
+				db: mockDB{
+					MockScan: func(ctx context.Context, q xsql.Query, dest ...interface{}) error {
+						if strings.Contains(q.String, "User_attributes") {
+							return &mysql.MySQLError{Number: 1054}
 						}
+						return scanPasswordOptions(nil, nil, nil, "N", nil, nil)(ctx, q, dest...)
 					},
 				},
 			},
 			args: args{
 				mg: &v1alpha1.User{
-					ObjectMeta: v1.ObjectMeta{
-						Annotations: map[string]string{
-							meta.AnnotationKeyExternalName: "example",
+					Spec: v1alpha1.UserSpec{
+						ForProvider: v1alpha1.UserParameters{
+							PasswordOptions: &v1alpha1.PasswordOptions{
+								FailedLoginAttempts: pointer.Int(3),
+							},
 						},
 					},
+				},
+			},
+			want: want{
+				err: errors.Wrap(errors.New(errLoginUnsupported), errSelectPasswordOptions),
+			},
+		},
+		"PasswordOptionsUnsupportedUnmanaged": {
+			reason: "We should return ResourceUpToDate=true if the server does not support password options that are not managed",
+			fields: fields{
+				db: mockDB{
+					MockScan: func(ctx context.Context, q xsql.Query, dest ...interface{}) error {
+						if strings.Contains(q.String, "password_lifetime") {
+							return &mysql.MySQLError{Number: 1054}
+						}
+						return nil
+					},
+				},
+			},
+			args: args{
+				mg: &v1alpha1.User{},
+			},
+			want: want{
+				o: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: true,
+				},
+			},
+		},
+		"PasswordOptionsUpToDate": {
+			reason: "We should return ResourceUpToDate=true if the observed password options and lock state match",
+			fields: fields{
+				db: mockDB{
+					MockScan: scanPasswordOptions(pointer.Int(0), pointer.Int(5), nil, "Y", pointer.Int(3), pointer.Int(-1)),
+				},
+			},
+			args: args{
+				mg: &v1alpha1.User{
 					Spec: v1alpha1.UserSpec{
 						ForProvider: v1alpha1.UserParameters{
-							PasswordSecretRef: &xpv1.SecretKeySelector{
-								SecretReference: xpv1.SecretReference{
-									Name: "example",
-								},
-								Key: "password-custom",
+							PasswordOptions: &v1alpha1.PasswordOptions{
+								Expire:              pointer.String("NEVER"),
+								History:             pointer.String("5"),
+								ReuseInterval:       pointer.String("DEFAULT"),
+								FailedLoginAttempts: pointer.Int(3),
+								PasswordLockTime:    pointer.String("UNBOUNDED"),
 							},
+							AccountLocked: pointer.Bool(true),
 						},
 					},
 				},
 			},
 			want: want{
-				err: nil,
-				c: managed.ExternalCreation{
-					ConnectionDetails: managed.ConnectionDetails{
-						xpv1.ResourceCredentialsSecretUserKey:     []byte("example"),
-						xpv1.ResourceCredentialsSecretPasswordKey: []byte("test1234"),
-						xpv1.ResourceCredentialsSecretEndpointKey: []byte("localhost"),
-						xpv1.ResourceCredentialsSecretPortKey:     []byte("3306"),
+				o: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: true,
+				},
+			},
+		},
+		"ErrSelectPasswordOptions": {
+			reason: "We should return any errors encountered while trying to select the password options",
+			fields: fields{
+				db: mockDB{
+					MockScan: func(ctx context.Context, q xsql.Query, dest ...interface{}) error {
+						if strings.Contains(q.String, "password_lifetime") {
+							return errBoom
+						}
+						return nil
 					},
 				},
 			},
+			args: args{
+				mg: &v1alpha1.User{},
+			},
+			want: want{
+				err: errors.Wrap(errBoom, errSelectPasswordOptions),
+			},
 		},
-	}
+		"DefaultRolesChanged": {
+			reason: "We should return ResourceUpToDate=false if the default roles changed",
+			fields: fields{
+				db: mockDB{
+					MockScan: func(ctx context.Context, q xsql.Query, dest ...interface{}) error {
+						if strings.Contains(q.String, "mysql.default_roles") {
+							*dest[0].(**string) = pointer.String("reader@%")
+						}
+						return nil
+					},
+				},
+			},
+			args: args{
+				mg: &v1alpha1.User{
+					Spec: v1alpha1.UserSpec{
+						ForProvider: v1alpha1.UserParameters{
+							DefaultRoles: []string{"writer@localhost", "reader"},
+						},
+					},
+				},
+			},
+			want: want{
+				o: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: false,
+				},
+			},
+		},
+		"ErrSelectDefaultRoles": {
+			reason: "We should return any errors encountered while trying to select the default roles",
+			fields: fields{
+				db: mockDB{
+					MockScan: func(ctx context.Context, q xsql.Query, dest ...interface{}) error {
+						if strings.Contains(q.String, "mysql.default_roles") {
+							return errBoom
+						}
+						return nil
+					},
+				},
+			},
+			args: args{
+				mg: &v1alpha1.User{},
+			},
+			want: want{
+				err: errors.Wrap(errBoom, errSelectDefaultRoles),
+			},
+		},
+		"PasswordChanged": {
+			reason: "We should return ResourceUpToDate=false if the password changed",
+			fields: fields{
+				db: mockDB{
+					MockScan: func(ctx context.Context, q xsql.Query, dest ...interface{}) error { return nil },
+				},
+				kube: &test.MockClient{
+					MockGet: func(_ context.Context, key client.ObjectKey, obj client.Object) error {
+						secret := corev1.Secret{
+							Data: map[string][]byte{},
+						}
+						secret.Data[xpv1.ResourceCredentialsSecretPasswordKey] = []byte(key.Name)
+						secret.DeepCopyInto(obj.(*corev1.Secret))
+						return nil
+					},
+				},
+			},
+			args: args{
+				mg: &v1alpha1.User{
+					Spec: v1alpha1.UserSpec{
+						ForProvider: v1alpha1.UserParameters{
+							PasswordSecretRef: &xpv1.SecretKeySelector{
+								SecretReference: xpv1.SecretReference{
+									Name: "example",
+								},
+								Key: "password",
+							},
+						},
+						ResourceSpec: xpv1.ResourceSpec{
+							WriteConnectionSecretToReference: &xpv1.SecretReference{
+								Name: "connection-secret",
+							},
+						},
+					},
+				},
+			},
+			want: want{
+				o: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: false,
+				},
+				err: nil,
+			},
+		},
+		"HostsNotExist": {
+			reason: "We should return ResourceExists=false if the user has no account on any of its hosts",
+			fields: fields{
+				db: mockDB{
+					MockScan: func(ctx context.Context, q xsql.Query, dest ...interface{}) error { return nil },
+				},
+			},
+			args: args{
+				mg: &v1alpha1.User{
+					Spec: v1alpha1.UserSpec{
+						ForProvider: v1alpha1.UserParameters{
+							Hosts: []string{"10.0.0.1", "10.0.0.2"},
+						},
+					},
+				},
+			},
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: false},
+			},
+		},
+		"HostsMissing": {
+			reason: "We should return ResourceUpToDate=false if the user lacks an account on any of its hosts",
+			fields: fields{
+				db: mockDB{
+					MockScan: func(ctx context.Context, q xsql.Query, dest ...interface{}) error {
+						if strings.Contains(q.String, "GROUP_CONCAT") {
+							*dest[0].(**string) = pointer.String("10.0.0.1\nlocalhost")
+						}
+						return nil
+					},
+				},
+			},
+			args: args{
+				mg: &v1alpha1.User{
+					Spec: v1alpha1.UserSpec{
+						ForProvider: v1alpha1.UserParameters{
+							Hosts: []string{"10.0.0.1", "10.0.0.2"},
+						},
+					},
+				},
+			},
+			want: want{
+				o: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: false,
+				},
+			},
+		},
+		"HostsExternalNameDropped": {
+			reason: "We should return ResourceUpToDate=false if a ready user has an account on the host of its external name that is not one of its hosts",
+			fields: fields{
+				db: mockDB{
+					MockScan: func(ctx context.Context, q xsql.Query, dest ...interface{}) error {
+						if strings.Contains(q.String, "GROUP_CONCAT(Host") {
+							*dest[0].(**string) = pointer.String("10.0.0.1\n10.0.0.9\nlocalhost")
+						}
+						return nil
+					},
+				},
+			},
+			args: args{
+				mg: &v1alpha1.User{
+					ObjectMeta: v1.ObjectMeta{
+						Annotations: map[string]string{meta.AnnotationKeyExternalName: "app@10.0.0.9"},
+					},
+					Spec: v1alpha1.UserSpec{
+						ForProvider: v1alpha1.UserParameters{
+							Hosts: []string{"10.0.0.1"},
+						},
+					},
+					Status: v1alpha1.UserStatus{
+						ResourceStatus: xpv1.ResourceStatus{
+							ConditionedStatus: xpv1.ConditionedStatus{
+								Conditions: []xpv1.Condition{xpv1.Available()},
+							},
+						},
+					},
+				},
+			},
+			want: want{
+				o: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: false,
+				},
+				hosts: []string{"10.0.0.1", "10.0.0.9"},
+			},
+		},
+		"HostsAccountLocked": {
+			reason: "We should return ResourceUpToDate=false if the lock state differs on any of the hosts of the user",
+			fields: fields{
+				db: mockDB{
+					MockScan: func(ctx context.Context, q xsql.Query, dest ...interface{}) error {
+						switch {
+						case strings.Contains(q.String, "GROUP_CONCAT(Host"):
+							*dest[0].(**string) = pointer.String("10.0.0.1\n10.0.0.2")
+						case strings.Contains(q.String, "account_locked") && q.Parameters[1] == "10.0.0.2":
+							*dest[3].(*string) = "Y"
+						case strings.Contains(q.String, "account_locked"):
+							*dest[3].(*string) = "N"
+						}
+						return nil
+					},
+				},
+			},
+			args: args{
+				mg: &v1alpha1.User{
+					Spec: v1alpha1.UserSpec{
+						ForProvider: v1alpha1.UserParameters{
+							Hosts:         []string{"10.0.0.1", "10.0.0.2"},
+							AccountLocked: pointer.Bool(false),
+						},
+					},
+				},
+			},
+			want: want{
+				o: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: false,
+				},
+				hosts: []string{"10.0.0.1", "10.0.0.2"},
+			},
+		},
+		"ErrSelectAttributes": {
+			reason: "We should return any errors encountered while trying to select the attributes",
+			fields: fields{
+				db: mockDB{
+					MockScan: func(ctx context.Context, q xsql.Query, dest ...interface{}) error {
+						if strings.Contains(q.String, "USER_ATTRIBUTES") {
+							return errBoom
+						}
+						return nil
+					},
+				},
+			},
+			args: args{
+				mg: &v1alpha1.User{},
+			},
+			want: want{
+				err: errors.Wrap(errBoom, errSelectAttributes),
+			},
+		},
+		"AttributesChanged": {
+			reason: "We should return ResourceUpToDate=false if the attributes of the account differ",
+			fields: fields{
+				db: mockDB{
+					MockScan: func(ctx context.Context, q xsql.Query, dest ...interface{}) error {
+						if strings.Contains(q.String, "USER_ATTRIBUTES") {
+							*dest[0].(**string) = pointer.String(`{"comment": "app", "team": "payments", "owner": {"name": "jane"}}`)
+						}
+						return nil
+					},
+				},
+			},
+			args: args{
+				mg: &v1alpha1.User{
+					ObjectMeta: v1.ObjectMeta{
+						Labels: map[string]string{"team": "payments"},
+					},
+					Spec: v1alpha1.UserSpec{
+						ForProvider: v1alpha1.UserParameters{
+							Comment: pointer.String("app"),
+							AttributesFrom: &v1alpha1.AttributesSource{
+								Labels: []string{"team"},
+							},
+						},
+					},
+				},
+			},
+			want: want{
+				o: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: false,
+				},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{
+				db:   tc.fields.db,
+				kube: tc.fields.kube,
+			}
+			got, err := e.Observe(tc.args.ctx, tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, got); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+			if tc.want.hosts != nil {
+				if diff := cmp.Diff(tc.want.hosts, tc.args.mg.(*v1alpha1.User).Status.AtProvider.Hosts); diff != "" {
+					t.Errorf("\n%s\ne.Observe(...): -want hosts, +got hosts:\n%s\n", tc.reason, diff)
+				}
+			}
+		})
+	}
+}
+
+func TestCreate(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type fields struct {
+		db   xsql.DB
+		kube client.Client
+	}
+
+	type args struct {
+		ctx context.Context
+		mg  resource.Managed
+	}
+
+	type want struct {
+		c   managed.ExternalCreation
+		err error
+	}
+
+	cases := map[string]struct {
+		reason    string
+		comparePw bool
+		fields    fields
+		args      args
+		want      want
+	}{
+		"ErrNotUser": {
+			reason: "An error should be returned if the managed resource is not a *User",
+			args: args{
+				mg: nil,
+			},
+			want: want{
+				err: errors.New(errNotUser),
+			},
+		},
+		"ErrExec": {
+			reason: "Any errors encountered while creating the user should be returned",
+			fields: fields{
+				db: &mockDB{
+					MockExec: func(ctx context.Context, q xsql.Query) error { return errBoom },
+				},
+			},
+			args: args{
+				mg: &v1alpha1.User{},
+			},
+			want: want{
+				err: errors.Wrap(errBoom, errCreateUser),
+			},
+		},
+		"Success": {
+			reason: "No error should be returned when we successfully create a user",
+			fields: fields{
+				db: &mockDB{
+					MockExec: func(ctx context.Context, q xsql.Query) error { return nil },
+				},
+			},
+			args: args{
+				mg: &v1alpha1.User{
+					ObjectMeta: v1.ObjectMeta{
+						Annotations: map[string]string{
+							meta.AnnotationKeyExternalName: "example",
+						},
+					},
+					Spec: v1alpha1.UserSpec{
+						ForProvider: v1alpha1.UserParameters{},
+					},
+				},
+			},
+			want: want{
+				err: nil,
+				c: managed.ExternalCreation{
+					ConnectionDetails: managed.ConnectionDetails{
+						xpv1.ResourceCredentialsSecretUserKey:     []byte("example"),
+						xpv1.ResourceCredentialsSecretPasswordKey: []byte(""),
+						xpv1.ResourceCredentialsSecretEndpointKey: []byte("localhost"),
+						xpv1.ResourceCredentialsSecretPortKey:     []byte("3306"),
+					},
+				},
+			},
+		},
+		"UserWithHost": {
+			reason: "The username must be split if it contains a host",
+			fields: fields{
+				db: &mockDB{
+					MockExec: func(ctx context.Context, q xsql.Query) error { return nil },
+				},
+			},
+			args: args{
+				mg: &v1alpha1.User{
+					ObjectMeta: v1.ObjectMeta{
+						Annotations: map[string]string{
+							meta.AnnotationKeyExternalName: "example@127.0.0.1",
+						},
+					},
+				},
+			},
+			want: want{
+				err: nil,
+				c: managed.ExternalCreation{
+					ConnectionDetails: managed.ConnectionDetails{
+						xpv1.ResourceCredentialsSecretUserKey:     []byte("example"),
+						xpv1.ResourceCredentialsSecretPasswordKey: []byte(""),
+						xpv1.ResourceCredentialsSecretEndpointKey: []byte("localhost"),
+						xpv1.ResourceCredentialsSecretPortKey:     []byte("3306"),
+					},
+				},
+			},
+		},
+		"UserWithPasswordRef": {
+			reason:    "The password must be read from the secret",
+			comparePw: true,
+			fields: fields{
+				db: &mockDB{
+					MockExec: func(ctx context.Context, q xsql.Query) error { return nil },
+				},
+				kube: &test.MockClient{
+					MockGet: func(_ context.Context, key client.ObjectKey, obj client.Object) error {
+						switch key.Name {
+						case "example":
+							secret := corev1.Secret{
+								Data: map[string][]byte{},
+							}
+							secret.Data["password-custom"] = []byte("test1234")
+							secret.DeepCopyInto(obj.(*corev1.Secret))
+							return nil
+						default:
+							return nil
+						}
+					},
+				},
+			},
+			args: args{
+				mg: &v1alpha1.User{
+					ObjectMeta: v1.ObjectMeta{
+						Annotations: map[string]string{
+							meta.AnnotationKeyExternalName: "example",
+						},
+					},
+					Spec: v1alpha1.UserSpec{
+						ForProvider: v1alpha1.UserParameters{
+							PasswordSecretRef: &xpv1.SecretKeySelector{
+								SecretReference: xpv1.SecretReference{
+									Name: "example",
+								},
+								Key: "password-custom",
+							},
+						},
+					},
+				},
+			},
+			want: want{
+				err: nil,
+				c: managed.ExternalCreation{
+					ConnectionDetails: managed.ConnectionDetails{
+						xpv1.ResourceCredentialsSecretUserKey:     []byte("example"),
+						xpv1.ResourceCredentialsSecretPasswordKey: []byte("test1234"),
+						xpv1.ResourceCredentialsSecretEndpointKey: []byte("localhost"),
+						xpv1.ResourceCredentialsSecretPortKey:     []byte("3306"),
+					},
+				},
+			},
+		},
+		"UserWithAuthPlugin": {
+			reason:    "No password should be set for a user that does not authenticate with one",
+			comparePw: true,
+			fields: fields{
+				db: &mockDB{
+					MockExec: func(ctx context.Context, q xsql.Query) error {
+						if q.String == "FLUSH PRIVILEGES" {
+							return nil
+						}
+						if q.String != "CREATE USER 'example'@'%' IDENTIFIED WITH 'AWSAuthenticationPlugin' AS 'RDS' REQUIRE SSL" {
+							return errBoom
+						}
+						return nil
+					},
+				},
+			},
+			args: args{
+				mg: &v1alpha1.User{
+					ObjectMeta: v1.ObjectMeta{
+						Annotations: map[string]string{
+							meta.AnnotationKeyExternalName: "example",
+						},
+					},
+					Spec: v1alpha1.UserSpec{
+						ForProvider: v1alpha1.UserParameters{
+							AuthPlugin: pointer.String("AWSAuthenticationPlugin"),
+							AuthString: pointer.String("RDS"),
+							Require: &v1alpha1.TLSRequirement{
+								Type: pointer.String("SSL"),
+							},
+						},
+					},
+				},
+			},
+			want: want{
+				err: nil,
+				c: managed.ExternalCreation{
+					ConnectionDetails: managed.ConnectionDetails{
+						xpv1.ResourceCredentialsSecretUserKey:     []byte("example"),
+						xpv1.ResourceCredentialsSecretPasswordKey: []byte(""),
+						xpv1.ResourceCredentialsSecretEndpointKey: []byte("localhost"),
+						xpv1.ResourceCredentialsSecretPortKey:     []byte("3306"),
+					},
+				},
+			},
+		},
+		"UserWithHosts": {
+			reason: "An account should be created on each host of the user",
+			fields: fields{
+				db: &mockDB{
+					MockExec: func(ctx context.Context, q xsql.Query) error {
+						if q.String == "FLUSH PRIVILEGES" {
+							return nil
+						}
+						if q.String != "CREATE USER 'example'@'10.0.0.1' IDENTIFIED WITH 'auth_socket', 'example'@'10.0.0.2' IDENTIFIED WITH 'auth_socket'" {
+							return errBoom
+						}
+						return nil
+					},
+				},
+			},
+			args: args{
+				mg: &v1alpha1.User{
+					ObjectMeta: v1.ObjectMeta{
+						Annotations: map[string]string{
+							meta.AnnotationKeyExternalName: "example",
+						},
+					},
+					Spec: v1alpha1.UserSpec{
+						ForProvider: v1alpha1.UserParameters{
+							Hosts:      []string{"10.0.0.1", "10.0.0.2"},
+							AuthPlugin: pointer.String("auth_socket"),
+						},
+					},
+				},
+			},
+			want: want{
+				err: nil,
+				c: managed.ExternalCreation{
+					ConnectionDetails: managed.ConnectionDetails{
+						xpv1.ResourceCredentialsSecretUserKey:     []byte("example"),
+						xpv1.ResourceCredentialsSecretPasswordKey: []byte(""),
+						xpv1.ResourceCredentialsSecretEndpointKey: []byte("localhost"),
+						xpv1.ResourceCredentialsSecretPortKey:     []byte("3306"),
+					},
+				},
+			},
+		},
+		"UserWithAttributes": {
+			reason: "The comment, attributes and selected labels should be set on the account",
+			fields: fields{
+				db: &mockDB{
+					MockExec: func(ctx context.Context, q xsql.Query) error {
+						if q.String == "FLUSH PRIVILEGES" {
+							return nil
+						}
+						if q.String != `CREATE USER 'example'@'%' IDENTIFIED WITH 'auth_socket' ATTRIBUTE '{"comment":"app","owner":"jane","team":"payments"}'` {
+							return errBoom
+						}
+						return nil
+					},
+				},
+			},
+			args: args{
+				mg: &v1alpha1.User{
+					ObjectMeta: v1.ObjectMeta{
+						Annotations: map[string]string{
+							meta.AnnotationKeyExternalName: "example",
+						},
+						Labels: map[string]string{
+							"team":  "payments",
+							"owner": "john",
+						},
+					},
+					Spec: v1alpha1.UserSpec{
+						ForProvider: v1alpha1.UserParameters{
+							AuthPlugin: pointer.String("auth_socket"),
+							Comment:    pointer.String("app"),
+							Attributes: map[string]string{"owner": "jane"},
+							AttributesFrom: &v1alpha1.AttributesSource{
+								Labels: []string{"team", "owner", "missing"},
+							},
+						},
+					},
+				},
+			},
+			want: want{
+				err: nil,
+				c: managed.ExternalCreation{
+					ConnectionDetails: managed.ConnectionDetails{
+						xpv1.ResourceCredentialsSecretUserKey:     []byte("example"),
+						xpv1.ResourceCredentialsSecretPasswordKey: []byte(""),
+						xpv1.ResourceCredentialsSecretEndpointKey: []byte("localhost"),
+						xpv1.ResourceCredentialsSecretPortKey:     []byte("3306"),
+					},
+				},
+			},
+		},
+	}
 
 	for name, tc := range cases {
 		t.Run(name, func(t *testing.T) {
@@ -544,57 +1195,328 @@ func TestUpdate(t *testing.T) {
 		"ErrNotUser": {
 			reason: "An error should be returned if the managed resource is not a *User",
 			args: args{
-				mg: nil,
+				mg: nil,
+			},
+			want: want{
+				err: errors.New(errNotUser),
+			},
+		},
+		"ErrExec": {
+			reason: "Any errors encountered while updating the user should be returned",
+			fields: fields{
+				db: &mockDB{
+					MockExec: func(ctx context.Context, q xsql.Query) error { return errBoom },
+				},
+			},
+			args: args{
+				mg: &v1alpha1.User{
+					Spec: v1alpha1.UserSpec{
+						ForProvider: v1alpha1.UserParameters{
+							PasswordSecretRef: &xpv1.SecretKeySelector{
+								SecretReference: xpv1.SecretReference{
+									Name: "connection-secret",
+								},
+								Key: xpv1.ResourceCredentialsSecretPasswordKey,
+							},
+						},
+						ResourceSpec: xpv1.ResourceSpec{
+							WriteConnectionSecretToReference: &xpv1.SecretReference{
+								Name: "password-secret",
+							},
+						},
+					},
+				},
+				kube: &test.MockClient{
+					MockGet: func(_ context.Context, key client.ObjectKey, obj client.Object) error {
+						secret := corev1.Secret{
+							Data: map[string][]byte{},
+						}
+						secret.Data[xpv1.ResourceCredentialsSecretPasswordKey] = []byte(key.Name)
+						secret.DeepCopyInto(obj.(*corev1.Secret))
+						return nil
+					},
+				},
+			},
+			want: want{
+				err: errors.Wrap(errBoom, errUpdateUser),
+			},
+		},
+		"Success": {
+			reason: "No error should be returned when we don't have to update a user",
+			fields: fields{
+				db: &mockDB{
+					MockExec: func(ctx context.Context, q xsql.Query) error { return nil },
+				},
+			},
+			args: args{
+				mg: &v1alpha1.User{
+					ObjectMeta: v1.ObjectMeta{
+						Annotations: map[string]string{
+							meta.AnnotationKeyExternalName: "example",
+						},
+					},
+				},
+			},
+			want: want{
+				err: nil,
+				c:   managed.ExternalUpdate{},
+			},
+		},
+		"SamePassword": {
+			reason: "No DB query should be executed if the password didn't change",
+			fields: fields{
+				db: &mockDB{},
+			},
+			args: args{
+				mg: &v1alpha1.User{
+					Spec: v1alpha1.UserSpec{
+						ForProvider: v1alpha1.UserParameters{
+							PasswordSecretRef: &xpv1.SecretKeySelector{
+								SecretReference: xpv1.SecretReference{
+									Name: "connection-secret",
+								},
+								Key: xpv1.ResourceCredentialsSecretPasswordKey,
+							},
+						},
+						ResourceSpec: xpv1.ResourceSpec{
+							WriteConnectionSecretToReference: &xpv1.SecretReference{
+								Name: "connection-secret",
+							},
+						},
+					},
+				},
+				kube: &test.MockClient{
+					MockGet: func(_ context.Context, key client.ObjectKey, obj client.Object) error {
+						secret := corev1.Secret{
+							Data: map[string][]byte{},
+						}
+						secret.Data[xpv1.ResourceCredentialsSecretPasswordKey] = []byte("samesame")
+						secret.DeepCopyInto(obj.(*corev1.Secret))
+						return nil
+					},
+				},
+			},
+			want: want{},
+		},
+		"UpdatePassword": {
+			reason: "The password must be updated",
+			fields: fields{
+				db: &mockDB{
+					MockExec: func(ctx context.Context, q xsql.Query) error { return nil },
+				},
+			},
+			args: args{
+				mg: &v1alpha1.User{
+					ObjectMeta: v1.ObjectMeta{
+						Annotations: map[string]string{
+							meta.AnnotationKeyExternalName: "example",
+						},
+					},
+					Spec: v1alpha1.UserSpec{
+						ForProvider: v1alpha1.UserParameters{
+							PasswordSecretRef: &xpv1.SecretKeySelector{
+								SecretReference: xpv1.SecretReference{
+									Name: "example",
+								},
+								Key: "password-custom",
+							},
+						},
+						ResourceSpec: xpv1.ResourceSpec{
+							WriteConnectionSecretToReference: &xpv1.SecretReference{
+								Name: "connection-secret",
+							},
+						},
+					},
+				},
+				kube: &test.MockClient{
+					MockGet: func(_ context.Context, key client.ObjectKey, obj client.Object) error {
+						switch key.Name {
+						case "example":
+							secret := corev1.Secret{
+								Data: map[string][]byte{},
+							}
+							secret.Data["password-custom"] = []byte("newpassword")
+							secret.DeepCopyInto(obj.(*corev1.Secret))
+							return nil
+						case "connection-secret":
+							secret := corev1.Secret{
+								Data: map[string][]byte{},
+							}
+							secret.Data[xpv1.ResourceCredentialsSecretPasswordKey] = []byte("oldpassword")
+							secret.DeepCopyInto(obj.(*corev1.Secret))
+							return nil
+						default:
+							return nil
+						}
+					},
+				},
+			},
+			want: want{
+				err: nil,
+				c: managed.ExternalUpdate{
+					ConnectionDetails: managed.ConnectionDetails{
+						xpv1.ResourceCredentialsSecretUserKey:     []byte("example"),
+						xpv1.ResourceCredentialsSecretPasswordKey: []byte("newpassword"),
+						xpv1.ResourceCredentialsSecretEndpointKey: []byte("localhost"),
+						xpv1.ResourceCredentialsSecretPortKey:     []byte("3306"),
+					},
+				},
+			},
+		},
+		"NoUpdateQueryUnchangedResourceOptions": {
+			reason: "We should not execute an SQL query if the resource options are unchanged.",
+			fields: fields{
+				db: &mockDB{
+					MockExec: func(ctx context.Context, q xsql.Query) error { return errBoom },
+				},
+			},
+			args: args{
+				mg: &v1alpha1.User{
+					ObjectMeta: v1.ObjectMeta{
+						Annotations: map[string]string{
+							meta.AnnotationKeyExternalName: "example",
+						},
+					},
+					Spec: v1alpha1.UserSpec{
+						ForProvider: v1alpha1.UserParameters{
+							PasswordSecretRef: &xpv1.SecretKeySelector{
+								SecretReference: xpv1.SecretReference{
+									Name: "connection-secret",
+								},
+								Key: xpv1.ResourceCredentialsSecretPasswordKey,
+							},
+							ResourceOptions: &v1alpha1.ResourceOptions{
+								MaxQueriesPerHour:     new(int),
+								MaxUpdatesPerHour:     new(int),
+								MaxConnectionsPerHour: new(int),
+								MaxUserConnections:    new(int),
+							},
+						},
+					},
+					Status: v1alpha1.UserStatus{
+						AtProvider: v1alpha1.UserObservation{
+							ResourceOptionsAsClauses: []string{
+								"MAX_QUERIES_PER_HOUR 0",
+								"MAX_UPDATES_PER_HOUR 0",
+								"MAX_CONNECTIONS_PER_HOUR 0",
+								"MAX_USER_CONNECTIONS 0",
+							},
+						},
+					},
+				},
+				kube: &test.MockClient{
+					MockGet: func(_ context.Context, key client.ObjectKey, obj client.Object) error {
+						secret := corev1.Secret{
+							Data: map[string][]byte{},
+						}
+						secret.Data[xpv1.ResourceCredentialsSecretPasswordKey] = []byte("samesame")
+						secret.DeepCopyInto(obj.(*corev1.Secret))
+						return nil
+					},
+				},
+			},
+			want: want{
+				err: nil,
+			},
+		},
+		"UpdateRequire": {
+			reason: "The TLS requirements must be updated if they changed",
+			fields: fields{
+				db: &mockDB{
+					MockExec: func(ctx context.Context, q xsql.Query) error {
+						if q.String == "FLUSH PRIVILEGES" {
+							return nil
+						}
+						if q.String != "ALTER USER 'example'@'%' REQUIRE X509" {
+							return errBoom
+						}
+						return nil
+					},
+				},
+			},
+			args: args{
+				mg: &v1alpha1.User{
+					ObjectMeta: v1.ObjectMeta{
+						Annotations: map[string]string{
+							meta.AnnotationKeyExternalName: "example",
+						},
+					},
+					Spec: v1alpha1.UserSpec{
+						ForProvider: v1alpha1.UserParameters{
+							Require: &v1alpha1.TLSRequirement{
+								Type: pointer.String("X509"),
+							},
+						},
+					},
+					Status: v1alpha1.UserStatus{
+						AtProvider: v1alpha1.UserObservation{
+							RequireAsClause: "REQUIRE NONE",
+						},
+					},
+				},
 			},
 			want: want{
-				err: errors.New(errNotUser),
+				err: nil,
 			},
 		},
-		"ErrExec": {
-			reason: "Any errors encountered while updating the user should be returned",
+		"UpdatePasswordOptions": {
+			reason: "Changed password options and lock state must be updated",
 			fields: fields{
 				db: &mockDB{
-					MockExec: func(ctx context.Context, q xsql.Query) error { return errBoom },
+					MockExec: func(ctx context.Context, q xsql.Query) error {
+						if q.String == "FLUSH PRIVILEGES" {
+							return nil
+						}
+						if q.String != "ALTER USER 'example'@'%' PASSWORD EXPIRE INTERVAL 90 DAY ACCOUNT LOCK" {
+							return errBoom
+						}
+						return nil
+					},
 				},
 			},
 			args: args{
 				mg: &v1alpha1.User{
+					ObjectMeta: v1.ObjectMeta{
+						Annotations: map[string]string{
+							meta.AnnotationKeyExternalName: "example",
+						},
+					},
 					Spec: v1alpha1.UserSpec{
 						ForProvider: v1alpha1.UserParameters{
-							PasswordSecretRef: &xpv1.SecretKeySelector{
-								SecretReference: xpv1.SecretReference{
-									Name: "connection-secret",
-								},
-								Key: xpv1.ResourceCredentialsSecretPasswordKey,
+							PasswordOptions: &v1alpha1.PasswordOptions{
+								Expire:  pointer.String("90"),
+								History: pointer.String("DEFAULT"),
 							},
+							AccountLocked: pointer.Bool(true),
 						},
-						ResourceSpec: xpv1.ResourceSpec{
-							WriteConnectionSecretToReference: &xpv1.SecretReference{
-								Name: "password-secret",
+					},
+					Status: v1alpha1.UserStatus{
+						AtProvider: v1alpha1.UserObservation{
+							PasswordOptionsAsClauses: []string{
+								"PASSWORD EXPIRE DEFAULT",
+								"PASSWORD HISTORY DEFAULT",
+								"PASSWORD REUSE INTERVAL DEFAULT",
+								"FAILED_LOGIN_ATTEMPTS 0",
+								"PASSWORD_LOCK_TIME 0",
 							},
 						},
 					},
 				},
-				kube: &test.MockClient{
-					MockGet: func(_ context.Context, key client.ObjectKey, obj client.Object) error {
-						secret := corev1.Secret{
-							Data: map[string][]byte{},
-						}
-						secret.Data[xpv1.ResourceCredentialsSecretPasswordKey] = []byte(key.Name)
-						secret.DeepCopyInto(obj.(*corev1.Secret))
-						return nil
-					},
-				},
 			},
 			want: want{
-				err: errors.Wrap(errBoom, errUpdateUser),
+				err: nil,
 			},
 		},
-		"Success": {
-			reason: "No error should be returned when we don't have to update a user",
+		"UpdateDefaultRoles": {
+			reason: "The default roles must be set if they changed",
 			fields: fields{
 				db: &mockDB{
-					MockExec: func(ctx context.Context, q xsql.Query) error { return nil },
+					MockExec: func(ctx context.Context, q xsql.Query) error {
+						if q.String != "SET DEFAULT ROLE 'writer'@'localhost', 'reader'@'%' TO 'example'@'%'" {
+							return errBoom
+						}
+						return nil
+					},
 				},
 			},
 			args: args{
@@ -604,20 +1526,44 @@ func TestUpdate(t *testing.T) {
 							meta.AnnotationKeyExternalName: "example",
 						},
 					},
+					Spec: v1alpha1.UserSpec{
+						ForProvider: v1alpha1.UserParameters{
+							DefaultRoles: []string{"writer@localhost", "reader"},
+						},
+					},
+					Status: v1alpha1.UserStatus{
+						AtProvider: v1alpha1.UserObservation{
+							DefaultRoles: []string{"reader@%"},
+						},
+					},
 				},
 			},
 			want: want{
 				err: nil,
-				c:   managed.ExternalUpdate{},
 			},
 		},
-		"SamePassword": {
-			reason: "No DB query should be executed if the password didn't change",
+		"UpdateAuthPlugin": {
+			reason: "The password must be set again if the authentication plugin changed",
 			fields: fields{
-				db: &mockDB{},
+				db: &mockDB{
+					MockExec: func(ctx context.Context, q xsql.Query) error {
+						if q.String == "FLUSH PRIVILEGES" {
+							return nil
+						}
+						if q.String != "ALTER USER 'example'@'%' IDENTIFIED WITH 'caching_sha2_password' BY 'samesame'" {
+							return errBoom
+						}
+						return nil
+					},
+				},
 			},
 			args: args{
 				mg: &v1alpha1.User{
+					ObjectMeta: v1.ObjectMeta{
+						Annotations: map[string]string{
+							meta.AnnotationKeyExternalName: "example",
+						},
+					},
 					Spec: v1alpha1.UserSpec{
 						ForProvider: v1alpha1.UserParameters{
 							PasswordSecretRef: &xpv1.SecretKeySelector{
@@ -626,11 +1572,12 @@ func TestUpdate(t *testing.T) {
 								},
 								Key: xpv1.ResourceCredentialsSecretPasswordKey,
 							},
+							AuthPlugin: pointer.String("caching_sha2_password"),
 						},
-						ResourceSpec: xpv1.ResourceSpec{
-							WriteConnectionSecretToReference: &xpv1.SecretReference{
-								Name: "connection-secret",
-							},
+					},
+					Status: v1alpha1.UserStatus{
+						AtProvider: v1alpha1.UserObservation{
+							AuthPlugin: "mysql_native_password",
 						},
 					},
 				},
@@ -645,13 +1592,31 @@ func TestUpdate(t *testing.T) {
 					},
 				},
 			},
-			want: want{},
+			want: want{
+				err: nil,
+				c: managed.ExternalUpdate{
+					ConnectionDetails: managed.ConnectionDetails{
+						xpv1.ResourceCredentialsSecretUserKey:     []byte("example"),
+						xpv1.ResourceCredentialsSecretPasswordKey: []byte("samesame"),
+						xpv1.ResourceCredentialsSecretEndpointKey: []byte("localhost"),
+						xpv1.ResourceCredentialsSecretPortKey:     []byte("3306"),
+					},
+				},
+			},
 		},
-		"UpdatePassword": {
-			reason: "The password must be updated",
+		"HostsChanged": {
+			reason: "Accounts should be created on added hosts and dropped on removed ones",
 			fields: fields{
 				db: &mockDB{
-					MockExec: func(ctx context.Context, q xsql.Query) error { return nil },
+					MockExec: func(ctx context.Context, q xsql.Query) error {
+						switch q.String {
+						case "CREATE USER 'example'@'10.0.0.3' IDENTIFIED BY 'samesame'",
+							"DROP USER IF EXISTS 'example'@'10.0.0.2'",
+							"FLUSH PRIVILEGES":
+							return nil
+						}
+						return errBoom
+					},
 				},
 			},
 			args: args{
@@ -665,38 +1630,66 @@ func TestUpdate(t *testing.T) {
 						ForProvider: v1alpha1.UserParameters{
 							PasswordSecretRef: &xpv1.SecretKeySelector{
 								SecretReference: xpv1.SecretReference{
-									Name: "example",
+									Name: "connection-secret",
 								},
-								Key: "password-custom",
+								Key: xpv1.ResourceCredentialsSecretPasswordKey,
 							},
+							Hosts: []string{"10.0.0.1", "10.0.0.3"},
 						},
-						ResourceSpec: xpv1.ResourceSpec{
-							WriteConnectionSecretToReference: &xpv1.SecretReference{
-								Name: "connection-secret",
-							},
+					},
+					Status: v1alpha1.UserStatus{
+						AtProvider: v1alpha1.UserObservation{
+							Hosts: []string{"10.0.0.1", "10.0.0.2"},
 						},
 					},
 				},
 				kube: &test.MockClient{
 					MockGet: func(_ context.Context, key client.ObjectKey, obj client.Object) error {
-						switch key.Name {
-						case "example":
-							secret := corev1.Secret{
-								Data: map[string][]byte{},
-							}
-							secret.Data["password-custom"] = []byte("newpassword")
-							secret.DeepCopyInto(obj.(*corev1.Secret))
-							return nil
-						case "connection-secret":
-							secret := corev1.Secret{
-								Data: map[string][]byte{},
-							}
-							secret.Data[xpv1.ResourceCredentialsSecretPasswordKey] = []byte("oldpassword")
-							secret.DeepCopyInto(obj.(*corev1.Secret))
-							return nil
-						default:
+						secret := corev1.Secret{
+							Data: map[string][]byte{},
+						}
+						secret.Data[xpv1.ResourceCredentialsSecretPasswordKey] = []byte("samesame")
+						secret.DeepCopyInto(obj.(*corev1.Secret))
+						return nil
+					},
+				},
+			},
+			want: want{
+				err: nil,
+				c:   managed.ExternalUpdate{},
+			},
+		},
+		"HostsAddedWithoutPasswordSecret": {
+			reason: "A new password should be set on all accounts if a host was added and the password is not read from a secret",
+			fields: fields{
+				db: &mockDB{
+					MockExec: func(ctx context.Context, q xsql.Query) error {
+						switch {
+						case strings.HasPrefix(q.String, "CREATE USER 'example'@'10.0.0.2' IDENTIFIED BY "),
+							strings.HasPrefix(q.String, "ALTER USER 'example'@'10.0.0.1' IDENTIFIED BY ") && strings.Contains(q.String, ", 'example'@'10.0.0.2' IDENTIFIED BY "),
+							q.String == "FLUSH PRIVILEGES":
 							return nil
 						}
+						return errBoom
+					},
+				},
+			},
+			args: args{
+				mg: &v1alpha1.User{
+					ObjectMeta: v1.ObjectMeta{
+						Annotations: map[string]string{
+							meta.AnnotationKeyExternalName: "example",
+						},
+					},
+					Spec: v1alpha1.UserSpec{
+						ForProvider: v1alpha1.UserParameters{
+							Hosts: []string{"10.0.0.1", "10.0.0.2"},
+						},
+					},
+					Status: v1alpha1.UserStatus{
+						AtProvider: v1alpha1.UserObservation{
+							Hosts: []string{"10.0.0.1"},
+						},
 					},
 				},
 			},
@@ -705,18 +1698,22 @@ func TestUpdate(t *testing.T) {
 				c: managed.ExternalUpdate{
 					ConnectionDetails: managed.ConnectionDetails{
 						xpv1.ResourceCredentialsSecretUserKey:     []byte("example"),
-						xpv1.ResourceCredentialsSecretPasswordKey: []byte("newpassword"),
 						xpv1.ResourceCredentialsSecretEndpointKey: []byte("localhost"),
 						xpv1.ResourceCredentialsSecretPortKey:     []byte("3306"),
 					},
 				},
 			},
 		},
-		"NoUpdateQueryUnchangedResourceOptions": {
-			reason: "We should not execute an SQL query if the resource options are unchanged.",
+		"AttributesChanged": {
+			reason: "Changed attributes should be set and attributes that are no longer desired removed",
 			fields: fields{
 				db: &mockDB{
-					MockExec: func(ctx context.Context, q xsql.Query) error { return errBoom },
+					MockExec: func(ctx context.Context, q xsql.Query) error {
+						if q.String != `ALTER USER 'example'@'%' ATTRIBUTE '{"comment":"app","example.org/team":"payments","owner":null}'` {
+							return errBoom
+						}
+						return nil
+					},
 				},
 			},
 			args: args{
@@ -724,48 +1721,30 @@ func TestUpdate(t *testing.T) {
 					ObjectMeta: v1.ObjectMeta{
 						Annotations: map[string]string{
 							meta.AnnotationKeyExternalName: "example",
+							"example.org/team":             "payments",
 						},
 					},
 					Spec: v1alpha1.UserSpec{
 						ForProvider: v1alpha1.UserParameters{
-							PasswordSecretRef: &xpv1.SecretKeySelector{
-								SecretReference: xpv1.SecretReference{
-									Name: "connection-secret",
-								},
-								Key: xpv1.ResourceCredentialsSecretPasswordKey,
-							},
-							ResourceOptions: &v1alpha1.ResourceOptions{
-								MaxQueriesPerHour:     new(int),
-								MaxUpdatesPerHour:     new(int),
-								MaxConnectionsPerHour: new(int),
-								MaxUserConnections:    new(int),
+							Comment: pointer.String("app"),
+							AttributesFrom: &v1alpha1.AttributesSource{
+								Annotations: []string{"example.org/team"},
 							},
 						},
 					},
 					Status: v1alpha1.UserStatus{
 						AtProvider: v1alpha1.UserObservation{
-							ResourceOptionsAsClauses: []string{
-								"MAX_QUERIES_PER_HOUR 0",
-								"MAX_UPDATES_PER_HOUR 0",
-								"MAX_CONNECTIONS_PER_HOUR 0",
-								"MAX_USER_CONNECTIONS 0",
+							Attributes: map[string]string{
+								"comment": "old",
+								"owner":   "jane",
 							},
 						},
 					},
 				},
-				kube: &test.MockClient{
-					MockGet: func(_ context.Context, key client.ObjectKey, obj client.Object) error {
-						secret := corev1.Secret{
-							Data: map[string][]byte{},
-						}
-						secret.Data[xpv1.ResourceCredentialsSecretPasswordKey] = []byte("samesame")
-						secret.DeepCopyInto(obj.(*corev1.Secret))
-						return nil
-					},
-				},
 			},
 			want: want{
 				err: nil,
+				c:   managed.ExternalUpdate{},
 			},
 		},
 	}
@@ -843,6 +1822,38 @@ func TestDelete(t *testing.T) {
 				},
 			},
 		},
+		"Hosts": {
+			reason: "The accounts on all desired and observed hosts should be dropped",
+			fields: fields{
+				db: &mockDB{
+					MockExec: func(ctx context.Context, q xsql.Query) error {
+						if q.String != "DROP USER IF EXISTS 'example'@'10.0.0.1', 'example'@'10.0.0.2'" && q.String != "FLUSH PRIVILEGES" {
+							return errBoom
+						}
+						return nil
+					},
+				},
+			},
+			args: args{
+				mg: &v1alpha1.User{
+					ObjectMeta: v1.ObjectMeta{
+						Annotations: map[string]string{
+							meta.AnnotationKeyExternalName: "example",
+						},
+					},
+					Spec: v1alpha1.UserSpec{
+						ForProvider: v1alpha1.UserParameters{
+							Hosts: []string{"10.0.0.1"},
+						},
+					},
+					Status: v1alpha1.UserStatus{
+						AtProvider: v1alpha1.UserObservation{
+							Hosts: []string{"10.0.0.1", "10.0.0.2"},
+						},
+					},
+				},
+			},
+		},
 	}
 
 	for name, tc := range cases {
@@ -855,3 +1866,25 @@ func TestDelete(t *testing.T) {
 		})
 	}
 }
+
+func TestObservedRequireClause(t *testing.T) {
+	cases := map[string]struct {
+		sslType, issuer, subject, cipher string
+		want                             string
+	}{
+		"None":        {want: "REQUIRE NONE"},
+		"SSL":         {sslType: "ANY", want: "REQUIRE SSL"},
+		"X509":        {sslType: "X509", want: "REQUIRE X509"},
+		"Certificate": {sslType: "SPECIFIED", issuer: "/CN=ca", subject: "/CN=example", want: "REQUIRE ISSUER '/CN=ca' AND SUBJECT '/CN=example'"},
+		"Cipher":      {sslType: "SPECIFIED", cipher: "EDH-RSA-DES-CBC3-SHA", want: "REQUIRE CIPHER 'EDH-RSA-DES-CBC3-SHA'"},
+		"Empty":       {sslType: "SPECIFIED", want: "REQUIRE SSL"},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if got := observedRequireClause(tc.sslType, tc.issuer, tc.subject, tc.cipher); got != tc.want {
+				t.Errorf("observedRequireClause(...): want %q, got %q", tc.want, got)
+			}
+		})
+	}
+}
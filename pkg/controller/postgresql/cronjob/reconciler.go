@@ -0,0 +1,329 @@
+/*
+Copyright 2022 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cronjob
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/crossplane-contrib/provider-sql/apis/postgresql/v1alpha1"
+	"github.com/crossplane-contrib/provider-sql/pkg/clients"
+	"github.com/crossplane-contrib/provider-sql/pkg/clients/postgresql"
+	"github.com/crossplane-contrib/provider-sql/pkg/clients/xsql"
+)
+
+const (
+	errTrackPCUsage = "cannot track ProviderConfig usage"
+	errGetPC        = "cannot get ProviderConfig"
+	errNoSecretRef  = "ProviderConfig does not reference a credentials Secret"
+	errGetSecret    = "cannot get credentials Secret"
+
+	errNotCronJob    = "managed resource is not a CronJob custom resource"
+	errSelectJob     = "cannot select cron job"
+	errSelectLastRun = "cannot select last run of cron job"
+	errCreateJob     = "cannot schedule cron job"
+	errUpdateJob     = "cannot alter cron job"
+	errDropJob       = "cannot unschedule cron job"
+	errFmtJobExists  = "a cron job named %s of the role used by the provider already exists"
+
+	maxConcurrency = 5
+)
+
+// Setup adds a controller that reconciles CronJob managed resources.
+func Setup(mgr ctrl.Manager, l logging.Logger) error {
+	name := managed.ControllerName(v1alpha1.CronJobGroupKind)
+
+	t := resource.NewProviderConfigUsageTracker(mgr.GetClient(), &v1alpha1.ProviderConfigUsage{})
+	r := managed.NewReconciler(mgr,
+		resource.ManagedKind(v1alpha1.CronJobGroupVersionKind),
+		managed.WithExternalConnecter(&connector{kube: mgr.GetClient(), usage: t, newDB: postgresql.New}),
+		managed.WithLogger(l.WithValues("controller", name)),
+		managed.WithPollInterval(10*time.Minute),
+		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))))
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		For(&v1alpha1.CronJob{}).
+		WithOptions(controller.Options{
+			MaxConcurrentReconciles: maxConcurrency,
+		}).
+		Complete(r)
+}
+
+type connector struct {
+	kube  client.Client
+	usage resource.Tracker
+	newDB func(creds map[string][]byte, database string, sslmode string) xsql.DB
+}
+
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	cr, ok := mg.(*v1alpha1.CronJob)
+	if !ok {
+		return nil, errors.New(errNotCronJob)
+	}
+
+	if err := c.usage.Track(ctx, mg); err != nil {
+		return nil, errors.Wrap(err, errTrackPCUsage)
+	}
+
+	// ProviderConfigReference could theoretically be nil, but in practice the
+	// DefaultProviderConfig initializer will set it before we get here.
+	pc := &v1alpha1.ProviderConfig{}
+	if err := c.kube.Get(ctx, types.NamespacedName{Name: cr.GetProviderConfigReference().Name}, pc); err != nil {
+		return nil, errors.Wrap(err, errGetPC)
+	}
+
+	// We don't need to check the credentials source because we currently only
+	// support one source (PostgreSQLConnectionSecret), which is required and
+	// enforced by the ProviderConfig schema.
+	ref := pc.Spec.Credentials.ConnectionSecretRef
+	if ref == nil {
+		return nil, errors.New(errNoSecretRef)
+	}
+
+	s := &corev1.Secret{}
+	if err := c.kube.Get(ctx, types.NamespacedName{Namespace: ref.Namespace, Name: ref.Name}, s); err != nil {
+		return nil, errors.Wrap(err, errGetSecret)
+	}
+
+	// pg_cron can only be installed in a single database, in which all jobs
+	// are scheduled regardless of the database they run in.
+	return &external{db: c.newDB(s.Data, pc.Spec.DefaultDatabase, clients.ToString(pc.Spec.SSLMode))}, nil
+}
+
+type external struct{ db xsql.DB }
+
+// jobCondition selects the job named $1 of the role $2, or of the role used by
+// the provider if $2 is NULL. Job names are only unique per role.
+const jobCondition = "jobname = $1 AND username = COALESCE($2, current_user)"
+
+func (c *external) observe(ctx context.Context, name string, username *string) (int64, *v1alpha1.CronJobParameters, error) {
+	observed := &v1alpha1.CronJobParameters{
+		Database: new(string),
+		Username: new(string),
+		Active:   new(bool),
+	}
+
+	query := "SELECT jobid, schedule, command, database, username, active FROM cron.job WHERE " + jobCondition
+
+	var id int64
+	err := c.db.Scan(ctx, xsql.Query{String: query, Parameters: []interface{}{name, username}},
+		&id,
+		&observed.Schedule,
+		&observed.Command,
+		observed.Database,
+		observed.Username,
+		observed.Active,
+	)
+	return id, observed, err
+}
+
+func (c *external) lastRun(ctx context.Context, id int64) (*v1alpha1.CronJobRun, error) {
+	query := "SELECT status, return_message, start_time, end_time " +
+		"FROM cron.job_run_details WHERE jobid = $1 " +
+		"ORDER BY runid DESC LIMIT 1"
+
+	var status, msg *string
+	var start, end *time.Time
+	err := c.db.Scan(ctx, xsql.Query{String: query, Parameters: []interface{}{id}}, &status, &msg, &start, &end)
+	if xsql.IsNoRows(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	run := &v1alpha1.CronJobRun{
+		Status:        clients.ToString(status),
+		ReturnMessage: clients.ToString(msg),
+	}
+	if start != nil {
+		t := metav1.NewTime(*start)
+		run.StartTime = &t
+	}
+	if end != nil {
+		t := metav1.NewTime(*end)
+		run.EndTime = &t
+	}
+	return run, nil
+}
+
+func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.CronJob)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotCronJob)
+	}
+
+	id, observed, err := c.observe(ctx, meta.GetExternalName(cr), cr.Spec.ForProvider.Username)
+	if xsql.IsNoRows(err) && cr.Spec.ForProvider.Username != nil {
+		// cron.schedule would replace a job of the same name of the role
+		// used by the provider rather than create ours.
+		_, _, err = c.observe(ctx, meta.GetExternalName(cr), nil)
+		if err == nil {
+			return managed.ExternalObservation{}, errors.Errorf(errFmtJobExists, meta.GetExternalName(cr))
+		}
+	}
+	if xsql.IsNoRows(err) {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errSelectJob)
+	}
+
+	run, err := c.lastRun(ctx, id)
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errSelectLastRun)
+	}
+
+	cr.Status.AtProvider.JobID = &id
+	cr.Status.AtProvider.LastRun = run
+	cr.SetConditions(xpv1.Available())
+
+	return managed.ExternalObservation{
+		ResourceExists:          true,
+		ResourceLateInitialized: lateInit(observed, &cr.Spec.ForProvider),
+		ResourceUpToDate:        upToDate(observed, cr.Spec.ForProvider),
+	}, nil
+}
+
+func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1alpha1.CronJob)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotCronJob)
+	}
+
+	cr.SetConditions(xpv1.Creating())
+
+	// cron.schedule does not accept a database, user or active flag, so we
+	// pass its job ID straight to cron.alter_job, which ignores NULL values.
+	// cron.schedule replaces any job of the same name of the role used by the
+	// provider, so we check there is none in the same DO block, and thus the
+	// same transaction. DO blocks take no parameters, so values are quoted.
+	gp := cr.Spec.ForProvider
+	name := pq.QuoteLiteral(meta.GetExternalName(cr))
+	body := fmt.Sprintf("BEGIN "+
+		"IF EXISTS (SELECT 1 FROM cron.job WHERE jobname = %[1]s AND username = current_user) THEN "+
+		"RAISE EXCEPTION 'a cron job named %% of the role used by the provider already exists', %[1]s; "+
+		"END IF; "+
+		"PERFORM cron.alter_job(cron.schedule(%[1]s, %[2]s, %[3]s), database := %[4]s, username := %[5]s, active := %[6]s); "+
+		"END",
+		name, pq.QuoteLiteral(gp.Schedule), pq.QuoteLiteral(gp.Command),
+		quoteLiteral(gp.Database), quoteLiteral(gp.Username), boolLiteral(gp.Active))
+
+	err := c.db.Exec(ctx, xsql.Query{String: "DO " + pq.QuoteLiteral(body)})
+	return managed.ExternalCreation{}, errors.Wrap(err, errCreateJob)
+}
+
+// quoteLiteral quotes the supplied value as a literal, or returns NULL.
+func quoteLiteral(s *string) string {
+	if s == nil {
+		return "NULL"
+	}
+	return pq.QuoteLiteral(*s)
+}
+
+// boolLiteral returns the supplied value as a literal, or NULL.
+func boolLiteral(b *bool) string {
+	if b == nil {
+		return "NULL"
+	}
+	return strconv.FormatBool(*b)
+}
+
+func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*v1alpha1.CronJob)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotCronJob)
+	}
+
+	gp := cr.Spec.ForProvider
+	query := "SELECT cron.alter_job(jobid, schedule := $3, command := $4, " +
+		"database := $5, active := $6) " +
+		"FROM cron.job WHERE " + jobCondition
+
+	err := c.db.Exec(ctx, xsql.Query{
+		String:     query,
+		Parameters: []interface{}{meta.GetExternalName(cr), gp.Username, gp.Schedule, gp.Command, gp.Database, gp.Active},
+	})
+	return managed.ExternalUpdate{}, errors.Wrap(err, errUpdateJob)
+}
+
+func (c *external) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1alpha1.CronJob)
+	if !ok {
+		return errors.New(errNotCronJob)
+	}
+
+	cr.SetConditions(xpv1.Deleting())
+
+	// cron.unschedule raises an error for unknown job names, so we only call
+	// it for jobs that still exist.
+	query := "SELECT cron.unschedule(jobid) FROM cron.job WHERE " + jobCondition
+
+	err := c.db.Exec(ctx, xsql.Query{String: query, Parameters: []interface{}{meta.GetExternalName(cr), cr.Spec.ForProvider.Username}})
+	return errors.Wrap(err, errDropJob)
+}
+
+func upToDate(observed *v1alpha1.CronJobParameters, desired v1alpha1.CronJobParameters) bool {
+	if observed.Schedule != desired.Schedule || observed.Command != desired.Command {
+		return false
+	}
+	if desired.Database != nil && *observed.Database != *desired.Database {
+		return false
+	}
+	if desired.Active != nil && *observed.Active != *desired.Active {
+		return false
+	}
+	return true
+}
+
+func lateInit(observed *v1alpha1.CronJobParameters, desired *v1alpha1.CronJobParameters) bool {
+	li := false
+
+	if desired.Database == nil {
+		desired.Database = observed.Database
+		li = true
+	}
+	if desired.Username == nil {
+		desired.Username = observed.Username
+		li = true
+	}
+	if desired.Active == nil {
+		desired.Active = observed.Active
+		li = true
+	}
+
+	return li
+}
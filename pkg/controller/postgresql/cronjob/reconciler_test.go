@@ -0,0 +1,693 @@
+/*
+Copyright 2022 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cronjob
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane-contrib/provider-sql/apis/postgresql/v1alpha1"
+	"github.com/crossplane-contrib/provider-sql/pkg/clients/xsql"
+)
+
+type mockDB struct {
+	MockExec                 func(ctx context.Context, q xsql.Query) error
+	MockExecTx               func(ctx context.Context, ql []xsql.Query) error
+	MockScan                 func(ctx context.Context, q xsql.Query, dest ...interface{}) error
+	MockGetConnectionDetails func(username, password string) managed.ConnectionDetails
+}
+
+func (m mockDB) Exec(ctx context.Context, q xsql.Query) error {
+	return m.MockExec(ctx, q)
+}
+func (m mockDB) ExecTx(ctx context.Context, ql []xsql.Query) error {
+	return m.MockExecTx(ctx, ql)
+}
+func (m mockDB) Scan(ctx context.Context, q xsql.Query, dest ...interface{}) error {
+	return m.MockScan(ctx, q, dest...)
+}
+func (m mockDB) Query(ctx context.Context, q xsql.Query) (*sql.Rows, error) {
+	return &sql.Rows{}, nil
+}
+func (m mockDB) GetConnectionDetails(username, password string) managed.ConnectionDetails {
+	return m.MockGetConnectionDetails(username, password)
+}
+
+// scanJob returns a MockScan that reports a job with the supplied schedule,
+// and a single failed run if run is true. Jobs must be selected by name and
+// username.
+func scanJob(schedule string, run bool) func(ctx context.Context, q xsql.Query, dest ...interface{}) error {
+	return func(ctx context.Context, q xsql.Query, dest ...interface{}) error {
+		if len(dest) == 4 {
+			if !run {
+				return sql.ErrNoRows
+			}
+			*dest[0].(**string) = pointer.String("failed")
+			*dest[1].(**string) = pointer.String("boom")
+			*dest[2].(**time.Time) = &time.Time{}
+			return nil
+		}
+		if !strings.HasSuffix(q.String, "WHERE "+jobCondition) || len(q.Parameters) != 2 {
+			return errors.Errorf("unexpected query: %s", q.String)
+		}
+		*dest[0].(*int64) = 42
+		*dest[1].(*string) = schedule
+		*dest[2].(*string) = "VACUUM"
+		*dest[3].(*string) = "postgres"
+		*dest[4].(*string) = "postgres"
+		*dest[5].(*bool) = true
+		return nil
+	}
+}
+
+func TestConnect(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type fields struct {
+		kube  client.Client
+		usage resource.Tracker
+		newDB func(creds map[string][]byte, database string, sslmode string) xsql.DB
+	}
+
+	type args struct {
+		ctx context.Context
+		mg  resource.Managed
+	}
+
+	cases := map[string]struct {
+		reason string
+		fields fields
+		args   args
+		want   error
+	}{
+		"ErrNotCronJob": {
+			reason: "An error should be returned if the managed resource is not a CronJob",
+			args: args{
+				mg: nil,
+			},
+			want: errors.New(errNotCronJob),
+		},
+		"ErrTrackProviderConfigUsage": {
+			reason: "An error should be returned if we can't track our ProviderConfig usage",
+			fields: fields{
+				usage: resource.TrackerFn(func(ctx context.Context, mg resource.Managed) error { return errBoom }),
+			},
+			args: args{
+				mg: &v1alpha1.CronJob{},
+			},
+			want: errors.Wrap(errBoom, errTrackPCUsage),
+		},
+		"ErrGetProviderConfig": {
+			reason: "An error should be returned if we can't get our ProviderConfig",
+			fields: fields{
+				kube: &test.MockClient{
+					MockGet: test.NewMockGetFn(errBoom),
+				},
+				usage: resource.TrackerFn(func(ctx context.Context, mg resource.Managed) error { return nil }),
+			},
+			args: args{
+				mg: &v1alpha1.CronJob{
+					Spec: v1alpha1.CronJobSpec{
+						ResourceSpec: xpv1.ResourceSpec{
+							ProviderConfigReference: &xpv1.Reference{},
+						},
+					},
+				},
+			},
+			want: errors.Wrap(errBoom, errGetPC),
+		},
+		"ErrMissingConnectionSecret": {
+			reason: "An error should be returned if our ProviderConfig doesn't specify a connection secret",
+			fields: fields{
+				kube: &test.MockClient{
+					// We call get to populate the Database struct, then again
+					// to populate the (empty) ProviderConfig struct, resulting
+					// in a ProviderConfig with a nil connection secret.
+					MockGet: test.NewMockGetFn(nil),
+				},
+				usage: resource.TrackerFn(func(ctx context.Context, mg resource.Managed) error { return nil }),
+			},
+			args: args{
+				mg: &v1alpha1.CronJob{
+					Spec: v1alpha1.CronJobSpec{
+						ResourceSpec: xpv1.ResourceSpec{
+							ProviderConfigReference: &xpv1.Reference{},
+						},
+					},
+				},
+			},
+			want: errors.New(errNoSecretRef),
+		},
+		"ErrGetConnectionSecret": {
+			reason: "An error should be returned if we can't get our ProviderConfig's connection secret",
+			fields: fields{
+				kube: &test.MockClient{
+					MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+						switch o := obj.(type) {
+						case *v1alpha1.ProviderConfig:
+							o.Spec.Credentials.ConnectionSecretRef = &xpv1.SecretReference{}
+						case *corev1.Secret:
+							return errBoom
+						}
+						return nil
+					}),
+				},
+				usage: resource.TrackerFn(func(ctx context.Context, mg resource.Managed) error { return nil }),
+			},
+			args: args{
+				mg: &v1alpha1.CronJob{
+					Spec: v1alpha1.CronJobSpec{
+						ResourceSpec: xpv1.ResourceSpec{
+							ProviderConfigReference: &xpv1.Reference{},
+						},
+					},
+				},
+			},
+			want: errors.Wrap(errBoom, errGetSecret),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := &connector{kube: tc.fields.kube, usage: tc.fields.usage, newDB: tc.fields.newDB}
+			_, err := e.Connect(tc.args.ctx, tc.args.mg)
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Connect(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestObserve(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type fields struct {
+		db xsql.DB
+	}
+
+	type args struct {
+		ctx context.Context
+		mg  resource.Managed
+	}
+
+	type want struct {
+		o   managed.ExternalObservation
+		mg  resource.Managed
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		fields fields
+		args   args
+		want   want
+	}{
+		"ErrNotCronJob": {
+			reason: "An error should be returned if the managed resource is not a CronJob",
+			args: args{
+				mg: nil,
+			},
+			want: want{
+				err: errors.New(errNotCronJob),
+			},
+		},
+		"ErrNoJob": {
+			reason: "We should return ResourceExists: false when no job is found",
+			fields: fields{
+				db: mockDB{
+					MockScan: func(ctx context.Context, q xsql.Query, dest ...interface{}) error { return sql.ErrNoRows },
+				},
+			},
+			args: args{
+				mg: &v1alpha1.CronJob{},
+			},
+			want: want{
+				o:  managed.ExternalObservation{ResourceExists: false},
+				mg: &v1alpha1.CronJob{},
+			},
+		},
+		"ErrProviderRoleJobExists": {
+			reason: "We should return an error if the role used by the provider has a job of the same name that scheduling ours would replace",
+			fields: fields{
+				db: mockDB{
+					MockScan: func(ctx context.Context, q xsql.Query, dest ...interface{}) error {
+						if q.Parameters[1] != (*string)(nil) {
+							return sql.ErrNoRows
+						}
+						return nil
+					},
+				},
+			},
+			args: args{
+				mg: &v1alpha1.CronJob{
+					ObjectMeta: metav1.ObjectMeta{
+						Annotations: map[string]string{meta.AnnotationKeyExternalName: "vacuum"},
+					},
+					Spec: v1alpha1.CronJobSpec{
+						ForProvider: v1alpha1.CronJobParameters{Username: pointer.String("app")},
+					},
+				},
+			},
+			want: want{
+				err: errors.Errorf(errFmtJobExists, "vacuum"),
+				mg: &v1alpha1.CronJob{
+					ObjectMeta: metav1.ObjectMeta{
+						Annotations: map[string]string{meta.AnnotationKeyExternalName: "vacuum"},
+					},
+					Spec: v1alpha1.CronJobSpec{
+						ForProvider: v1alpha1.CronJobParameters{Username: pointer.String("app")},
+					},
+				},
+			},
+		},
+		"ErrSelectJob": {
+			reason: "We should return any errors encountered while trying to select the job",
+			fields: fields{
+				db: mockDB{
+					MockScan: func(ctx context.Context, q xsql.Query, dest ...interface{}) error { return errBoom },
+				},
+			},
+			args: args{
+				mg: &v1alpha1.CronJob{},
+			},
+			want: want{
+				err: errors.Wrap(errBoom, errSelectJob),
+				mg:  &v1alpha1.CronJob{},
+			},
+		},
+		"ErrSelectLastRun": {
+			reason: "We should return any errors encountered while trying to select the last run of the job",
+			fields: fields{
+				db: mockDB{
+					MockScan: func(ctx context.Context, q xsql.Query, dest ...interface{}) error {
+						if len(dest) == 4 {
+							return errBoom
+						}
+						return nil
+					},
+				},
+			},
+			args: args{
+				mg: &v1alpha1.CronJob{},
+			},
+			want: want{
+				err: errors.Wrap(errBoom, errSelectLastRun),
+				mg:  &v1alpha1.CronJob{},
+			},
+		},
+		"SuccessLateInit": {
+			reason: "Unset parameters should be late initialized from the observed job",
+			fields: fields{
+				db: mockDB{
+					MockScan: scanJob("0 3 * * *", false),
+				},
+			},
+			args: args{
+				mg: &v1alpha1.CronJob{
+					Spec: v1alpha1.CronJobSpec{
+						ForProvider: v1alpha1.CronJobParameters{
+							Schedule: "0 3 * * *",
+							Command:  "VACUUM",
+						},
+					},
+				},
+			},
+			want: want{
+				o: managed.ExternalObservation{
+					ResourceExists:          true,
+					ResourceLateInitialized: true,
+					ResourceUpToDate:        true,
+				},
+				mg: &v1alpha1.CronJob{
+					Spec: v1alpha1.CronJobSpec{
+						ForProvider: v1alpha1.CronJobParameters{
+							Schedule: "0 3 * * *",
+							Command:  "VACUUM",
+							Database: pointer.String("postgres"),
+							Username: pointer.String("postgres"),
+							Active:   pointer.Bool(true),
+						},
+					},
+					Status: v1alpha1.CronJobStatus{
+						ResourceStatus: xpv1.ResourceStatus{
+							ConditionedStatus: xpv1.ConditionedStatus{
+								Conditions: []xpv1.Condition{xpv1.Available()},
+							},
+						},
+						AtProvider: v1alpha1.CronJobObservation{JobID: pointer.Int64(42)},
+					},
+				},
+			},
+		},
+		"NotUpToDate": {
+			reason: "We should return ResourceUpToDate: false and the last run when the schedule differs",
+			fields: fields{
+				db: mockDB{
+					MockScan: scanJob("0 4 * * *", true),
+				},
+			},
+			args: args{
+				mg: &v1alpha1.CronJob{
+					Spec: v1alpha1.CronJobSpec{
+						ForProvider: v1alpha1.CronJobParameters{
+							Schedule: "0 3 * * *",
+							Command:  "VACUUM",
+							Database: pointer.String("postgres"),
+							Username: pointer.String("postgres"),
+							Active:   pointer.Bool(true),
+						},
+					},
+				},
+			},
+			want: want{
+				o: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: false,
+				},
+				mg: &v1alpha1.CronJob{
+					Spec: v1alpha1.CronJobSpec{
+						ForProvider: v1alpha1.CronJobParameters{
+							Schedule: "0 3 * * *",
+							Command:  "VACUUM",
+							Database: pointer.String("postgres"),
+							Username: pointer.String("postgres"),
+							Active:   pointer.Bool(true),
+						},
+					},
+					Status: v1alpha1.CronJobStatus{
+						ResourceStatus: xpv1.ResourceStatus{
+							ConditionedStatus: xpv1.ConditionedStatus{
+								Conditions: []xpv1.Condition{xpv1.Available()},
+							},
+						},
+						AtProvider: v1alpha1.CronJobObservation{
+							JobID: pointer.Int64(42),
+							LastRun: &v1alpha1.CronJobRun{
+								Status:        "failed",
+								ReturnMessage: "boom",
+								StartTime:     &metav1.Time{},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{db: tc.fields.db}
+			got, err := e.Observe(tc.args.ctx, tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, got); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.mg, tc.args.mg, test.EquateConditions()); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want managed resource, +got managed resource:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestCreate(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type fields struct {
+		db xsql.DB
+	}
+
+	type args struct {
+		ctx context.Context
+		mg  resource.Managed
+	}
+
+	type want struct {
+		c   managed.ExternalCreation
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		fields fields
+		args   args
+		want   want
+	}{
+		"ErrNotCronJob": {
+			reason: "An error should be returned if the managed resource is not a CronJob",
+			args: args{
+				mg: nil,
+			},
+			want: want{
+				err: errors.New(errNotCronJob),
+			},
+		},
+		"ErrExec": {
+			reason: "Any errors encountered while scheduling the job should be returned",
+			fields: fields{
+				db: &mockDB{
+					MockExec: func(ctx context.Context, q xsql.Query) error { return errBoom },
+				},
+			},
+			args: args{
+				mg: &v1alpha1.CronJob{},
+			},
+			want: want{
+				err: errors.Wrap(errBoom, errCreateJob),
+			},
+		},
+		"Success": {
+			reason: "The job should only be scheduled if the role used by the provider has no job of the same name",
+			fields: fields{
+				db: &mockDB{
+					MockExec: func(ctx context.Context, q xsql.Query) error {
+						want := "DO 'BEGIN " +
+							"IF EXISTS (SELECT 1 FROM cron.job WHERE jobname = ''vacuum'' AND username = current_user) THEN " +
+							"RAISE EXCEPTION ''a cron job named % of the role used by the provider already exists'', ''vacuum''; " +
+							"END IF; " +
+							"PERFORM cron.alter_job(cron.schedule(''vacuum'', ''0 3 * * *'', ''VACUUM ''''app''''''), database := ''app'', username := NULL, active := false); " +
+							"END'"
+						if q.String != want {
+							return errors.Errorf("unexpected query: %s", q.String)
+						}
+						return nil
+					},
+				},
+			},
+			args: args{
+				mg: &v1alpha1.CronJob{
+					ObjectMeta: metav1.ObjectMeta{
+						Annotations: map[string]string{meta.AnnotationKeyExternalName: "vacuum"},
+					},
+					Spec: v1alpha1.CronJobSpec{
+						ForProvider: v1alpha1.CronJobParameters{
+							Schedule: "0 3 * * *",
+							Command:  "VACUUM 'app'",
+							Database: pointer.String("app"),
+							Active:   pointer.Bool(false),
+						},
+					},
+				},
+			},
+			want: want{
+				err: nil,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{db: tc.fields.db}
+			got, err := e.Create(tc.args.ctx, tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Create(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.c, got); diff != "" {
+				t.Errorf("\n%s\ne.Create(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type fields struct {
+		db xsql.DB
+	}
+
+	type args struct {
+		ctx context.Context
+		mg  resource.Managed
+	}
+
+	type want struct {
+		c   managed.ExternalUpdate
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		fields fields
+		args   args
+		want   want
+	}{
+		"ErrNotCronJob": {
+			reason: "An error should be returned if the managed resource is not a CronJob",
+			args: args{
+				mg: nil,
+			},
+			want: want{
+				err: errors.New(errNotCronJob),
+			},
+		},
+		"ErrExec": {
+			reason: "Any errors encountered while altering the job should be returned",
+			fields: fields{
+				db: &mockDB{
+					MockExec: func(ctx context.Context, q xsql.Query) error { return errBoom },
+				},
+			},
+			args: args{
+				mg: &v1alpha1.CronJob{},
+			},
+			want: want{
+				err: errors.Wrap(errBoom, errUpdateJob),
+			},
+		},
+		"Success": {
+			reason: "No error should be returned when we successfully alter a job",
+			fields: fields{
+				db: &mockDB{
+					MockExec: func(ctx context.Context, q xsql.Query) error {
+						if u, _ := q.Parameters[1].(*string); !strings.HasSuffix(q.String, "FROM cron.job WHERE "+jobCondition) || u == nil || *u != "app" {
+							return errors.Errorf("unexpected query: %s", q.String)
+						}
+						return nil
+					},
+				},
+			},
+			args: args{
+				mg: &v1alpha1.CronJob{
+					Spec: v1alpha1.CronJobSpec{
+						ForProvider: v1alpha1.CronJobParameters{Username: pointer.String("app")},
+					},
+				},
+			},
+			want: want{
+				err: nil,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{db: tc.fields.db}
+			got, err := e.Update(tc.args.ctx, tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Update(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.c, got); diff != "" {
+				t.Errorf("\n%s\ne.Update(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestDelete(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type fields struct {
+		db xsql.DB
+	}
+
+	type args struct {
+		ctx context.Context
+		mg  resource.Managed
+	}
+
+	cases := map[string]struct {
+		reason string
+		fields fields
+		args   args
+		want   error
+	}{
+		"ErrNotCronJob": {
+			reason: "An error should be returned if the managed resource is not a CronJob",
+			args: args{
+				mg: nil,
+			},
+			want: errors.New(errNotCronJob),
+		},
+		"ErrDropJob": {
+			reason: "Errors unscheduling a job should be returned",
+			fields: fields{
+				db: &mockDB{
+					MockExec: func(ctx context.Context, q xsql.Query) error { return errBoom },
+				},
+			},
+			args: args{
+				mg: &v1alpha1.CronJob{},
+			},
+			want: errors.Wrap(errBoom, errDropJob),
+		},
+		"Success": {
+			reason: "No error should be returned if the job of the role used by the provider was unscheduled",
+			fields: fields{
+				db: &mockDB{
+					MockExec: func(ctx context.Context, q xsql.Query) error {
+						if u, _ := q.Parameters[1].(*string); !strings.HasSuffix(q.String, "WHERE "+jobCondition) || u != nil {
+							return errors.Errorf("unexpected query: %s", q.String)
+						}
+						return nil
+					},
+				},
+			},
+			args: args{
+				mg: &v1alpha1.CronJob{},
+			},
+			want: nil,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{db: tc.fields.db}
+			err := e.Delete(tc.args.ctx, tc.args.mg)
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Delete(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
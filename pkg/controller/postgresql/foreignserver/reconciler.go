@@ -0,0 +1,327 @@
+/*
+Copyright 2022 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package foreignserver
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/crossplane-contrib/provider-sql/apis/postgresql/v1alpha1"
+	"github.com/crossplane-contrib/provider-sql/pkg/clients"
+	"github.com/crossplane-contrib/provider-sql/pkg/clients/postgresql"
+	"github.com/crossplane-contrib/provider-sql/pkg/clients/xsql"
+)
+
+const (
+	errTrackPCUsage = "cannot track ProviderConfig usage"
+	errGetPC        = "cannot get ProviderConfig"
+	errNoSecretRef  = "ProviderConfig does not reference a credentials Secret"
+	errGetSecret    = "cannot get credentials Secret"
+
+	errNotForeignServer = "managed resource is not a ForeignServer custom resource"
+	errSelectServer     = "cannot select foreign server"
+	errCreateServer     = "cannot create foreign server"
+	errUpdateServer     = "cannot update foreign server"
+	errDropServer       = "cannot drop foreign server"
+	errNoWrapper        = "foreign data wrapper not passed or could not be resolved"
+
+	maxConcurrency = 5
+)
+
+// Setup adds a controller that reconciles ForeignServer managed resources.
+func Setup(mgr ctrl.Manager, l logging.Logger) error {
+	name := managed.ControllerName(v1alpha1.ForeignServerGroupKind)
+
+	t := resource.NewProviderConfigUsageTracker(mgr.GetClient(), &v1alpha1.ProviderConfigUsage{})
+	r := managed.NewReconciler(mgr,
+		resource.ManagedKind(v1alpha1.ForeignServerGroupVersionKind),
+		managed.WithExternalConnecter(&connector{kube: mgr.GetClient(), usage: t, newDB: postgresql.New}),
+		managed.WithLogger(l.WithValues("controller", name)),
+		managed.WithPollInterval(10*time.Minute),
+		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))))
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		For(&v1alpha1.ForeignServer{}).
+		WithOptions(controller.Options{
+			MaxConcurrentReconciles: maxConcurrency,
+		}).
+		Complete(r)
+}
+
+type connector struct {
+	kube  client.Client
+	usage resource.Tracker
+	newDB func(creds map[string][]byte, database string, sslmode string) xsql.DB
+}
+
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	cr, ok := mg.(*v1alpha1.ForeignServer)
+	if !ok {
+		return nil, errors.New(errNotForeignServer)
+	}
+
+	if err := c.usage.Track(ctx, mg); err != nil {
+		return nil, errors.Wrap(err, errTrackPCUsage)
+	}
+
+	// ProviderConfigReference could theoretically be nil, but in practice the
+	// DefaultProviderConfig initializer will set it before we get here.
+	pc := &v1alpha1.ProviderConfig{}
+	if err := c.kube.Get(ctx, types.NamespacedName{Name: cr.GetProviderConfigReference().Name}, pc); err != nil {
+		return nil, errors.Wrap(err, errGetPC)
+	}
+
+	// We don't need to check the credentials source because we currently only
+	// support one source (PostgreSQLConnectionSecret), which is required and
+	// enforced by the ProviderConfig schema.
+	ref := pc.Spec.Credentials.ConnectionSecretRef
+	if ref == nil {
+		return nil, errors.New(errNoSecretRef)
+	}
+
+	s := &corev1.Secret{}
+	if err := c.kube.Get(ctx, types.NamespacedName{Namespace: ref.Namespace, Name: ref.Name}, s); err != nil {
+		return nil, errors.Wrap(err, errGetSecret)
+	}
+
+	// Foreign servers are local to a database, so we must not fall back to
+	// the default database if the user expected one to be resolved.
+	if cr.Spec.ForProvider.Database != nil {
+		return &external{db: c.newDB(s.Data, *cr.Spec.ForProvider.Database, clients.ToString(pc.Spec.SSLMode))}, nil
+	}
+
+	return &external{db: c.newDB(s.Data, pc.Spec.DefaultDatabase, clients.ToString(pc.Spec.SSLMode))}, nil
+}
+
+type external struct{ db xsql.DB }
+
+func (c *external) observe(ctx context.Context, name string) (*v1alpha1.ForeignServerParameters, error) {
+	observed := &v1alpha1.ForeignServerParameters{
+		ForeignDataWrapper: new(string),
+		Owner:              new(string),
+	}
+
+	query := "SELECT " +
+		"w.fdwname, " +
+		"s.srvtype, " +
+		"s.srvversion, " +
+		"pg_catalog.pg_get_userbyid(s.srvowner), " +
+		"s.srvoptions " +
+		"FROM pg_foreign_server AS s " +
+		"INNER JOIN pg_foreign_data_wrapper AS w ON s.srvfdw = w.oid " +
+		"WHERE s.srvname = $1"
+
+	var opts []string
+	err := c.db.Scan(ctx, xsql.Query{String: query, Parameters: []interface{}{name}},
+		observed.ForeignDataWrapper,
+		&observed.Type,
+		&observed.Version,
+		observed.Owner,
+		pq.Array(&opts),
+	)
+	if err != nil {
+		return nil, err
+	}
+	observed.Options = optionsFromMap(postgresql.ParseOptions(opts))
+	return observed, nil
+}
+
+func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.ForeignServer)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotForeignServer)
+	}
+
+	observed, err := c.observe(ctx, meta.GetExternalName(cr))
+
+	// If the database we try to connect on does not exist then
+	// there cannot be a foreign server in that database either.
+	if xsql.IsNoRows(err) || postgresql.IsInvalidCatalog(err) {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errSelectServer)
+	}
+
+	cr.SetConditions(xpv1.Available())
+
+	return managed.ExternalObservation{
+		ResourceExists:          true,
+		ResourceLateInitialized: lateInit(observed, &cr.Spec.ForProvider),
+		ResourceUpToDate:        upToDate(observed, cr.Spec.ForProvider),
+	}, nil
+}
+
+func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1alpha1.ForeignServer)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotForeignServer)
+	}
+
+	if cr.Spec.ForProvider.ForeignDataWrapper == nil {
+		return managed.ExternalCreation{}, errors.New(errNoWrapper)
+	}
+
+	cr.SetConditions(xpv1.Creating())
+
+	srv := pq.QuoteIdentifier(meta.GetExternalName(cr))
+
+	var b strings.Builder
+	b.WriteString("CREATE SERVER ")
+	b.WriteString(srv)
+	if cr.Spec.ForProvider.Type != nil {
+		b.WriteString(" TYPE ")
+		b.WriteString(pq.QuoteLiteral(*cr.Spec.ForProvider.Type))
+	}
+	if cr.Spec.ForProvider.Version != nil {
+		b.WriteString(" VERSION ")
+		b.WriteString(pq.QuoteLiteral(*cr.Spec.ForProvider.Version))
+	}
+	b.WriteString(" FOREIGN DATA WRAPPER ")
+	b.WriteString(pq.QuoteIdentifier(*cr.Spec.ForProvider.ForeignDataWrapper))
+	if o := postgresql.OptionsClause(optionsToMap(cr.Spec.ForProvider.Options)); o != "" {
+		b.WriteString(" ")
+		b.WriteString(o)
+	}
+
+	ql := []xsql.Query{{String: b.String()}}
+	if cr.Spec.ForProvider.Owner != nil {
+		ql = append(ql, xsql.Query{String: fmt.Sprintf("ALTER SERVER %s OWNER TO %s", srv, pq.QuoteIdentifier(*cr.Spec.ForProvider.Owner))})
+	}
+
+	return managed.ExternalCreation{}, errors.Wrap(c.db.ExecTx(ctx, ql), errCreateServer)
+}
+
+func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*v1alpha1.ForeignServer)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotForeignServer)
+	}
+
+	observed, err := c.observe(ctx, meta.GetExternalName(cr))
+	if err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errSelectServer)
+	}
+
+	srv := pq.QuoteIdentifier(meta.GetExternalName(cr))
+	desired := cr.Spec.ForProvider
+
+	ql := []xsql.Query{}
+	if desired.Version != nil && clients.ToString(observed.Version) != *desired.Version {
+		ql = append(ql, xsql.Query{String: fmt.Sprintf("ALTER SERVER %s VERSION %s", srv, pq.QuoteLiteral(*desired.Version))})
+	}
+	if desired.Options != nil {
+		if o := postgresql.AlterOptionsClause(optionsToMap(observed.Options), optionsToMap(desired.Options)); o != "" {
+			ql = append(ql, xsql.Query{String: fmt.Sprintf("ALTER SERVER %s %s", srv, o)})
+		}
+	}
+	if desired.Owner != nil && *observed.Owner != *desired.Owner {
+		ql = append(ql, xsql.Query{String: fmt.Sprintf("ALTER SERVER %s OWNER TO %s", srv, pq.QuoteIdentifier(*desired.Owner))})
+	}
+	if len(ql) == 0 {
+		return managed.ExternalUpdate{}, nil
+	}
+
+	return managed.ExternalUpdate{}, errors.Wrap(c.db.ExecTx(ctx, ql), errUpdateServer)
+}
+
+func (c *external) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1alpha1.ForeignServer)
+	if !ok {
+		return errors.New(errNotForeignServer)
+	}
+
+	cr.SetConditions(xpv1.Deleting())
+
+	err := c.db.Exec(ctx, xsql.Query{String: "DROP SERVER IF EXISTS " + pq.QuoteIdentifier(meta.GetExternalName(cr))})
+	return errors.Wrap(err, errDropServer)
+}
+
+func optionsToMap(opts []v1alpha1.ForeignOption) map[string]string {
+	m := make(map[string]string, len(opts))
+	for _, o := range opts {
+		m[o.Name] = o.Value
+	}
+	return m
+}
+
+func optionsFromMap(m map[string]string) []v1alpha1.ForeignOption {
+	if len(m) == 0 {
+		return nil
+	}
+	opts := make([]v1alpha1.ForeignOption, 0, len(m))
+	for k, v := range m {
+		opts = append(opts, v1alpha1.ForeignOption{Name: k, Value: v})
+	}
+	return opts
+}
+
+func upToDate(observed *v1alpha1.ForeignServerParameters, desired v1alpha1.ForeignServerParameters) bool {
+	if desired.Version != nil && clients.ToString(observed.Version) != *desired.Version {
+		return false
+	}
+	if desired.Owner != nil && clients.ToString(observed.Owner) != *desired.Owner {
+		return false
+	}
+	if desired.Options == nil {
+		return true
+	}
+	return cmp.Equal(optionsToMap(observed.Options), optionsToMap(desired.Options), cmpopts.EquateEmpty())
+}
+
+func lateInit(observed *v1alpha1.ForeignServerParameters, desired *v1alpha1.ForeignServerParameters) bool {
+	li := false
+
+	if desired.ForeignDataWrapper == nil {
+		desired.ForeignDataWrapper = observed.ForeignDataWrapper
+		li = true
+	}
+	if desired.Type == nil && observed.Type != nil {
+		desired.Type = observed.Type
+		li = true
+	}
+	if desired.Version == nil && observed.Version != nil {
+		desired.Version = observed.Version
+		li = true
+	}
+	if desired.Owner == nil {
+		desired.Owner = observed.Owner
+		li = true
+	}
+
+	return li
+}
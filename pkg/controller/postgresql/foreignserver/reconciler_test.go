@@ -0,0 +1,677 @@
+/*
+Copyright 2022 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package foreignserver
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane-contrib/provider-sql/apis/postgresql/v1alpha1"
+	"github.com/crossplane-contrib/provider-sql/pkg/clients/xsql"
+)
+
+type mockDB struct {
+	MockExec                 func(ctx context.Context, q xsql.Query) error
+	MockExecTx               func(ctx context.Context, ql []xsql.Query) error
+	MockScan                 func(ctx context.Context, q xsql.Query, dest ...interface{}) error
+	MockGetConnectionDetails func(username, password string) managed.ConnectionDetails
+}
+
+func (m mockDB) Exec(ctx context.Context, q xsql.Query) error {
+	return m.MockExec(ctx, q)
+}
+func (m mockDB) ExecTx(ctx context.Context, ql []xsql.Query) error {
+	return m.MockExecTx(ctx, ql)
+}
+func (m mockDB) Scan(ctx context.Context, q xsql.Query, dest ...interface{}) error {
+	return m.MockScan(ctx, q, dest...)
+}
+func (m mockDB) Query(ctx context.Context, q xsql.Query) (*sql.Rows, error) {
+	return &sql.Rows{}, nil
+}
+func (m mockDB) GetConnectionDetails(username, password string) managed.ConnectionDetails {
+	return m.MockGetConnectionDetails(username, password)
+}
+
+// scanServer returns a MockScan that reports a foreign server owned by the
+// supplied role with the supplied options.
+func scanServer(owner string, opts ...string) func(ctx context.Context, q xsql.Query, dest ...interface{}) error {
+	return func(ctx context.Context, q xsql.Query, dest ...interface{}) error {
+		*dest[0].(*string) = "postgres_fdw"
+		*dest[3].(*string) = owner
+		*dest[4].(*pq.StringArray) = opts
+		return nil
+	}
+}
+
+func TestConnect(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type fields struct {
+		kube  client.Client
+		usage resource.Tracker
+		newDB func(creds map[string][]byte, database string, sslmode string) xsql.DB
+	}
+
+	type args struct {
+		ctx context.Context
+		mg  resource.Managed
+	}
+
+	cases := map[string]struct {
+		reason string
+		fields fields
+		args   args
+		want   error
+	}{
+		"ErrNotForeignServer": {
+			reason: "An error should be returned if the managed resource is not a ForeignServer",
+			args: args{
+				mg: nil,
+			},
+			want: errors.New(errNotForeignServer),
+		},
+		"ErrTrackProviderConfigUsage": {
+			reason: "An error should be returned if we can't track our ProviderConfig usage",
+			fields: fields{
+				usage: resource.TrackerFn(func(ctx context.Context, mg resource.Managed) error { return errBoom }),
+			},
+			args: args{
+				mg: &v1alpha1.ForeignServer{},
+			},
+			want: errors.Wrap(errBoom, errTrackPCUsage),
+		},
+		"ErrGetProviderConfig": {
+			reason: "An error should be returned if we can't get our ProviderConfig",
+			fields: fields{
+				kube: &test.MockClient{
+					MockGet: test.NewMockGetFn(errBoom),
+				},
+				usage: resource.TrackerFn(func(ctx context.Context, mg resource.Managed) error { return nil }),
+			},
+			args: args{
+				mg: &v1alpha1.ForeignServer{
+					Spec: v1alpha1.ForeignServerSpec{
+						ResourceSpec: xpv1.ResourceSpec{
+							ProviderConfigReference: &xpv1.Reference{},
+						},
+					},
+				},
+			},
+			want: errors.Wrap(errBoom, errGetPC),
+		},
+		"ErrMissingConnectionSecret": {
+			reason: "An error should be returned if our ProviderConfig doesn't specify a connection secret",
+			fields: fields{
+				kube: &test.MockClient{
+					// We call get to populate the Database struct, then again
+					// to populate the (empty) ProviderConfig struct, resulting
+					// in a ProviderConfig with a nil connection secret.
+					MockGet: test.NewMockGetFn(nil),
+				},
+				usage: resource.TrackerFn(func(ctx context.Context, mg resource.Managed) error { return nil }),
+			},
+			args: args{
+				mg: &v1alpha1.ForeignServer{
+					Spec: v1alpha1.ForeignServerSpec{
+						ResourceSpec: xpv1.ResourceSpec{
+							ProviderConfigReference: &xpv1.Reference{},
+						},
+					},
+				},
+			},
+			want: errors.New(errNoSecretRef),
+		},
+		"ErrGetConnectionSecret": {
+			reason: "An error should be returned if we can't get our ProviderConfig's connection secret",
+			fields: fields{
+				kube: &test.MockClient{
+					MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+						switch o := obj.(type) {
+						case *v1alpha1.ProviderConfig:
+							o.Spec.Credentials.ConnectionSecretRef = &xpv1.SecretReference{}
+						case *corev1.Secret:
+							return errBoom
+						}
+						return nil
+					}),
+				},
+				usage: resource.TrackerFn(func(ctx context.Context, mg resource.Managed) error { return nil }),
+			},
+			args: args{
+				mg: &v1alpha1.ForeignServer{
+					Spec: v1alpha1.ForeignServerSpec{
+						ResourceSpec: xpv1.ResourceSpec{
+							ProviderConfigReference: &xpv1.Reference{},
+						},
+					},
+				},
+			},
+			want: errors.Wrap(errBoom, errGetSecret),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := &connector{kube: tc.fields.kube, usage: tc.fields.usage, newDB: tc.fields.newDB}
+			_, err := e.Connect(tc.args.ctx, tc.args.mg)
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Connect(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestObserve(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type fields struct {
+		db xsql.DB
+	}
+
+	type args struct {
+		ctx context.Context
+		mg  resource.Managed
+	}
+
+	type want struct {
+		o   managed.ExternalObservation
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		fields fields
+		args   args
+		want   want
+	}{
+		"ErrNotForeignServer": {
+			reason: "An error should be returned if the managed resource is not a ForeignServer",
+			args: args{
+				mg: nil,
+			},
+			want: want{
+				err: errors.New(errNotForeignServer),
+			},
+		},
+		"ErrNoServer": {
+			reason: "We should return ResourceExists: false when no foreign server is found",
+			fields: fields{
+				db: mockDB{
+					MockScan: func(ctx context.Context, q xsql.Query, dest ...interface{}) error { return sql.ErrNoRows },
+				},
+			},
+			args: args{
+				mg: &v1alpha1.ForeignServer{},
+			},
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: false},
+			},
+		},
+		"ErrSelectServer": {
+			reason: "We should return any errors encountered while trying to select the foreign server",
+			fields: fields{
+				db: mockDB{
+					MockScan: func(ctx context.Context, q xsql.Query, dest ...interface{}) error { return errBoom },
+				},
+			},
+			args: args{
+				mg: &v1alpha1.ForeignServer{},
+			},
+			want: want{
+				err: errors.Wrap(errBoom, errSelectServer),
+			},
+		},
+		"SuccessLateInit": {
+			reason: "Unset parameters should be late initialized from the observed foreign server",
+			fields: fields{
+				db: mockDB{
+					MockScan: scanServer("postgres", "host=remote"),
+				},
+			},
+			args: args{
+				mg: &v1alpha1.ForeignServer{},
+			},
+			want: want{
+				o: managed.ExternalObservation{
+					ResourceExists:          true,
+					ResourceUpToDate:        true,
+					ResourceLateInitialized: true,
+				},
+			},
+		},
+		"SuccessUpToDate": {
+			reason: "We should report the foreign server as up to date when owner and options match",
+			fields: fields{
+				db: mockDB{
+					MockScan: scanServer("owner", "host=remote", "port=5432"),
+				},
+			},
+			args: args{
+				mg: &v1alpha1.ForeignServer{
+					Spec: v1alpha1.ForeignServerSpec{
+						ForProvider: v1alpha1.ForeignServerParameters{
+							ForeignDataWrapper: pointer.StringPtr("postgres_fdw"),
+							Owner:              pointer.StringPtr("owner"),
+							Options: []v1alpha1.ForeignOption{
+								{Name: "port", Value: "5432"},
+								{Name: "host", Value: "remote"},
+							},
+						},
+					},
+				},
+			},
+			want: want{
+				o: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: true,
+				},
+			},
+		},
+		"SuccessOptionsDrift": {
+			reason: "We should report the foreign server as outdated when options differ",
+			fields: fields{
+				db: mockDB{
+					MockScan: scanServer("owner", "host=elsewhere"),
+				},
+			},
+			args: args{
+				mg: &v1alpha1.ForeignServer{
+					Spec: v1alpha1.ForeignServerSpec{
+						ForProvider: v1alpha1.ForeignServerParameters{
+							ForeignDataWrapper: pointer.StringPtr("postgres_fdw"),
+							Owner:              pointer.StringPtr("owner"),
+							Options: []v1alpha1.ForeignOption{
+								{Name: "host", Value: "remote"},
+							},
+						},
+					},
+				},
+			},
+			want: want{
+				o: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: false,
+				},
+			},
+		},
+		"SuccessOwnerDrift": {
+			reason: "We should report the foreign server as outdated when the owner differs",
+			fields: fields{
+				db: mockDB{
+					MockScan: scanServer("postgres"),
+				},
+			},
+			args: args{
+				mg: &v1alpha1.ForeignServer{
+					Spec: v1alpha1.ForeignServerSpec{
+						ForProvider: v1alpha1.ForeignServerParameters{
+							ForeignDataWrapper: pointer.StringPtr("postgres_fdw"),
+							Owner:              pointer.StringPtr("owner"),
+						},
+					},
+				},
+			},
+			want: want{
+				o: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: false,
+				},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{db: tc.fields.db}
+			got, err := e.Observe(tc.args.ctx, tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, got); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestCreate(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type fields struct {
+		db xsql.DB
+	}
+
+	type args struct {
+		ctx context.Context
+		mg  resource.Managed
+	}
+
+	type want struct {
+		c   managed.ExternalCreation
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		fields fields
+		args   args
+		want   want
+	}{
+		"ErrNotForeignServer": {
+			reason: "An error should be returned if the managed resource is not a ForeignServer",
+			args: args{
+				mg: nil,
+			},
+			want: want{
+				err: errors.New(errNotForeignServer),
+			},
+		},
+		"ErrNoWrapper": {
+			reason: "An error should be returned if no foreign data wrapper was passed",
+			args: args{
+				mg: &v1alpha1.ForeignServer{},
+			},
+			want: want{
+				err: errors.New(errNoWrapper),
+			},
+		},
+		"ErrExec": {
+			reason: "Any errors encountered while creating the foreign server should be returned",
+			fields: fields{
+				db: &mockDB{
+					MockExecTx: func(ctx context.Context, ql []xsql.Query) error { return errBoom },
+				},
+			},
+			args: args{
+				mg: &v1alpha1.ForeignServer{
+					Spec: v1alpha1.ForeignServerSpec{
+						ForProvider: v1alpha1.ForeignServerParameters{
+							ForeignDataWrapper: pointer.StringPtr("postgres_fdw"),
+						},
+					},
+				},
+			},
+			want: want{
+				err: errors.Wrap(errBoom, errCreateServer),
+			},
+		},
+		"Success": {
+			reason: "No error should be returned when we successfully create a foreign server",
+			fields: fields{
+				db: &mockDB{
+					MockExecTx: func(ctx context.Context, ql []xsql.Query) error {
+						want := []xsql.Query{
+							{String: `CREATE SERVER "" VERSION '15' FOREIGN DATA WRAPPER "postgres_fdw" OPTIONS ("dbname" 'app', "host" 'remote')`},
+							{String: `ALTER SERVER "" OWNER TO "owner"`},
+						}
+						if diff := cmp.Diff(want, ql); diff != "" {
+							return errors.New(diff)
+						}
+						return nil
+					},
+				},
+			},
+			args: args{
+				mg: &v1alpha1.ForeignServer{
+					Spec: v1alpha1.ForeignServerSpec{
+						ForProvider: v1alpha1.ForeignServerParameters{
+							ForeignDataWrapper: pointer.StringPtr("postgres_fdw"),
+							Version:            pointer.StringPtr("15"),
+							Owner:              pointer.StringPtr("owner"),
+							Options: []v1alpha1.ForeignOption{
+								{Name: "host", Value: "remote"},
+								{Name: "dbname", Value: "app"},
+							},
+						},
+					},
+				},
+			},
+			want: want{
+				err: nil,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{db: tc.fields.db}
+			got, err := e.Create(tc.args.ctx, tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Create(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.c, got); diff != "" {
+				t.Errorf("\n%s\ne.Create(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type fields struct {
+		db xsql.DB
+	}
+
+	type args struct {
+		ctx context.Context
+		mg  resource.Managed
+	}
+
+	type want struct {
+		u   managed.ExternalUpdate
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		fields fields
+		args   args
+		want   want
+	}{
+		"ErrNotForeignServer": {
+			reason: "An error should be returned if the managed resource is not a ForeignServer",
+			args: args{
+				mg: nil,
+			},
+			want: want{
+				err: errors.New(errNotForeignServer),
+			},
+		},
+		"ErrSelectServer": {
+			reason: "Errors selecting the current foreign server should be returned",
+			fields: fields{
+				db: &mockDB{
+					MockScan: func(ctx context.Context, q xsql.Query, dest ...interface{}) error { return errBoom },
+				},
+			},
+			args: args{
+				mg: &v1alpha1.ForeignServer{},
+			},
+			want: want{
+				err: errors.Wrap(errBoom, errSelectServer),
+			},
+		},
+		"ErrExec": {
+			reason: "Errors altering the foreign server should be returned",
+			fields: fields{
+				db: &mockDB{
+					MockScan:   scanServer("postgres"),
+					MockExecTx: func(ctx context.Context, ql []xsql.Query) error { return errBoom },
+				},
+			},
+			args: args{
+				mg: &v1alpha1.ForeignServer{
+					Spec: v1alpha1.ForeignServerSpec{
+						ForProvider: v1alpha1.ForeignServerParameters{
+							Owner: pointer.StringPtr("owner"),
+						},
+					},
+				},
+			},
+			want: want{
+				err: errors.Wrap(errBoom, errUpdateServer),
+			},
+		},
+		"Success": {
+			reason: "Only the options and owner that changed should be altered",
+			fields: fields{
+				db: &mockDB{
+					MockScan: scanServer("postgres", "host=elsewhere", "port=5432", "fetch_size=100"),
+					MockExecTx: func(ctx context.Context, ql []xsql.Query) error {
+						want := []xsql.Query{
+							{String: `ALTER SERVER "" OPTIONS (ADD "dbname" 'app', SET "host" 'remote', DROP "fetch_size")`},
+							{String: `ALTER SERVER "" OWNER TO "owner"`},
+						}
+						if diff := cmp.Diff(want, ql); diff != "" {
+							return errors.New(diff)
+						}
+						return nil
+					},
+				},
+			},
+			args: args{
+				mg: &v1alpha1.ForeignServer{
+					Spec: v1alpha1.ForeignServerSpec{
+						ForProvider: v1alpha1.ForeignServerParameters{
+							Owner: pointer.StringPtr("owner"),
+							Options: []v1alpha1.ForeignOption{
+								{Name: "host", Value: "remote"},
+								{Name: "port", Value: "5432"},
+								{Name: "dbname", Value: "app"},
+							},
+						},
+					},
+				},
+			},
+			want: want{
+				err: nil,
+			},
+		},
+		"NoOp": {
+			reason: "Nothing should be altered if the foreign server is up to date",
+			fields: fields{
+				db: &mockDB{
+					MockScan: scanServer("owner"),
+				},
+			},
+			args: args{
+				mg: &v1alpha1.ForeignServer{
+					Spec: v1alpha1.ForeignServerSpec{
+						ForProvider: v1alpha1.ForeignServerParameters{
+							Owner: pointer.StringPtr("owner"),
+						},
+					},
+				},
+			},
+			want: want{
+				err: nil,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{db: tc.fields.db}
+			got, err := e.Update(tc.args.ctx, tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Update(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.u, got); diff != "" {
+				t.Errorf("\n%s\ne.Update(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestDelete(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type fields struct {
+		db xsql.DB
+	}
+
+	type args struct {
+		ctx context.Context
+		mg  resource.Managed
+	}
+
+	cases := map[string]struct {
+		reason string
+		fields fields
+		args   args
+		want   error
+	}{
+		"ErrNotForeignServer": {
+			reason: "An error should be returned if the managed resource is not a ForeignServer",
+			args: args{
+				mg: nil,
+			},
+			want: errors.New(errNotForeignServer),
+		},
+		"ErrDropServer": {
+			reason: "Errors dropping a foreign server should be returned",
+			fields: fields{
+				db: &mockDB{
+					MockExec: func(ctx context.Context, q xsql.Query) error {
+						return errBoom
+					},
+				},
+			},
+			args: args{
+				mg: &v1alpha1.ForeignServer{},
+			},
+			want: errors.Wrap(errBoom, errDropServer),
+		},
+		"Success": {
+			reason: "No error should be returned if the foreign server was dropped",
+			fields: fields{
+				db: &mockDB{
+					MockExec: func(ctx context.Context, q xsql.Query) error { return nil },
+				},
+			},
+			args: args{
+				mg: &v1alpha1.ForeignServer{},
+			},
+			want: nil,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{db: tc.fields.db}
+			err := e.Delete(tc.args.ctx, tc.args.mg)
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Delete(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -22,10 +22,15 @@ import (
 	"github.com/crossplane/crossplane-runtime/pkg/logging"
 
 	"github.com/crossplane-contrib/provider-sql/pkg/controller/postgresql/config"
+	"github.com/crossplane-contrib/provider-sql/pkg/controller/postgresql/cronjob"
 	"github.com/crossplane-contrib/provider-sql/pkg/controller/postgresql/database"
 	"github.com/crossplane-contrib/provider-sql/pkg/controller/postgresql/extension"
+	"github.com/crossplane-contrib/provider-sql/pkg/controller/postgresql/foreignserver"
 	"github.com/crossplane-contrib/provider-sql/pkg/controller/postgresql/grant"
 	"github.com/crossplane-contrib/provider-sql/pkg/controller/postgresql/role"
+	"github.com/crossplane-contrib/provider-sql/pkg/controller/postgresql/serversetting"
+	"github.com/crossplane-contrib/provider-sql/pkg/controller/postgresql/tablespace"
+	"github.com/crossplane-contrib/provider-sql/pkg/controller/postgresql/usermapping"
 )
 
 // Setup creates all PostgreSQL controllers with the supplied logger and adds
@@ -37,6 +42,11 @@ func Setup(mgr ctrl.Manager, l logging.Logger) error {
 		role.Setup,
 		grant.Setup,
 		extension.Setup,
+		foreignserver.Setup,
+		usermapping.Setup,
+		cronjob.Setup,
+		tablespace.Setup,
+		serversetting.Setup,
 	} {
 		if err := setup(mgr, l); err != nil {
 			return err
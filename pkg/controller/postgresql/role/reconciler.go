@@ -59,6 +59,8 @@ const (
 	errDropRole                = "cannot drop role"
 	errUpdateRole              = "cannot update role"
 	errGetPasswordSecretFailed = "cannot get password secret"
+	errSelectPassword          = "cannot select role password"
+	errEncryptPassword         = "cannot encrypt role password"
 	errComparePrivileges       = "cannot compare desired and observed privileges"
 	errSetRoleConfigs          = "cannot set role configuration parameters"
 
@@ -293,13 +295,18 @@ func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.Ext
 		}
 	}
 
+	enc, err := postgresql.EncryptPassword(pw, meta.GetExternalName(cr), clients.ToString(cr.Spec.ForProvider.PasswordEncryption))
+	if err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errEncryptPassword)
+	}
+
 	// NOTE we're not using pq's "Parameters" setting here
 	// because it does not allow us to pass identifiers.
 	if err := c.db.Exec(ctx, xsql.Query{
 		String: fmt.Sprintf(
 			"CREATE ROLE %s PASSWORD %s %s",
 			crn,
-			pq.QuoteLiteral(pw),
+			pq.QuoteLiteral(enc),
 			strings.Join(privs, " "),
 		),
 	}); err != nil {
@@ -344,8 +351,12 @@ func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.Ext
 	crn := pq.QuoteIdentifier(meta.GetExternalName(cr))
 
 	if pwchanged {
+		enc, err := postgresql.EncryptPassword(pw, meta.GetExternalName(cr), clients.ToString(cr.Spec.ForProvider.PasswordEncryption))
+		if err != nil {
+			return managed.ExternalUpdate{}, errors.Wrap(err, errEncryptPassword)
+		}
 		if err := c.db.Exec(ctx, xsql.Query{
-			String: fmt.Sprintf("ALTER ROLE %s PASSWORD %s", crn, pq.QuoteLiteral(pw)),
+			String: fmt.Sprintf("ALTER ROLE %s PASSWORD %s", crn, pq.QuoteLiteral(enc)),
 		}); err != nil {
 			return managed.ExternalUpdate{}, errors.Wrap(err, errUpdateRole)
 		}
@@ -20,6 +20,7 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/crossplane-contrib/provider-sql/apis/postgresql/v1alpha1"
@@ -38,6 +39,7 @@ import (
 	"github.com/crossplane/crossplane-runtime/pkg/resource"
 	"github.com/crossplane/crossplane-runtime/pkg/test"
 
+	"github.com/crossplane-contrib/provider-sql/pkg/clients/postgresql"
 	"github.com/crossplane-contrib/provider-sql/pkg/clients/xsql"
 )
 
@@ -68,6 +70,21 @@ func (m mockDB) GetConnectionDetails(rolename, password string) managed.Connecti
 	}
 }
 
+// errNoAuthid is returned by PostgreSQL when the provider may not read
+// pg_authid, in which case passwords are compared with the connection secret.
+var errNoAuthid = &pq.Error{Code: "42501"}
+
+// scanVerifier returns a MockScan that reports the supplied password verifier
+// for the role, and default values for any other query.
+func scanVerifier(verifier string) func(ctx context.Context, q xsql.Query, dest ...interface{}) error {
+	return func(ctx context.Context, q xsql.Query, dest ...interface{}) error {
+		if len(dest) == 1 {
+			*dest[0].(**string) = &verifier
+		}
+		return nil
+	}
+}
+
 func TestConnect(t *testing.T) {
 	errBoom := errors.New("boom")
 
@@ -320,6 +337,178 @@ func TestObserve(t *testing.T) {
 				err: nil,
 			},
 		},
+		"PasswordMatchesVerifier": {
+			reason: "We should return ResourceUpToDate=true if the password matches the verifier stored by the server",
+			fields: fields{
+				db: mockDB{
+					MockScan: scanVerifier("md52c0326e996832e9bdfac8417eac7e6f5"),
+				},
+				kube: &test.MockClient{
+					MockGet: func(_ context.Context, key client.ObjectKey, obj client.Object) error {
+						secret := corev1.Secret{
+							Data: map[string][]byte{"password": []byte("secret")},
+						}
+						secret.DeepCopyInto(obj.(*corev1.Secret))
+						return nil
+					},
+				},
+			},
+			args: args{
+				mg: &v1alpha1.Role{
+					ObjectMeta: v1.ObjectMeta{
+						Annotations: map[string]string{
+							meta.AnnotationKeyExternalName: "example",
+						},
+					},
+					Spec: v1alpha1.RoleSpec{
+						ForProvider: v1alpha1.RoleParameters{
+							PasswordSecretRef: &xpv1.SecretKeySelector{
+								SecretReference: xpv1.SecretReference{
+									Name: "example",
+								},
+								Key: "password",
+							},
+							PasswordEncryption: pointer.String(postgresql.PasswordEncryptionMD5),
+						},
+					},
+				},
+			},
+			want: want{
+				o: managed.ExternalObservation{
+					ResourceExists:          true,
+					ResourceUpToDate:        true,
+					ResourceLateInitialized: true,
+				},
+				err: nil,
+			},
+		},
+		"PasswordEncryptionDrifted": {
+			reason: "We should return ResourceUpToDate=false if the stored verifier does not use the desired password encryption",
+			fields: fields{
+				db: mockDB{
+					MockScan: scanVerifier("md52c0326e996832e9bdfac8417eac7e6f5"),
+				},
+				kube: &test.MockClient{
+					MockGet: func(_ context.Context, key client.ObjectKey, obj client.Object) error {
+						secret := corev1.Secret{
+							Data: map[string][]byte{"password": []byte("secret")},
+						}
+						secret.DeepCopyInto(obj.(*corev1.Secret))
+						return nil
+					},
+				},
+			},
+			args: args{
+				mg: &v1alpha1.Role{
+					ObjectMeta: v1.ObjectMeta{
+						Annotations: map[string]string{
+							meta.AnnotationKeyExternalName: "example",
+						},
+					},
+					Spec: v1alpha1.RoleSpec{
+						ForProvider: v1alpha1.RoleParameters{
+							PasswordSecretRef: &xpv1.SecretKeySelector{
+								SecretReference: xpv1.SecretReference{
+									Name: "example",
+								},
+								Key: "password",
+							},
+						},
+					},
+				},
+			},
+			want: want{
+				o: managed.ExternalObservation{
+					ResourceExists:          true,
+					ResourceUpToDate:        false,
+					ResourceLateInitialized: true,
+				},
+				err: nil,
+			},
+		},
+		"PasswordDriftedFromVerifier": {
+			reason: "We should return ResourceUpToDate=false if the password was changed outside of the provider",
+			fields: fields{
+				db: mockDB{
+					MockScan: scanVerifier("md5a3556571e93b0d20722ba62be61e8c2d"),
+				},
+				kube: &test.MockClient{
+					MockGet: func(_ context.Context, key client.ObjectKey, obj client.Object) error {
+						secret := corev1.Secret{
+							Data: map[string][]byte{"password": []byte("secret")},
+						}
+						secret.DeepCopyInto(obj.(*corev1.Secret))
+						return nil
+					},
+				},
+			},
+			args: args{
+				mg: &v1alpha1.Role{
+					ObjectMeta: v1.ObjectMeta{
+						Annotations: map[string]string{
+							meta.AnnotationKeyExternalName: "example",
+						},
+					},
+					Spec: v1alpha1.RoleSpec{
+						ForProvider: v1alpha1.RoleParameters{
+							PasswordSecretRef: &xpv1.SecretKeySelector{
+								SecretReference: xpv1.SecretReference{
+									Name: "example",
+								},
+								Key: "password",
+							},
+						},
+					},
+				},
+			},
+			want: want{
+				o: managed.ExternalObservation{
+					ResourceExists:          true,
+					ResourceUpToDate:        false,
+					ResourceLateInitialized: true,
+				},
+				err: nil,
+			},
+		},
+		"ErrSelectPassword": {
+			reason: "We should return any errors encountered while selecting the stored password verifier",
+			fields: fields{
+				db: mockDB{
+					MockScan: func(ctx context.Context, q xsql.Query, dest ...interface{}) error {
+						if len(dest) == 1 {
+							return errBoom
+						}
+						return nil
+					},
+				},
+				kube: &test.MockClient{
+					MockGet: func(_ context.Context, key client.ObjectKey, obj client.Object) error {
+						secret := corev1.Secret{
+							Data: map[string][]byte{"password": []byte("secret")},
+						}
+						secret.DeepCopyInto(obj.(*corev1.Secret))
+						return nil
+					},
+				},
+			},
+			args: args{
+				mg: &v1alpha1.Role{
+					Spec: v1alpha1.RoleSpec{
+						ForProvider: v1alpha1.RoleParameters{
+							PasswordSecretRef: &xpv1.SecretKeySelector{
+								SecretReference: xpv1.SecretReference{
+									Name: "example",
+								},
+								Key: "password",
+							},
+						},
+					},
+				},
+			},
+			want: want{
+				err: errors.Wrap(errBoom, errSelectPassword),
+			},
+		},
 		"ConfigurationParametersChanged": {
 			reason: "We should return ResourceUpToDate=false if ConfigurationParameter is changed",
 			fields: fields{
@@ -469,6 +658,7 @@ func TestCreate(t *testing.T) {
 			comparePw: true,
 			fields: fields{
 				db: &mockDB{
+					MockScan: func(ctx context.Context, q xsql.Query, dest ...interface{}) error { return sql.ErrNoRows },
 					MockExec: func(ctx context.Context, q xsql.Query) error { return nil },
 				},
 				kube: &test.MockClient{
@@ -578,6 +768,7 @@ func TestUpdate(t *testing.T) {
 			reason: "Any errors encountered while updating the role should be returned",
 			fields: fields{
 				db: &mockDB{
+					MockScan: func(ctx context.Context, q xsql.Query, dest ...interface{}) error { return errNoAuthid },
 					MockExec: func(ctx context.Context, q xsql.Query) error { return errBoom },
 				},
 			},
@@ -638,7 +829,9 @@ func TestUpdate(t *testing.T) {
 		"SamePassword": {
 			reason: "No DB query should be executed if the password didn't change",
 			fields: fields{
-				db: &mockDB{},
+				db: &mockDB{
+					MockScan: func(ctx context.Context, q xsql.Query, dest ...interface{}) error { return errNoAuthid },
+				},
 			},
 			args: args{
 				mg: &v1alpha1.Role{
@@ -680,7 +873,14 @@ func TestUpdate(t *testing.T) {
 			reason: "The password must be updated",
 			fields: fields{
 				db: &mockDB{
-					MockExec: func(ctx context.Context, q xsql.Query) error { return nil },
+					MockScan: func(ctx context.Context, q xsql.Query, dest ...interface{}) error { return errNoAuthid },
+					MockExec: func(ctx context.Context, q xsql.Query) error {
+						// The password must only be sent to the server hashed.
+						if strings.Contains(q.String, "newpassword") {
+							return errBoom
+						}
+						return nil
+					},
 				},
 			},
 			args: args{
@@ -745,6 +945,7 @@ func TestUpdate(t *testing.T) {
 			reason: "We should only try to set privileges whose values have changed.",
 			fields: fields{
 				db: &mockDB{
+					MockScan: func(ctx context.Context, q xsql.Query, dest ...interface{}) error { return errNoAuthid },
 					MockExec: func(ctx context.Context, q xsql.Query) error {
 						// Verify that query contains only the identifier that we
 						// expect, otherwise return a boom.
@@ -803,6 +1004,7 @@ func TestUpdate(t *testing.T) {
 			reason: "We should not execute an SQL query if privileges are unchanged.",
 			fields: fields{
 				db: &mockDB{
+					MockScan: func(ctx context.Context, q xsql.Query, dest ...interface{}) error { return errNoAuthid },
 					MockExec: func(ctx context.Context, q xsql.Query) error {
 						return errBoom
 					},
@@ -857,6 +1059,7 @@ func TestUpdate(t *testing.T) {
 			reason: "We should error if observed privilege list is shorter than desired privilege list",
 			fields: fields{
 				db: &mockDB{
+					MockScan: func(ctx context.Context, q xsql.Query, dest ...interface{}) error { return errNoAuthid },
 					MockExec: func(ctx context.Context, q xsql.Query) error {
 						return nil
 					},
@@ -911,6 +1114,7 @@ func TestUpdate(t *testing.T) {
 			reason: "We should set configuration parameters when diff between desired and observed.",
 			fields: fields{
 				db: &mockDB{
+					MockScan: func(ctx context.Context, q xsql.Query, dest ...interface{}) error { return errNoAuthid },
 					MockExecTx: func(ctx context.Context, q []xsql.Query) error {
 						crn := pq.QuoteIdentifier("example")
 						if len(q) != 3 {
@@ -988,6 +1192,7 @@ func TestUpdate(t *testing.T) {
 			reason: "We should not execute an SQL query if configuration parameters are unchanged.",
 			fields: fields{
 				db: &mockDB{
+					MockScan: func(ctx context.Context, q xsql.Query, dest ...interface{}) error { return errNoAuthid },
 					MockExecTx: func(ctx context.Context, q []xsql.Query) error {
 						return errBoom
 					},
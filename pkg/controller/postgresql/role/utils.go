@@ -25,9 +25,12 @@ import (
 	"github.com/pkg/errors"
 
 	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
 	"github.com/crossplane/crossplane-runtime/pkg/resource"
 
 	"github.com/crossplane-contrib/provider-sql/apis/postgresql/v1alpha1"
+	"github.com/crossplane-contrib/provider-sql/pkg/clients/postgresql"
+	"github.com/crossplane-contrib/provider-sql/pkg/clients/xsql"
 )
 
 func (c *external) getPassword(ctx context.Context, role *v1alpha1.Role) (newPwd string, changed bool, err error) {
@@ -43,6 +46,27 @@ func (c *external) getPassword(ctx context.Context, role *v1alpha1.Role) (newPwd
 		return "", false, errors.Wrap(err, errGetPasswordSecretFailed)
 	}
 	newPwd = string(s.Data[role.Spec.ForProvider.PasswordSecretRef.Key])
+	if newPwd == "" {
+		return "", false, nil
+	}
+
+	// Prefer checking the password against the verifier stored by the server,
+	// which also detects passwords changed outside of the provider. Reading
+	// pg_authid requires superuser, so we fall back to comparing the password
+	// with our connection secret if we can't.
+	var verifier *string
+	err = c.db.Scan(ctx, xsql.Query{
+		String:     "SELECT rolpassword FROM pg_authid WHERE rolname = $1",
+		Parameters: []interface{}{meta.GetExternalName(role)},
+	}, &verifier)
+	switch {
+	case err == nil:
+		return newPwd, verifier == nil || !verifierUpToDate(*verifier, role, newPwd), nil
+	case xsql.IsNoRows(err), postgresql.IsInsufficientPrivilege(err):
+		// Fall through to comparing with the connection secret.
+	default:
+		return "", false, errors.Wrap(err, errSelectPassword)
+	}
 
 	if role.Spec.WriteConnectionSecretToReference == nil {
 		return newPwd, false, nil
@@ -64,3 +88,16 @@ func (c *external) getPassword(ctx context.Context, role *v1alpha1.Role) (newPwd
 
 	return newPwd, changed, nil
 }
+
+// verifierUpToDate returns true if the supplied verifier was computed from the
+// supplied password using the desired password encryption method.
+func verifierUpToDate(verifier string, role *v1alpha1.Role, password string) bool {
+	method := postgresql.PasswordEncryptionSCRAM
+	if role.Spec.ForProvider.PasswordEncryption != nil {
+		method = *role.Spec.ForProvider.PasswordEncryption
+	}
+	if postgresql.PasswordEncryptionOf(verifier) != method {
+		return false
+	}
+	return postgresql.VerifyPassword(verifier, meta.GetExternalName(role), password)
+}
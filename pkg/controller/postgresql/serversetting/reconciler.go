@@ -0,0 +1,237 @@
+/*
+Copyright 2022 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package serversetting
+
+import (
+	"context"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/crossplane-contrib/provider-sql/apis/common"
+	"github.com/crossplane-contrib/provider-sql/apis/postgresql/v1alpha1"
+	"github.com/crossplane-contrib/provider-sql/pkg/clients"
+	"github.com/crossplane-contrib/provider-sql/pkg/clients/postgresql"
+	"github.com/crossplane-contrib/provider-sql/pkg/clients/xsql"
+)
+
+const (
+	errTrackPCUsage = "cannot track ProviderConfig usage"
+	errGetPC        = "cannot get ProviderConfig"
+	errNoSecretRef  = "ProviderConfig does not reference a credentials Secret"
+	errGetSecret    = "cannot get credentials Secret"
+
+	errNotServerSetting = "managed resource is not a ServerSetting custom resource"
+	errSelectSetting    = "cannot select server setting"
+	errSetSetting       = "cannot set server setting"
+	errResetSetting     = "cannot reset server setting"
+	errReloadConf       = "cannot reload server configuration"
+
+	maxConcurrency = 5
+)
+
+// Setup adds a controller that reconciles ServerSetting managed resources.
+func Setup(mgr ctrl.Manager, l logging.Logger) error {
+	name := managed.ControllerName(v1alpha1.ServerSettingGroupKind)
+
+	t := resource.NewProviderConfigUsageTracker(mgr.GetClient(), &v1alpha1.ProviderConfigUsage{})
+	r := managed.NewReconciler(mgr,
+		resource.ManagedKind(v1alpha1.ServerSettingGroupVersionKind),
+		managed.WithExternalConnecter(&connector{kube: mgr.GetClient(), usage: t, newDB: postgresql.New}),
+		managed.WithLogger(l.WithValues("controller", name)),
+		managed.WithPollInterval(10*time.Minute),
+		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))))
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		For(&v1alpha1.ServerSetting{}).
+		WithOptions(controller.Options{
+			MaxConcurrentReconciles: maxConcurrency,
+		}).
+		Complete(r)
+}
+
+type connector struct {
+	kube  client.Client
+	usage resource.Tracker
+	newDB func(creds map[string][]byte, database string, sslmode string) xsql.DB
+}
+
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	cr, ok := mg.(*v1alpha1.ServerSetting)
+	if !ok {
+		return nil, errors.New(errNotServerSetting)
+	}
+
+	if err := c.usage.Track(ctx, mg); err != nil {
+		return nil, errors.Wrap(err, errTrackPCUsage)
+	}
+
+	// ProviderConfigReference could theoretically be nil, but in practice the
+	// DefaultProviderConfig initializer will set it before we get here.
+	pc := &v1alpha1.ProviderConfig{}
+	if err := c.kube.Get(ctx, types.NamespacedName{Name: cr.GetProviderConfigReference().Name}, pc); err != nil {
+		return nil, errors.Wrap(err, errGetPC)
+	}
+
+	// We don't need to check the credentials source because we currently only
+	// support one source (PostgreSQLConnectionSecret), which is required and
+	// enforced by the ProviderConfig schema.
+	ref := pc.Spec.Credentials.ConnectionSecretRef
+	if ref == nil {
+		return nil, errors.New(errNoSecretRef)
+	}
+
+	s := &corev1.Secret{}
+	if err := c.kube.Get(ctx, types.NamespacedName{Namespace: ref.Namespace, Name: ref.Name}, s); err != nil {
+		return nil, errors.Wrap(err, errGetSecret)
+	}
+
+	return &external{db: c.newDB(s.Data, pc.Spec.DefaultDatabase, clients.ToString(pc.Spec.SSLMode))}, nil
+}
+
+type external struct{ db xsql.DB }
+
+// persisted returns the value of the parameter written to postgresql.auto.conf
+// by ALTER SYSTEM.
+func (c *external) persisted(ctx context.Context, parameter string) (string, error) {
+	query := "SELECT setting FROM pg_file_settings " +
+		"WHERE lower(name) = lower($1) AND sourcefile LIKE '%postgresql.auto.conf' " +
+		"ORDER BY seqno DESC LIMIT 1"
+
+	var v string
+	err := c.db.Scan(ctx, xsql.Query{String: query, Parameters: []interface{}{parameter}}, &v)
+	return v, err
+}
+
+// current returns the value of the parameter currently in effect, and the
+// type of its values.
+func (c *external) current(ctx context.Context, parameter string) (v1alpha1.ServerSettingObservation, string, error) {
+	query := "SELECT setting, COALESCE(unit, ''), source, pending_restart, vartype " +
+		"FROM pg_settings WHERE lower(name) = lower($1)"
+
+	o := v1alpha1.ServerSettingObservation{}
+	var vartype string
+	err := c.db.Scan(ctx, xsql.Query{String: query, Parameters: []interface{}{parameter}},
+		&o.Setting,
+		&o.Unit,
+		&o.Source,
+		&o.PendingRestart,
+		&vartype,
+	)
+	return o, vartype, err
+}
+
+func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.ServerSetting)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotServerSetting)
+	}
+
+	value, err := c.persisted(ctx, cr.Spec.ForProvider.Parameter)
+	if xsql.IsNoRows(err) {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errSelectSetting)
+	}
+
+	// Placeholder parameters of extensions that are not loaded yet are not
+	// reported by pg_settings, so we tolerate them being missing and compare
+	// their values as they are spelled.
+	o, vartype, err := c.current(ctx, cr.Spec.ForProvider.Parameter)
+	if err != nil && !xsql.IsNoRows(err) {
+		return managed.ExternalObservation{}, errors.Wrap(err, errSelectSetting)
+	}
+
+	cr.Status.AtProvider = o
+	cr.SetConditions(xpv1.Available())
+	if o.PendingRestart {
+		cr.SetConditions(common.PendingRestart())
+	} else {
+		cr.SetConditions(common.Applied())
+	}
+
+	// PostgreSQL keeps the value as it was spelled in ALTER SYSTEM, so we
+	// compare it as the server interprets it, e.g. 1GB is the same as 1024MB.
+	return managed.ExternalObservation{
+		ResourceExists:   true,
+		ResourceUpToDate: normalize(value, vartype, o.Unit) == normalize(cr.Spec.ForProvider.Value, vartype, o.Unit),
+	}, nil
+}
+
+func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1alpha1.ServerSetting)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotServerSetting)
+	}
+
+	cr.SetConditions(xpv1.Creating())
+
+	return managed.ExternalCreation{}, c.set(ctx, cr.Spec.ForProvider)
+}
+
+func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*v1alpha1.ServerSetting)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotServerSetting)
+	}
+
+	return managed.ExternalUpdate{}, c.set(ctx, cr.Spec.ForProvider)
+}
+
+func (c *external) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1alpha1.ServerSetting)
+	if !ok {
+		return errors.New(errNotServerSetting)
+	}
+
+	cr.SetConditions(xpv1.Deleting())
+
+	query := "ALTER SYSTEM RESET " + pq.QuoteIdentifier(cr.Spec.ForProvider.Parameter)
+	if err := c.db.Exec(ctx, xsql.Query{String: query}); err != nil {
+		return errors.Wrap(err, errResetSetting)
+	}
+	return errors.Wrap(c.reload(ctx), errReloadConf)
+}
+
+// set writes the parameter to postgresql.auto.conf and reloads the server
+// configuration. ALTER SYSTEM cannot be executed inside a transaction block,
+// so the statements are executed individually.
+func (c *external) set(ctx context.Context, p v1alpha1.ServerSettingParameters) error {
+	query := "ALTER SYSTEM SET " + pq.QuoteIdentifier(p.Parameter) + " = " + pq.QuoteLiteral(p.Value)
+	if err := c.db.Exec(ctx, xsql.Query{String: query}); err != nil {
+		return errors.Wrap(err, errSetSetting)
+	}
+	return errors.Wrap(c.reload(ctx), errReloadConf)
+}
+
+func (c *external) reload(ctx context.Context) error {
+	return c.db.Exec(ctx, xsql.Query{String: "SELECT pg_reload_conf()"})
+}
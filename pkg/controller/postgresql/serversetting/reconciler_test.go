@@ -0,0 +1,756 @@
+/*
+Copyright 2022 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package serversetting
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane-contrib/provider-sql/apis/common"
+	"github.com/crossplane-contrib/provider-sql/apis/postgresql/v1alpha1"
+	"github.com/crossplane-contrib/provider-sql/pkg/clients/xsql"
+)
+
+type mockDB struct {
+	MockExec                 func(ctx context.Context, q xsql.Query) error
+	MockExecTx               func(ctx context.Context, ql []xsql.Query) error
+	MockScan                 func(ctx context.Context, q xsql.Query, dest ...interface{}) error
+	MockGetConnectionDetails func(username, password string) managed.ConnectionDetails
+}
+
+func (m mockDB) Exec(ctx context.Context, q xsql.Query) error {
+	return m.MockExec(ctx, q)
+}
+func (m mockDB) ExecTx(ctx context.Context, ql []xsql.Query) error {
+	return m.MockExecTx(ctx, ql)
+}
+func (m mockDB) Scan(ctx context.Context, q xsql.Query, dest ...interface{}) error {
+	return m.MockScan(ctx, q, dest...)
+}
+func (m mockDB) Query(ctx context.Context, q xsql.Query) (*sql.Rows, error) {
+	return &sql.Rows{}, nil
+}
+func (m mockDB) GetConnectionDetails(username, password string) managed.ConnectionDetails {
+	return m.MockGetConnectionDetails(username, password)
+}
+
+// scanSetting returns a MockScan that reports the supplied value persisted by
+// ALTER SYSTEM for a memory parameter, and whether the server must be
+// restarted to apply it.
+func scanSetting(value string, pendingRestart bool) func(ctx context.Context, q xsql.Query, dest ...interface{}) error {
+	return scanObserved(value, "integer", v1alpha1.ServerSettingObservation{
+		Setting:        "65536",
+		Unit:           "kB",
+		Source:         "configuration file",
+		PendingRestart: pendingRestart,
+	})
+}
+
+// scanObserved returns a MockScan that reports the supplied value persisted by
+// ALTER SYSTEM, and the supplied observation of a parameter of vartype.
+func scanObserved(value, vartype string, o v1alpha1.ServerSettingObservation) func(ctx context.Context, q xsql.Query, dest ...interface{}) error {
+	return func(ctx context.Context, q xsql.Query, dest ...interface{}) error {
+		if len(dest) == 1 {
+			*dest[0].(*string) = value
+			return nil
+		}
+		*dest[0].(*string) = o.Setting
+		*dest[1].(*string) = o.Unit
+		*dest[2].(*string) = o.Source
+		*dest[3].(*bool) = o.PendingRestart
+		*dest[4].(*string) = vartype
+		return nil
+	}
+}
+
+func TestConnect(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type fields struct {
+		kube  client.Client
+		usage resource.Tracker
+		newDB func(creds map[string][]byte, database string, sslmode string) xsql.DB
+	}
+
+	type args struct {
+		ctx context.Context
+		mg  resource.Managed
+	}
+
+	cases := map[string]struct {
+		reason string
+		fields fields
+		args   args
+		want   error
+	}{
+		"ErrNotServerSetting": {
+			reason: "An error should be returned if the managed resource is not a ServerSetting",
+			args: args{
+				mg: nil,
+			},
+			want: errors.New(errNotServerSetting),
+		},
+		"ErrTrackProviderConfigUsage": {
+			reason: "An error should be returned if we can't track our ProviderConfig usage",
+			fields: fields{
+				usage: resource.TrackerFn(func(ctx context.Context, mg resource.Managed) error { return errBoom }),
+			},
+			args: args{
+				mg: &v1alpha1.ServerSetting{},
+			},
+			want: errors.Wrap(errBoom, errTrackPCUsage),
+		},
+		"ErrGetProviderConfig": {
+			reason: "An error should be returned if we can't get our ProviderConfig",
+			fields: fields{
+				kube: &test.MockClient{
+					MockGet: test.NewMockGetFn(errBoom),
+				},
+				usage: resource.TrackerFn(func(ctx context.Context, mg resource.Managed) error { return nil }),
+			},
+			args: args{
+				mg: &v1alpha1.ServerSetting{
+					Spec: v1alpha1.ServerSettingSpec{
+						ResourceSpec: xpv1.ResourceSpec{
+							ProviderConfigReference: &xpv1.Reference{},
+						},
+					},
+				},
+			},
+			want: errors.Wrap(errBoom, errGetPC),
+		},
+		"ErrMissingConnectionSecret": {
+			reason: "An error should be returned if our ProviderConfig doesn't specify a connection secret",
+			fields: fields{
+				kube: &test.MockClient{
+					// We call get to populate the Database struct, then again
+					// to populate the (empty) ProviderConfig struct, resulting
+					// in a ProviderConfig with a nil connection secret.
+					MockGet: test.NewMockGetFn(nil),
+				},
+				usage: resource.TrackerFn(func(ctx context.Context, mg resource.Managed) error { return nil }),
+			},
+			args: args{
+				mg: &v1alpha1.ServerSetting{
+					Spec: v1alpha1.ServerSettingSpec{
+						ResourceSpec: xpv1.ResourceSpec{
+							ProviderConfigReference: &xpv1.Reference{},
+						},
+					},
+				},
+			},
+			want: errors.New(errNoSecretRef),
+		},
+		"ErrGetConnectionSecret": {
+			reason: "An error should be returned if we can't get our ProviderConfig's connection secret",
+			fields: fields{
+				kube: &test.MockClient{
+					MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+						switch o := obj.(type) {
+						case *v1alpha1.ProviderConfig:
+							o.Spec.Credentials.ConnectionSecretRef = &xpv1.SecretReference{}
+						case *corev1.Secret:
+							return errBoom
+						}
+						return nil
+					}),
+				},
+				usage: resource.TrackerFn(func(ctx context.Context, mg resource.Managed) error { return nil }),
+			},
+			args: args{
+				mg: &v1alpha1.ServerSetting{
+					Spec: v1alpha1.ServerSettingSpec{
+						ResourceSpec: xpv1.ResourceSpec{
+							ProviderConfigReference: &xpv1.Reference{},
+						},
+					},
+				},
+			},
+			want: errors.Wrap(errBoom, errGetSecret),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := &connector{kube: tc.fields.kube, usage: tc.fields.usage, newDB: tc.fields.newDB}
+			_, err := e.Connect(tc.args.ctx, tc.args.mg)
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Connect(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestObserve(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type fields struct {
+		db xsql.DB
+	}
+
+	type args struct {
+		ctx context.Context
+		mg  resource.Managed
+	}
+
+	type want struct {
+		o   managed.ExternalObservation
+		mg  resource.Managed
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		fields fields
+		args   args
+		want   want
+	}{
+		"ErrNotServerSetting": {
+			reason: "An error should be returned if the managed resource is not a ServerSetting",
+			args: args{
+				mg: nil,
+			},
+			want: want{
+				err: errors.New(errNotServerSetting),
+			},
+		},
+		"ErrNoSetting": {
+			reason: "We should return ResourceExists: false when the parameter was not set by ALTER SYSTEM",
+			fields: fields{
+				db: mockDB{
+					MockScan: func(ctx context.Context, q xsql.Query, dest ...interface{}) error { return sql.ErrNoRows },
+				},
+			},
+			args: args{
+				mg: &v1alpha1.ServerSetting{},
+			},
+			want: want{
+				o:  managed.ExternalObservation{ResourceExists: false},
+				mg: &v1alpha1.ServerSetting{},
+			},
+		},
+		"ErrSelectSetting": {
+			reason: "We should return any errors encountered while trying to select the setting",
+			fields: fields{
+				db: mockDB{
+					MockScan: func(ctx context.Context, q xsql.Query, dest ...interface{}) error { return errBoom },
+				},
+			},
+			args: args{
+				mg: &v1alpha1.ServerSetting{},
+			},
+			want: want{
+				err: errors.Wrap(errBoom, errSelectSetting),
+				mg:  &v1alpha1.ServerSetting{},
+			},
+		},
+		"SuccessPendingRestart": {
+			reason: "We should report the current setting and a pending restart",
+			fields: fields{
+				db: mockDB{
+					MockScan: scanSetting("64MB", true),
+				},
+			},
+			args: args{
+				mg: &v1alpha1.ServerSetting{
+					Spec: v1alpha1.ServerSettingSpec{
+						ForProvider: v1alpha1.ServerSettingParameters{Parameter: "work_mem", Value: "64MB"},
+					},
+				},
+			},
+			want: want{
+				o: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: true,
+				},
+				mg: &v1alpha1.ServerSetting{
+					Spec: v1alpha1.ServerSettingSpec{
+						ForProvider: v1alpha1.ServerSettingParameters{Parameter: "work_mem", Value: "64MB"},
+					},
+					Status: v1alpha1.ServerSettingStatus{
+						ResourceStatus: xpv1.ResourceStatus{
+							ConditionedStatus: xpv1.ConditionedStatus{
+								Conditions: []xpv1.Condition{xpv1.Available(), common.PendingRestart()},
+							},
+						},
+						AtProvider: v1alpha1.ServerSettingObservation{
+							Setting:        "65536",
+							Unit:           "kB",
+							Source:         "configuration file",
+							PendingRestart: true,
+						},
+					},
+				},
+			},
+		},
+		"NotUpToDate": {
+			reason: "We should return ResourceUpToDate: false when another value was persisted",
+			fields: fields{
+				db: mockDB{
+					MockScan: scanSetting("32MB", false),
+				},
+			},
+			args: args{
+				mg: &v1alpha1.ServerSetting{
+					Spec: v1alpha1.ServerSettingSpec{
+						ForProvider: v1alpha1.ServerSettingParameters{Parameter: "work_mem", Value: "64MB"},
+					},
+				},
+			},
+			want: want{
+				o: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: false,
+				},
+				mg: &v1alpha1.ServerSetting{
+					Spec: v1alpha1.ServerSettingSpec{
+						ForProvider: v1alpha1.ServerSettingParameters{Parameter: "work_mem", Value: "64MB"},
+					},
+					Status: v1alpha1.ServerSettingStatus{
+						ResourceStatus: xpv1.ResourceStatus{
+							ConditionedStatus: xpv1.ConditionedStatus{
+								Conditions: []xpv1.Condition{xpv1.Available(), common.Applied()},
+							},
+						},
+						AtProvider: v1alpha1.ServerSettingObservation{
+							Setting: "65536",
+							Unit:    "kB",
+							Source:  "configuration file",
+						},
+					},
+				},
+			},
+		},
+		"UpToDateUnitSpelling": {
+			reason: "Values spelled with another unit of the parameter should be up to date",
+			fields: fields{
+				db: mockDB{
+					MockScan: scanSetting("65536kB", false),
+				},
+			},
+			args: args{
+				mg: &v1alpha1.ServerSetting{
+					Spec: v1alpha1.ServerSettingSpec{
+						ForProvider: v1alpha1.ServerSettingParameters{Parameter: "work_mem", Value: "64MB"},
+					},
+				},
+			},
+			want: want{
+				o: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: true,
+				},
+				mg: &v1alpha1.ServerSetting{
+					Spec: v1alpha1.ServerSettingSpec{
+						ForProvider: v1alpha1.ServerSettingParameters{Parameter: "work_mem", Value: "64MB"},
+					},
+					Status: v1alpha1.ServerSettingStatus{
+						ResourceStatus: xpv1.ResourceStatus{
+							ConditionedStatus: xpv1.ConditionedStatus{
+								Conditions: []xpv1.Condition{xpv1.Available(), common.Applied()},
+							},
+						},
+						AtProvider: v1alpha1.ServerSettingObservation{
+							Setting: "65536",
+							Unit:    "kB",
+							Source:  "configuration file",
+						},
+					},
+				},
+			},
+		},
+		"UpToDateLargerUnit": {
+			reason: "Values spelled with a larger unit than the parameter's should be up to date",
+			fields: fields{
+				db: mockDB{
+					MockScan: scanSetting("1GB", false),
+				},
+			},
+			args: args{
+				mg: &v1alpha1.ServerSetting{
+					Spec: v1alpha1.ServerSettingSpec{
+						ForProvider: v1alpha1.ServerSettingParameters{Parameter: "work_mem", Value: "1048576"},
+					},
+				},
+			},
+			want: want{
+				o: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: true,
+				},
+				mg: &v1alpha1.ServerSetting{
+					Spec: v1alpha1.ServerSettingSpec{
+						ForProvider: v1alpha1.ServerSettingParameters{Parameter: "work_mem", Value: "1048576"},
+					},
+					Status: v1alpha1.ServerSettingStatus{
+						ResourceStatus: xpv1.ResourceStatus{
+							ConditionedStatus: xpv1.ConditionedStatus{
+								Conditions: []xpv1.Condition{xpv1.Available(), common.Applied()},
+							},
+						},
+						AtProvider: v1alpha1.ServerSettingObservation{
+							Setting: "65536",
+							Unit:    "kB",
+							Source:  "configuration file",
+						},
+					},
+				},
+			},
+		},
+		"UpToDateBooleanSpelling": {
+			reason: "Values spelled as another boolean of the same truth should be up to date",
+			fields: fields{
+				db: mockDB{
+					MockScan: scanObserved("on", "bool", v1alpha1.ServerSettingObservation{
+						Setting: "on",
+						Source:  "configuration file",
+					}),
+				},
+			},
+			args: args{
+				mg: &v1alpha1.ServerSetting{
+					Spec: v1alpha1.ServerSettingSpec{
+						ForProvider: v1alpha1.ServerSettingParameters{Parameter: "log_connections", Value: "True"},
+					},
+				},
+			},
+			want: want{
+				o: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: true,
+				},
+				mg: &v1alpha1.ServerSetting{
+					Spec: v1alpha1.ServerSettingSpec{
+						ForProvider: v1alpha1.ServerSettingParameters{Parameter: "log_connections", Value: "True"},
+					},
+					Status: v1alpha1.ServerSettingStatus{
+						ResourceStatus: xpv1.ResourceStatus{
+							ConditionedStatus: xpv1.ConditionedStatus{
+								Conditions: []xpv1.Condition{xpv1.Available(), common.Applied()},
+							},
+						},
+						AtProvider: v1alpha1.ServerSettingObservation{
+							Setting: "on",
+							Source:  "configuration file",
+						},
+					},
+				},
+			},
+		},
+		"NotUpToDateBoolean": {
+			reason: "Values spelled as a boolean of another truth should not be up to date",
+			fields: fields{
+				db: mockDB{
+					MockScan: scanObserved("on", "bool", v1alpha1.ServerSettingObservation{
+						Setting: "on",
+						Source:  "configuration file",
+					}),
+				},
+			},
+			args: args{
+				mg: &v1alpha1.ServerSetting{
+					Spec: v1alpha1.ServerSettingSpec{
+						ForProvider: v1alpha1.ServerSettingParameters{Parameter: "log_connections", Value: "no"},
+					},
+				},
+			},
+			want: want{
+				o: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: false,
+				},
+				mg: &v1alpha1.ServerSetting{
+					Spec: v1alpha1.ServerSettingSpec{
+						ForProvider: v1alpha1.ServerSettingParameters{Parameter: "log_connections", Value: "no"},
+					},
+					Status: v1alpha1.ServerSettingStatus{
+						ResourceStatus: xpv1.ResourceStatus{
+							ConditionedStatus: xpv1.ConditionedStatus{
+								Conditions: []xpv1.Condition{xpv1.Available(), common.Applied()},
+							},
+						},
+						AtProvider: v1alpha1.ServerSettingObservation{
+							Setting: "on",
+							Source:  "configuration file",
+						},
+					},
+				},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{db: tc.fields.db}
+			got, err := e.Observe(tc.args.ctx, tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, got); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.mg, tc.args.mg, test.EquateConditions()); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want managed resource, +got managed resource:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestCreate(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type fields struct {
+		db xsql.DB
+	}
+
+	type args struct {
+		ctx context.Context
+		mg  resource.Managed
+	}
+
+	type want struct {
+		c   managed.ExternalCreation
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		fields fields
+		args   args
+		want   want
+	}{
+		"ErrNotServerSetting": {
+			reason: "An error should be returned if the managed resource is not a ServerSetting",
+			args: args{
+				mg: nil,
+			},
+			want: want{
+				err: errors.New(errNotServerSetting),
+			},
+		},
+		"ErrSetSetting": {
+			reason: "Any errors encountered while setting the parameter should be returned",
+			fields: fields{
+				db: &mockDB{
+					MockExec: func(ctx context.Context, q xsql.Query) error { return errBoom },
+				},
+			},
+			args: args{
+				mg: &v1alpha1.ServerSetting{},
+			},
+			want: want{
+				err: errors.Wrap(errBoom, errSetSetting),
+			},
+		},
+		"ErrReloadConf": {
+			reason: "Any errors encountered while reloading the configuration should be returned",
+			fields: fields{
+				db: &mockDB{
+					MockExec: func(ctx context.Context, q xsql.Query) error {
+						if strings.Contains(q.String, "pg_reload_conf") {
+							return errBoom
+						}
+						return nil
+					},
+				},
+			},
+			args: args{
+				mg: &v1alpha1.ServerSetting{},
+			},
+			want: want{
+				err: errors.Wrap(errBoom, errReloadConf),
+			},
+		},
+		"Success": {
+			reason: "No error should be returned when we successfully set the parameter",
+			fields: fields{
+				db: &mockDB{
+					MockExec: func(ctx context.Context, q xsql.Query) error { return nil },
+				},
+			},
+			args: args{
+				mg: &v1alpha1.ServerSetting{},
+			},
+			want: want{
+				err: nil,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{db: tc.fields.db}
+			got, err := e.Create(tc.args.ctx, tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Create(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.c, got); diff != "" {
+				t.Errorf("\n%s\ne.Create(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type fields struct {
+		db xsql.DB
+	}
+
+	type args struct {
+		ctx context.Context
+		mg  resource.Managed
+	}
+
+	type want struct {
+		c   managed.ExternalUpdate
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		fields fields
+		args   args
+		want   want
+	}{
+		"ErrNotServerSetting": {
+			reason: "An error should be returned if the managed resource is not a ServerSetting",
+			args: args{
+				mg: nil,
+			},
+			want: want{
+				err: errors.New(errNotServerSetting),
+			},
+		},
+		"ErrSetSetting": {
+			reason: "Any errors encountered while setting the parameter should be returned",
+			fields: fields{
+				db: &mockDB{
+					MockExec: func(ctx context.Context, q xsql.Query) error { return errBoom },
+				},
+			},
+			args: args{
+				mg: &v1alpha1.ServerSetting{},
+			},
+			want: want{
+				err: errors.Wrap(errBoom, errSetSetting),
+			},
+		},
+		"Success": {
+			reason: "No error should be returned when we successfully set the parameter",
+			fields: fields{
+				db: &mockDB{
+					MockExec: func(ctx context.Context, q xsql.Query) error { return nil },
+				},
+			},
+			args: args{
+				mg: &v1alpha1.ServerSetting{},
+			},
+			want: want{
+				err: nil,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{db: tc.fields.db}
+			got, err := e.Update(tc.args.ctx, tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Update(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.c, got); diff != "" {
+				t.Errorf("\n%s\ne.Update(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestDelete(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type fields struct {
+		db xsql.DB
+	}
+
+	type args struct {
+		ctx context.Context
+		mg  resource.Managed
+	}
+
+	cases := map[string]struct {
+		reason string
+		fields fields
+		args   args
+		want   error
+	}{
+		"ErrNotServerSetting": {
+			reason: "An error should be returned if the managed resource is not a ServerSetting",
+			args: args{
+				mg: nil,
+			},
+			want: errors.New(errNotServerSetting),
+		},
+		"ErrResetSetting": {
+			reason: "Errors resetting a parameter should be returned",
+			fields: fields{
+				db: &mockDB{
+					MockExec: func(ctx context.Context, q xsql.Query) error { return errBoom },
+				},
+			},
+			args: args{
+				mg: &v1alpha1.ServerSetting{},
+			},
+			want: errors.Wrap(errBoom, errResetSetting),
+		},
+		"Success": {
+			reason: "No error should be returned if the parameter was reset",
+			fields: fields{
+				db: &mockDB{
+					MockExec: func(ctx context.Context, q xsql.Query) error { return nil },
+				},
+			},
+			args: args{
+				mg: &v1alpha1.ServerSetting{},
+			},
+			want: nil,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{db: tc.fields.db}
+			err := e.Delete(tc.args.ctx, tc.args.mg)
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Delete(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,138 @@
+/*
+Copyright 2022 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package serversetting
+
+import (
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Multipliers of the memory units to bytes, and of the time units to
+// microseconds, as accepted by PostgreSQL for integer and real parameters.
+var (
+	memoryUnits = map[string]float64{
+		"B":  1,
+		"kB": 1 << 10,
+		"MB": 1 << 20,
+		"GB": 1 << 30,
+		"TB": 1 << 40,
+	}
+	timeUnits = map[string]float64{
+		"us":  1,
+		"ms":  1e3,
+		"s":   1e6,
+		"min": 60e6,
+		"h":   3600e6,
+		"d":   86400e6,
+	}
+)
+
+var numeric = regexp.MustCompile(`^\s*([-+]?(?:\d+\.?\d*|\.\d+)(?:[eE][-+]?\d+)?)\s*([A-Za-z]*)\s*$`)
+
+// normalize returns the value of a parameter the way PostgreSQL interprets
+// it, given the parameter's vartype and unit as reported by pg_settings, so
+// that different spellings of the same value compare equal. Values that
+// cannot be interpreted, including those of parameters unknown to
+// pg_settings, are returned unchanged.
+func normalize(value, vartype, unit string) string {
+	switch vartype {
+	case "bool":
+		if b, ok := parseBool(value); ok {
+			return strconv.FormatBool(b)
+		}
+	case "enum":
+		return strings.ToLower(strings.TrimSpace(value))
+	case "integer", "real":
+		if v, ok := parseNumber(value, unit); ok {
+			if vartype == "integer" {
+				v = math.RoundToEven(v)
+			}
+			return strconv.FormatFloat(v, 'g', -1, 64)
+		}
+	}
+	return value
+}
+
+// parseBool accepts the spellings of a boolean PostgreSQL accepts: on, off,
+// 1, 0, and any unique prefix of true, false, yes and no.
+func parseBool(value string) (bool, bool) {
+	v := strings.ToLower(strings.TrimSpace(value))
+	switch {
+	case v == "":
+		return false, false
+	case v == "on" || v == "1":
+		return true, true
+	case v == "off" || v == "of" || v == "0":
+		return false, true
+	case strings.HasPrefix("true", v), strings.HasPrefix("yes", v):
+		return true, true
+	case strings.HasPrefix("false", v), strings.HasPrefix("no", v):
+		return false, true
+	}
+	return false, false
+}
+
+// parseNumber returns the value expressed in the unit of the parameter. A
+// value without a unit is already expressed in the parameter's unit.
+func parseNumber(value, unit string) (float64, bool) {
+	m := numeric.FindStringSubmatch(value)
+	if m == nil {
+		return 0, false
+	}
+	v, err := strconv.ParseFloat(m[1], 64)
+	if err != nil {
+		return 0, false
+	}
+	if m[2] == "" {
+		return v, true
+	}
+
+	base, units, ok := parseUnit(unit)
+	if !ok {
+		return 0, false
+	}
+	mult, ok := units[m[2]]
+	if !ok {
+		return 0, false
+	}
+	return v * mult / base, true
+}
+
+// parseUnit returns the size of a pg_settings unit, such as 8kB or ms, and the
+// family of units it belongs to.
+func parseUnit(unit string) (float64, map[string]float64, bool) {
+	n := strings.IndexFunc(unit, func(r rune) bool { return r < '0' || r > '9' })
+	if n < 0 {
+		return 0, nil, false
+	}
+	size := 1.0
+	if n > 0 {
+		s, err := strconv.ParseFloat(unit[:n], 64)
+		if err != nil {
+			return 0, nil, false
+		}
+		size = s
+	}
+	for _, units := range []map[string]float64{memoryUnits, timeUnits} {
+		if mult, ok := units[unit[n:]]; ok {
+			return size * mult, units, true
+		}
+	}
+	return 0, nil, false
+}
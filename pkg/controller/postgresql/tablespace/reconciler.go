@@ -0,0 +1,306 @@
+/*
+Copyright 2022 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tablespace
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/crossplane-contrib/provider-sql/apis/postgresql/v1alpha1"
+	"github.com/crossplane-contrib/provider-sql/pkg/clients"
+	"github.com/crossplane-contrib/provider-sql/pkg/clients/postgresql"
+	"github.com/crossplane-contrib/provider-sql/pkg/clients/xsql"
+)
+
+const (
+	errTrackPCUsage = "cannot track ProviderConfig usage"
+	errGetPC        = "cannot get ProviderConfig"
+	errNoSecretRef  = "ProviderConfig does not reference a credentials Secret"
+	errGetSecret    = "cannot get credentials Secret"
+
+	errNotTablespace    = "managed resource is not a Tablespace custom resource"
+	errSelectTablespace = "cannot select tablespace"
+	errCreateTablespace = "cannot create tablespace"
+	errUpdateTablespace = "cannot update tablespace"
+	errDropTablespace   = "cannot drop tablespace"
+
+	// Options of the tablespace as they are stored in spcoptions.
+	optionSeqPageCost    = "seq_page_cost"
+	optionRandomPageCost = "random_page_cost"
+
+	maxConcurrency = 5
+)
+
+// Setup adds a controller that reconciles Tablespace managed resources.
+func Setup(mgr ctrl.Manager, l logging.Logger) error {
+	name := managed.ControllerName(v1alpha1.TablespaceGroupKind)
+
+	t := resource.NewProviderConfigUsageTracker(mgr.GetClient(), &v1alpha1.ProviderConfigUsage{})
+	r := managed.NewReconciler(mgr,
+		resource.ManagedKind(v1alpha1.TablespaceGroupVersionKind),
+		managed.WithExternalConnecter(&connector{kube: mgr.GetClient(), usage: t, newDB: postgresql.New}),
+		managed.WithLogger(l.WithValues("controller", name)),
+		managed.WithPollInterval(10*time.Minute),
+		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))))
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		For(&v1alpha1.Tablespace{}).
+		WithOptions(controller.Options{
+			MaxConcurrentReconciles: maxConcurrency,
+		}).
+		Complete(r)
+}
+
+type connector struct {
+	kube  client.Client
+	usage resource.Tracker
+	newDB func(creds map[string][]byte, database string, sslmode string) xsql.DB
+}
+
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	cr, ok := mg.(*v1alpha1.Tablespace)
+	if !ok {
+		return nil, errors.New(errNotTablespace)
+	}
+
+	if err := c.usage.Track(ctx, mg); err != nil {
+		return nil, errors.Wrap(err, errTrackPCUsage)
+	}
+
+	// ProviderConfigReference could theoretically be nil, but in practice the
+	// DefaultProviderConfig initializer will set it before we get here.
+	pc := &v1alpha1.ProviderConfig{}
+	if err := c.kube.Get(ctx, types.NamespacedName{Name: cr.GetProviderConfigReference().Name}, pc); err != nil {
+		return nil, errors.Wrap(err, errGetPC)
+	}
+
+	// We don't need to check the credentials source because we currently only
+	// support one source (PostgreSQLConnectionSecret), which is required and
+	// enforced by the ProviderConfig schema.
+	ref := pc.Spec.Credentials.ConnectionSecretRef
+	if ref == nil {
+		return nil, errors.New(errNoSecretRef)
+	}
+
+	s := &corev1.Secret{}
+	if err := c.kube.Get(ctx, types.NamespacedName{Namespace: ref.Namespace, Name: ref.Name}, s); err != nil {
+		return nil, errors.Wrap(err, errGetSecret)
+	}
+
+	return &external{db: c.newDB(s.Data, pc.Spec.DefaultDatabase, clients.ToString(pc.Spec.SSLMode))}, nil
+}
+
+type external struct{ db xsql.DB }
+
+func (c *external) observe(ctx context.Context, name string) (*v1alpha1.TablespaceParameters, error) {
+	observed := &v1alpha1.TablespaceParameters{
+		Owner: new(string),
+	}
+
+	query := "SELECT " +
+		"pg_catalog.pg_get_userbyid(spcowner), " +
+		"pg_catalog.pg_tablespace_location(oid), " +
+		"spcoptions " +
+		"FROM pg_tablespace WHERE spcname = $1"
+
+	var opts []string
+	err := c.db.Scan(ctx, xsql.Query{String: query, Parameters: []interface{}{name}},
+		observed.Owner,
+		&observed.Location,
+		pq.Array(&opts),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	o := postgresql.ParseOptions(opts)
+	if v, ok := o[optionSeqPageCost]; ok {
+		observed.SeqPageCost = &v
+	}
+	if v, ok := o[optionRandomPageCost]; ok {
+		observed.RandomPageCost = &v
+	}
+	return observed, nil
+}
+
+func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.Tablespace)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotTablespace)
+	}
+
+	observed, err := c.observe(ctx, meta.GetExternalName(cr))
+	if xsql.IsNoRows(err) {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errSelectTablespace)
+	}
+
+	cr.SetConditions(xpv1.Available())
+
+	return managed.ExternalObservation{
+		ResourceExists:          true,
+		ResourceLateInitialized: lateInit(observed, &cr.Spec.ForProvider),
+		ResourceUpToDate:        upToDate(observed, cr.Spec.ForProvider),
+	}, nil
+}
+
+func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1alpha1.Tablespace)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotTablespace)
+	}
+
+	cr.SetConditions(xpv1.Creating())
+
+	var b strings.Builder
+	b.WriteString("CREATE TABLESPACE ")
+	b.WriteString(pq.QuoteIdentifier(meta.GetExternalName(cr)))
+	if cr.Spec.ForProvider.Owner != nil {
+		b.WriteString(" OWNER ")
+		b.WriteString(pq.QuoteIdentifier(*cr.Spec.ForProvider.Owner))
+	}
+	b.WriteString(" LOCATION ")
+	b.WriteString(pq.QuoteLiteral(cr.Spec.ForProvider.Location))
+	if o := setOptions(cr.Spec.ForProvider); len(o) > 0 {
+		b.WriteString(" WITH (")
+		b.WriteString(strings.Join(o, ", "))
+		b.WriteString(")")
+	}
+
+	// CREATE TABLESPACE cannot be executed inside a transaction block.
+	return managed.ExternalCreation{}, errors.Wrap(c.db.Exec(ctx, xsql.Query{String: b.String()}), errCreateTablespace)
+}
+
+func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*v1alpha1.Tablespace)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotTablespace)
+	}
+
+	observed, err := c.observe(ctx, meta.GetExternalName(cr))
+	if err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errSelectTablespace)
+	}
+
+	ts := pq.QuoteIdentifier(meta.GetExternalName(cr))
+	desired := cr.Spec.ForProvider
+
+	ql := []xsql.Query{}
+	if desired.Owner != nil && *observed.Owner != *desired.Owner {
+		ql = append(ql, xsql.Query{String: fmt.Sprintf("ALTER TABLESPACE %s OWNER TO %s", ts, pq.QuoteIdentifier(*desired.Owner))})
+	}
+	if !costUpToDate(observed.SeqPageCost, desired.SeqPageCost) || !costUpToDate(observed.RandomPageCost, desired.RandomPageCost) {
+		ql = append(ql, xsql.Query{String: fmt.Sprintf("ALTER TABLESPACE %s SET (%s)", ts, strings.Join(setOptions(desired), ", "))})
+	}
+	if len(ql) == 0 {
+		return managed.ExternalUpdate{}, nil
+	}
+
+	return managed.ExternalUpdate{}, errors.Wrap(c.db.ExecTx(ctx, ql), errUpdateTablespace)
+}
+
+func (c *external) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1alpha1.Tablespace)
+	if !ok {
+		return errors.New(errNotTablespace)
+	}
+
+	cr.SetConditions(xpv1.Deleting())
+
+	err := c.db.Exec(ctx, xsql.Query{String: "DROP TABLESPACE IF EXISTS " + pq.QuoteIdentifier(meta.GetExternalName(cr))})
+	return errors.Wrap(err, errDropTablespace)
+}
+
+// setOptions returns the storage options of the tablespace as they appear in
+// the WITH clause of CREATE TABLESPACE and ALTER TABLESPACE ... SET.
+func setOptions(p v1alpha1.TablespaceParameters) []string {
+	o := []string{}
+	if p.SeqPageCost != nil {
+		o = append(o, optionSeqPageCost+" = "+pq.QuoteLiteral(*p.SeqPageCost))
+	}
+	if p.RandomPageCost != nil {
+		o = append(o, optionRandomPageCost+" = "+pq.QuoteLiteral(*p.RandomPageCost))
+	}
+	return o
+}
+
+// costUpToDate compares page costs numerically, so that e.g. 1.0 and 1 are
+// considered equal.
+func costUpToDate(observed, desired *string) bool {
+	if desired == nil {
+		return true
+	}
+	if observed == nil {
+		return false
+	}
+	o, err := strconv.ParseFloat(*observed, 64)
+	if err != nil {
+		return false
+	}
+	d, err := strconv.ParseFloat(*desired, 64)
+	if err != nil {
+		return false
+	}
+	return o == d
+}
+
+func upToDate(observed *v1alpha1.TablespaceParameters, desired v1alpha1.TablespaceParameters) bool {
+	if desired.Owner != nil && *observed.Owner != *desired.Owner {
+		return false
+	}
+	return costUpToDate(observed.SeqPageCost, desired.SeqPageCost) && costUpToDate(observed.RandomPageCost, desired.RandomPageCost)
+}
+
+func lateInit(observed *v1alpha1.TablespaceParameters, desired *v1alpha1.TablespaceParameters) bool {
+	li := false
+
+	if desired.Owner == nil {
+		desired.Owner = observed.Owner
+		li = true
+	}
+	if desired.SeqPageCost == nil && observed.SeqPageCost != nil {
+		desired.SeqPageCost = observed.SeqPageCost
+		li = true
+	}
+	if desired.RandomPageCost == nil && observed.RandomPageCost != nil {
+		desired.RandomPageCost = observed.RandomPageCost
+		li = true
+	}
+
+	return li
+}
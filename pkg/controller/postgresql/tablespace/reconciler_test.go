@@ -0,0 +1,673 @@
+/*
+Copyright 2022 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tablespace
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane-contrib/provider-sql/apis/postgresql/v1alpha1"
+	"github.com/crossplane-contrib/provider-sql/pkg/clients/xsql"
+)
+
+type mockDB struct {
+	MockExec                 func(ctx context.Context, q xsql.Query) error
+	MockExecTx               func(ctx context.Context, ql []xsql.Query) error
+	MockScan                 func(ctx context.Context, q xsql.Query, dest ...interface{}) error
+	MockGetConnectionDetails func(username, password string) managed.ConnectionDetails
+}
+
+func (m mockDB) Exec(ctx context.Context, q xsql.Query) error {
+	return m.MockExec(ctx, q)
+}
+func (m mockDB) ExecTx(ctx context.Context, ql []xsql.Query) error {
+	return m.MockExecTx(ctx, ql)
+}
+func (m mockDB) Scan(ctx context.Context, q xsql.Query, dest ...interface{}) error {
+	return m.MockScan(ctx, q, dest...)
+}
+func (m mockDB) Query(ctx context.Context, q xsql.Query) (*sql.Rows, error) {
+	return &sql.Rows{}, nil
+}
+func (m mockDB) GetConnectionDetails(username, password string) managed.ConnectionDetails {
+	return m.MockGetConnectionDetails(username, password)
+}
+
+// scanTablespace returns a MockScan that reports a tablespace owned by the
+// supplied role with the supplied options.
+func scanTablespace(owner string, opts ...string) func(ctx context.Context, q xsql.Query, dest ...interface{}) error {
+	return func(ctx context.Context, q xsql.Query, dest ...interface{}) error {
+		*dest[0].(*string) = owner
+		*dest[1].(*string) = "/data/fast"
+		*dest[2].(*pq.StringArray) = opts
+		return nil
+	}
+}
+
+func TestConnect(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type fields struct {
+		kube  client.Client
+		usage resource.Tracker
+		newDB func(creds map[string][]byte, database string, sslmode string) xsql.DB
+	}
+
+	type args struct {
+		ctx context.Context
+		mg  resource.Managed
+	}
+
+	cases := map[string]struct {
+		reason string
+		fields fields
+		args   args
+		want   error
+	}{
+		"ErrNotTablespace": {
+			reason: "An error should be returned if the managed resource is not a Tablespace",
+			args: args{
+				mg: nil,
+			},
+			want: errors.New(errNotTablespace),
+		},
+		"ErrTrackProviderConfigUsage": {
+			reason: "An error should be returned if we can't track our ProviderConfig usage",
+			fields: fields{
+				usage: resource.TrackerFn(func(ctx context.Context, mg resource.Managed) error { return errBoom }),
+			},
+			args: args{
+				mg: &v1alpha1.Tablespace{},
+			},
+			want: errors.Wrap(errBoom, errTrackPCUsage),
+		},
+		"ErrGetProviderConfig": {
+			reason: "An error should be returned if we can't get our ProviderConfig",
+			fields: fields{
+				kube: &test.MockClient{
+					MockGet: test.NewMockGetFn(errBoom),
+				},
+				usage: resource.TrackerFn(func(ctx context.Context, mg resource.Managed) error { return nil }),
+			},
+			args: args{
+				mg: &v1alpha1.Tablespace{
+					Spec: v1alpha1.TablespaceSpec{
+						ResourceSpec: xpv1.ResourceSpec{
+							ProviderConfigReference: &xpv1.Reference{},
+						},
+					},
+				},
+			},
+			want: errors.Wrap(errBoom, errGetPC),
+		},
+		"ErrMissingConnectionSecret": {
+			reason: "An error should be returned if our ProviderConfig doesn't specify a connection secret",
+			fields: fields{
+				kube: &test.MockClient{
+					// We call get to populate the Database struct, then again
+					// to populate the (empty) ProviderConfig struct, resulting
+					// in a ProviderConfig with a nil connection secret.
+					MockGet: test.NewMockGetFn(nil),
+				},
+				usage: resource.TrackerFn(func(ctx context.Context, mg resource.Managed) error { return nil }),
+			},
+			args: args{
+				mg: &v1alpha1.Tablespace{
+					Spec: v1alpha1.TablespaceSpec{
+						ResourceSpec: xpv1.ResourceSpec{
+							ProviderConfigReference: &xpv1.Reference{},
+						},
+					},
+				},
+			},
+			want: errors.New(errNoSecretRef),
+		},
+		"ErrGetConnectionSecret": {
+			reason: "An error should be returned if we can't get our ProviderConfig's connection secret",
+			fields: fields{
+				kube: &test.MockClient{
+					MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+						switch o := obj.(type) {
+						case *v1alpha1.ProviderConfig:
+							o.Spec.Credentials.ConnectionSecretRef = &xpv1.SecretReference{}
+						case *corev1.Secret:
+							return errBoom
+						}
+						return nil
+					}),
+				},
+				usage: resource.TrackerFn(func(ctx context.Context, mg resource.Managed) error { return nil }),
+			},
+			args: args{
+				mg: &v1alpha1.Tablespace{
+					Spec: v1alpha1.TablespaceSpec{
+						ResourceSpec: xpv1.ResourceSpec{
+							ProviderConfigReference: &xpv1.Reference{},
+						},
+					},
+				},
+			},
+			want: errors.Wrap(errBoom, errGetSecret),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := &connector{kube: tc.fields.kube, usage: tc.fields.usage, newDB: tc.fields.newDB}
+			_, err := e.Connect(tc.args.ctx, tc.args.mg)
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Connect(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestObserve(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type fields struct {
+		db xsql.DB
+	}
+
+	type args struct {
+		ctx context.Context
+		mg  resource.Managed
+	}
+
+	type want struct {
+		o   managed.ExternalObservation
+		mg  resource.Managed
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		fields fields
+		args   args
+		want   want
+	}{
+		"ErrNotTablespace": {
+			reason: "An error should be returned if the managed resource is not a Tablespace",
+			args: args{
+				mg: nil,
+			},
+			want: want{
+				err: errors.New(errNotTablespace),
+			},
+		},
+		"ErrNoTablespace": {
+			reason: "We should return ResourceExists: false when no tablespace is found",
+			fields: fields{
+				db: mockDB{
+					MockScan: func(ctx context.Context, q xsql.Query, dest ...interface{}) error { return sql.ErrNoRows },
+				},
+			},
+			args: args{
+				mg: &v1alpha1.Tablespace{},
+			},
+			want: want{
+				o:  managed.ExternalObservation{ResourceExists: false},
+				mg: &v1alpha1.Tablespace{},
+			},
+		},
+		"ErrSelectTablespace": {
+			reason: "We should return any errors encountered while trying to select the tablespace",
+			fields: fields{
+				db: mockDB{
+					MockScan: func(ctx context.Context, q xsql.Query, dest ...interface{}) error { return errBoom },
+				},
+			},
+			args: args{
+				mg: &v1alpha1.Tablespace{},
+			},
+			want: want{
+				err: errors.Wrap(errBoom, errSelectTablespace),
+				mg:  &v1alpha1.Tablespace{},
+			},
+		},
+		"SuccessLateInit": {
+			reason: "Unset parameters should be late initialized from the observed tablespace",
+			fields: fields{
+				db: mockDB{
+					MockScan: scanTablespace("postgres", "random_page_cost=1.1"),
+				},
+			},
+			args: args{
+				mg: &v1alpha1.Tablespace{
+					Spec: v1alpha1.TablespaceSpec{
+						ForProvider: v1alpha1.TablespaceParameters{Location: "/data/fast"},
+					},
+				},
+			},
+			want: want{
+				o: managed.ExternalObservation{
+					ResourceExists:          true,
+					ResourceLateInitialized: true,
+					ResourceUpToDate:        true,
+				},
+				mg: &v1alpha1.Tablespace{
+					Spec: v1alpha1.TablespaceSpec{
+						ForProvider: v1alpha1.TablespaceParameters{
+							Location:       "/data/fast",
+							Owner:          pointer.String("postgres"),
+							RandomPageCost: pointer.String("1.1"),
+						},
+					},
+					Status: v1alpha1.TablespaceStatus{
+						ResourceStatus: xpv1.ResourceStatus{
+							ConditionedStatus: xpv1.ConditionedStatus{
+								Conditions: []xpv1.Condition{xpv1.Available()},
+							},
+						},
+					},
+				},
+			},
+		},
+		"CostsCompareNumerically": {
+			reason: "Page costs that are numerically equal should be considered up to date",
+			fields: fields{
+				db: mockDB{
+					MockScan: scanTablespace("postgres", "seq_page_cost=1"),
+				},
+			},
+			args: args{
+				mg: &v1alpha1.Tablespace{
+					Spec: v1alpha1.TablespaceSpec{
+						ForProvider: v1alpha1.TablespaceParameters{
+							Owner:       pointer.String("postgres"),
+							SeqPageCost: pointer.String("1.0"),
+						},
+					},
+				},
+			},
+			want: want{
+				o: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: true,
+				},
+				mg: &v1alpha1.Tablespace{
+					Spec: v1alpha1.TablespaceSpec{
+						ForProvider: v1alpha1.TablespaceParameters{
+							Owner:       pointer.String("postgres"),
+							SeqPageCost: pointer.String("1.0"),
+						},
+					},
+					Status: v1alpha1.TablespaceStatus{
+						ResourceStatus: xpv1.ResourceStatus{
+							ConditionedStatus: xpv1.ConditionedStatus{
+								Conditions: []xpv1.Condition{xpv1.Available()},
+							},
+						},
+					},
+				},
+			},
+		},
+		"OwnerDrift": {
+			reason: "We should return ResourceUpToDate: false when the tablespace is owned by another role",
+			fields: fields{
+				db: mockDB{
+					MockScan: scanTablespace("postgres"),
+				},
+			},
+			args: args{
+				mg: &v1alpha1.Tablespace{
+					Spec: v1alpha1.TablespaceSpec{
+						ForProvider: v1alpha1.TablespaceParameters{Owner: pointer.String("owner")},
+					},
+				},
+			},
+			want: want{
+				o: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: false,
+				},
+				mg: &v1alpha1.Tablespace{
+					Spec: v1alpha1.TablespaceSpec{
+						ForProvider: v1alpha1.TablespaceParameters{Owner: pointer.String("owner")},
+					},
+					Status: v1alpha1.TablespaceStatus{
+						ResourceStatus: xpv1.ResourceStatus{
+							ConditionedStatus: xpv1.ConditionedStatus{
+								Conditions: []xpv1.Condition{xpv1.Available()},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{db: tc.fields.db}
+			got, err := e.Observe(tc.args.ctx, tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, got); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.mg, tc.args.mg, test.EquateConditions()); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want managed resource, +got managed resource:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestCreate(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type fields struct {
+		db xsql.DB
+	}
+
+	type args struct {
+		ctx context.Context
+		mg  resource.Managed
+	}
+
+	type want struct {
+		c   managed.ExternalCreation
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		fields fields
+		args   args
+		want   want
+	}{
+		"ErrNotTablespace": {
+			reason: "An error should be returned if the managed resource is not a Tablespace",
+			args: args{
+				mg: nil,
+			},
+			want: want{
+				err: errors.New(errNotTablespace),
+			},
+		},
+		"ErrExec": {
+			reason: "Any errors encountered while creating the tablespace should be returned",
+			fields: fields{
+				db: &mockDB{
+					MockExec: func(ctx context.Context, q xsql.Query) error { return errBoom },
+				},
+			},
+			args: args{
+				mg: &v1alpha1.Tablespace{},
+			},
+			want: want{
+				err: errors.Wrap(errBoom, errCreateTablespace),
+			},
+		},
+		"Success": {
+			reason: "The tablespace should be created with its owner and storage options",
+			fields: fields{
+				db: &mockDB{
+					MockExec: func(ctx context.Context, q xsql.Query) error {
+						want := `CREATE TABLESPACE "fast" OWNER "owner" LOCATION '/data/fast' WITH (seq_page_cost = '1', random_page_cost = '1.1')`
+						if q.String != want {
+							return errors.Errorf("unexpected query: %s", q.String)
+						}
+						return nil
+					},
+				},
+			},
+			args: args{
+				mg: &v1alpha1.Tablespace{
+					ObjectMeta: metav1.ObjectMeta{
+						Annotations: map[string]string{meta.AnnotationKeyExternalName: "fast"},
+					},
+					Spec: v1alpha1.TablespaceSpec{
+						ForProvider: v1alpha1.TablespaceParameters{
+							Location:       "/data/fast",
+							Owner:          pointer.String("owner"),
+							SeqPageCost:    pointer.String("1"),
+							RandomPageCost: pointer.String("1.1"),
+						},
+					},
+				},
+			},
+			want: want{
+				err: nil,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{db: tc.fields.db}
+			got, err := e.Create(tc.args.ctx, tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Create(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.c, got); diff != "" {
+				t.Errorf("\n%s\ne.Create(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type fields struct {
+		db xsql.DB
+	}
+
+	type args struct {
+		ctx context.Context
+		mg  resource.Managed
+	}
+
+	type want struct {
+		c   managed.ExternalUpdate
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		fields fields
+		args   args
+		want   want
+	}{
+		"ErrNotTablespace": {
+			reason: "An error should be returned if the managed resource is not a Tablespace",
+			args: args{
+				mg: nil,
+			},
+			want: want{
+				err: errors.New(errNotTablespace),
+			},
+		},
+		"ErrSelectTablespace": {
+			reason: "Any errors encountered while selecting the tablespace should be returned",
+			fields: fields{
+				db: &mockDB{
+					MockScan: func(ctx context.Context, q xsql.Query, dest ...interface{}) error { return errBoom },
+				},
+			},
+			args: args{
+				mg: &v1alpha1.Tablespace{},
+			},
+			want: want{
+				err: errors.Wrap(errBoom, errSelectTablespace),
+			},
+		},
+		"ErrExecTx": {
+			reason: "Any errors encountered while altering the tablespace should be returned",
+			fields: fields{
+				db: &mockDB{
+					MockScan:   scanTablespace("postgres"),
+					MockExecTx: func(ctx context.Context, ql []xsql.Query) error { return errBoom },
+				},
+			},
+			args: args{
+				mg: &v1alpha1.Tablespace{
+					Spec: v1alpha1.TablespaceSpec{
+						ForProvider: v1alpha1.TablespaceParameters{Owner: pointer.String("owner")},
+					},
+				},
+			},
+			want: want{
+				err: errors.Wrap(errBoom, errUpdateTablespace),
+			},
+		},
+		"NoOp": {
+			reason: "No queries should be executed when the tablespace is up to date",
+			fields: fields{
+				db: &mockDB{
+					MockScan: scanTablespace("owner"),
+				},
+			},
+			args: args{
+				mg: &v1alpha1.Tablespace{
+					Spec: v1alpha1.TablespaceSpec{
+						ForProvider: v1alpha1.TablespaceParameters{Owner: pointer.String("owner")},
+					},
+				},
+			},
+			want: want{
+				err: nil,
+			},
+		},
+		"Success": {
+			reason: "Only the owner and options that drifted should be altered",
+			fields: fields{
+				db: &mockDB{
+					MockScan: scanTablespace("postgres", "seq_page_cost=1"),
+					MockExecTx: func(ctx context.Context, ql []xsql.Query) error {
+						want := []xsql.Query{
+							{String: `ALTER TABLESPACE "fast" OWNER TO "owner"`},
+							{String: `ALTER TABLESPACE "fast" SET (seq_page_cost = '2')`},
+						}
+						if diff := cmp.Diff(want, ql); diff != "" {
+							return errors.New(diff)
+						}
+						return nil
+					},
+				},
+			},
+			args: args{
+				mg: &v1alpha1.Tablespace{
+					ObjectMeta: metav1.ObjectMeta{
+						Annotations: map[string]string{meta.AnnotationKeyExternalName: "fast"},
+					},
+					Spec: v1alpha1.TablespaceSpec{
+						ForProvider: v1alpha1.TablespaceParameters{
+							Owner:       pointer.String("owner"),
+							SeqPageCost: pointer.String("2"),
+						},
+					},
+				},
+			},
+			want: want{
+				err: nil,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{db: tc.fields.db}
+			got, err := e.Update(tc.args.ctx, tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Update(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.c, got); diff != "" {
+				t.Errorf("\n%s\ne.Update(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestDelete(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type fields struct {
+		db xsql.DB
+	}
+
+	type args struct {
+		ctx context.Context
+		mg  resource.Managed
+	}
+
+	cases := map[string]struct {
+		reason string
+		fields fields
+		args   args
+		want   error
+	}{
+		"ErrNotTablespace": {
+			reason: "An error should be returned if the managed resource is not a Tablespace",
+			args: args{
+				mg: nil,
+			},
+			want: errors.New(errNotTablespace),
+		},
+		"ErrDropTablespace": {
+			reason: "Errors dropping a tablespace should be returned",
+			fields: fields{
+				db: &mockDB{
+					MockExec: func(ctx context.Context, q xsql.Query) error { return errBoom },
+				},
+			},
+			args: args{
+				mg: &v1alpha1.Tablespace{},
+			},
+			want: errors.Wrap(errBoom, errDropTablespace),
+		},
+		"Success": {
+			reason: "No error should be returned if the tablespace was dropped",
+			fields: fields{
+				db: &mockDB{
+					MockExec: func(ctx context.Context, q xsql.Query) error { return nil },
+				},
+			},
+			args: args{
+				mg: &v1alpha1.Tablespace{},
+			},
+			want: nil,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{db: tc.fields.db}
+			err := e.Delete(tc.args.ctx, tc.args.mg)
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Delete(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,356 @@
+/*
+Copyright 2022 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package usermapping
+
+import (
+	"context"
+	"crypto/subtle"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/crossplane-contrib/provider-sql/apis/postgresql/v1alpha1"
+	"github.com/crossplane-contrib/provider-sql/pkg/clients"
+	"github.com/crossplane-contrib/provider-sql/pkg/clients/postgresql"
+	"github.com/crossplane-contrib/provider-sql/pkg/clients/xsql"
+)
+
+const (
+	errTrackPCUsage = "cannot track ProviderConfig usage"
+	errGetPC        = "cannot get ProviderConfig"
+	errNoSecretRef  = "ProviderConfig does not reference a credentials Secret"
+	errGetSecret    = "cannot get credentials Secret"
+
+	errNotUserMapping     = "managed resource is not a UserMapping custom resource"
+	errSelectUserMapping  = "cannot select user mapping"
+	errCreateUserMapping  = "cannot create user mapping"
+	errUpdateUserMapping  = "cannot update user mapping"
+	errDropUserMapping    = "cannot drop user mapping"
+	errGetRemoteCredsFail = "cannot get remote credentials secret"
+	errNoRole             = "role not passed or could not be resolved"
+	errNoServer           = "server not passed or could not be resolved"
+
+	// Options of the mapping populated from the remote credentials secret.
+	optionUser     = "user"
+	optionPassword = "password"
+
+	// pg_user_mappings reports mappings for PUBLIC with this user name.
+	publicRole = "public"
+
+	maxConcurrency = 5
+)
+
+// Setup adds a controller that reconciles UserMapping managed resources.
+func Setup(mgr ctrl.Manager, l logging.Logger) error {
+	name := managed.ControllerName(v1alpha1.UserMappingGroupKind)
+
+	t := resource.NewProviderConfigUsageTracker(mgr.GetClient(), &v1alpha1.ProviderConfigUsage{})
+	r := managed.NewReconciler(mgr,
+		resource.ManagedKind(v1alpha1.UserMappingGroupVersionKind),
+		managed.WithExternalConnecter(&connector{kube: mgr.GetClient(), usage: t, newDB: postgresql.New}),
+		managed.WithLogger(l.WithValues("controller", name)),
+		managed.WithPollInterval(10*time.Minute),
+		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))))
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		For(&v1alpha1.UserMapping{}).
+		WithOptions(controller.Options{
+			MaxConcurrentReconciles: maxConcurrency,
+		}).
+		Complete(r)
+}
+
+type connector struct {
+	kube  client.Client
+	usage resource.Tracker
+	newDB func(creds map[string][]byte, database string, sslmode string) xsql.DB
+}
+
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	cr, ok := mg.(*v1alpha1.UserMapping)
+	if !ok {
+		return nil, errors.New(errNotUserMapping)
+	}
+
+	if err := c.usage.Track(ctx, mg); err != nil {
+		return nil, errors.Wrap(err, errTrackPCUsage)
+	}
+
+	// ProviderConfigReference could theoretically be nil, but in practice the
+	// DefaultProviderConfig initializer will set it before we get here.
+	pc := &v1alpha1.ProviderConfig{}
+	if err := c.kube.Get(ctx, types.NamespacedName{Name: cr.GetProviderConfigReference().Name}, pc); err != nil {
+		return nil, errors.Wrap(err, errGetPC)
+	}
+
+	// We don't need to check the credentials source because we currently only
+	// support one source (PostgreSQLConnectionSecret), which is required and
+	// enforced by the ProviderConfig schema.
+	ref := pc.Spec.Credentials.ConnectionSecretRef
+	if ref == nil {
+		return nil, errors.New(errNoSecretRef)
+	}
+
+	s := &corev1.Secret{}
+	if err := c.kube.Get(ctx, types.NamespacedName{Namespace: ref.Namespace, Name: ref.Name}, s); err != nil {
+		return nil, errors.Wrap(err, errGetSecret)
+	}
+
+	// User mappings live alongside their foreign server, so we must not fall
+	// back to the default database if the user expected one to be resolved.
+	database := pc.Spec.DefaultDatabase
+	if cr.Spec.ForProvider.Database != nil {
+		database = *cr.Spec.ForProvider.Database
+	}
+
+	return &external{
+		db:   c.newDB(s.Data, database, clients.ToString(pc.Spec.SSLMode)),
+		kube: c.kube,
+	}, nil
+}
+
+type external struct {
+	db   xsql.DB
+	kube client.Client
+}
+
+// roleIdentifier returns the role as it must appear in a user mapping
+// statement, and as it is reported by pg_user_mappings.
+func roleIdentifier(role string) (sql string, usename string) {
+	if strings.EqualFold(role, publicRole) {
+		return "PUBLIC", publicRole
+	}
+	return pq.QuoteIdentifier(role), role
+}
+
+// observe returns the options of the mapping, and whether they are visible
+// to us. pg_user_mappings hides the options, including the remote password,
+// from roles that are neither superusers nor the mapped role, and from roles
+// that do not own the server of a PUBLIC mapping. The visibility condition
+// below mirrors the one of the view.
+func (c *external) observe(ctx context.Context, gp v1alpha1.UserMappingParameters) (map[string]string, bool, error) {
+	_, usename := roleIdentifier(*gp.Role)
+
+	query := `SELECT um.umoptions,
+	  (um.umuser <> 0 AND um.usename = current_user
+	    AND (pg_has_role(s.srvowner, 'USAGE') OR has_server_privilege(s.oid, 'USAGE')))
+	  OR (um.umuser = 0 AND pg_has_role(s.srvowner, 'USAGE'))
+	  OR COALESCE((SELECT rolsuper FROM pg_roles WHERE rolname = current_user), false)
+	FROM pg_user_mappings AS um
+	JOIN pg_foreign_server AS s ON s.oid = um.srvid
+	WHERE um.srvname = $1 AND um.usename = $2`
+
+	var opts []string
+	var visible bool
+	if err := c.db.Scan(ctx, xsql.Query{String: query, Parameters: []interface{}{*gp.Server, usename}}, pq.Array(&opts), &visible); err != nil {
+		return nil, false, err
+	}
+	return postgresql.ParseOptions(opts), visible, nil
+}
+
+// optionsUpToDate returns true if the observed options are the desired ones.
+// The remote password is compared in constant time rather than along with
+// the other options.
+func optionsUpToDate(observed, desired map[string]string) bool {
+	op, ook := observed[optionPassword]
+	dp, dok := desired[optionPassword]
+	if ook != dok || subtle.ConstantTimeCompare([]byte(op), []byte(dp)) != 1 {
+		return false
+	}
+	return cmp.Equal(withoutPassword(observed), withoutPassword(desired), cmpopts.EquateEmpty())
+}
+
+func withoutPassword(opts map[string]string) map[string]string {
+	out := make(map[string]string, len(opts))
+	for k, v := range opts {
+		if k != optionPassword {
+			out[k] = v
+		}
+	}
+	return out
+}
+
+// desiredOptions returns the options of the mapping, including the remote
+// credentials read from the referenced secret.
+func (c *external) desiredOptions(ctx context.Context, gp v1alpha1.UserMappingParameters) (map[string]string, error) {
+	opts := make(map[string]string, len(gp.Options)+2)
+	for _, o := range gp.Options {
+		opts[o.Name] = o.Value
+	}
+
+	ref := gp.RemoteCredentialsSecretRef
+	if ref == nil {
+		return opts, nil
+	}
+
+	s := &corev1.Secret{}
+	if err := c.kube.Get(ctx, types.NamespacedName{Namespace: ref.Namespace, Name: ref.Name}, s); err != nil {
+		return nil, errors.Wrap(err, errGetRemoteCredsFail)
+	}
+	if u, ok := s.Data[xpv1.ResourceCredentialsSecretUserKey]; ok {
+		opts[optionUser] = string(u)
+	}
+	if p, ok := s.Data[xpv1.ResourceCredentialsSecretPasswordKey]; ok {
+		opts[optionPassword] = string(p)
+	}
+	return opts, nil
+}
+
+func validate(gp v1alpha1.UserMappingParameters) error {
+	if gp.Role == nil {
+		return errors.New(errNoRole)
+	}
+	if gp.Server == nil {
+		return errors.New(errNoServer)
+	}
+	return nil
+}
+
+func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.UserMapping)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotUserMapping)
+	}
+
+	gp := cr.Spec.ForProvider
+	if err := validate(gp); err != nil {
+		return managed.ExternalObservation{}, err
+	}
+
+	observed, visible, err := c.observe(ctx, gp)
+
+	// If the database we try to connect on does not exist then
+	// there cannot be a user mapping in that database either.
+	if xsql.IsNoRows(err) || postgresql.IsInvalidCatalog(err) {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errSelectUserMapping)
+	}
+
+	desired, err := c.desiredOptions(ctx, gp)
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
+
+	cr.SetConditions(xpv1.Available())
+
+	// We can't tell whether options we can't see have drifted, so we don't
+	// try to update them.
+	return managed.ExternalObservation{
+		ResourceExists:   true,
+		ResourceUpToDate: !visible || optionsUpToDate(observed, desired),
+	}, nil
+}
+
+func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1alpha1.UserMapping)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotUserMapping)
+	}
+
+	gp := cr.Spec.ForProvider
+	if err := validate(gp); err != nil {
+		return managed.ExternalCreation{}, err
+	}
+
+	desired, err := c.desiredOptions(ctx, gp)
+	if err != nil {
+		return managed.ExternalCreation{}, err
+	}
+
+	cr.SetConditions(xpv1.Creating())
+
+	ro, _ := roleIdentifier(*gp.Role)
+	query := strings.TrimSpace(fmt.Sprintf("CREATE USER MAPPING FOR %s SERVER %s %s",
+		ro,
+		pq.QuoteIdentifier(*gp.Server),
+		postgresql.OptionsClause(desired),
+	))
+
+	return managed.ExternalCreation{}, errors.Wrap(c.db.Exec(ctx, xsql.Query{String: query}), errCreateUserMapping)
+}
+
+func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*v1alpha1.UserMapping)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotUserMapping)
+	}
+
+	gp := cr.Spec.ForProvider
+	if err := validate(gp); err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+
+	observed, visible, err := c.observe(ctx, gp)
+	if err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errSelectUserMapping)
+	}
+	if !visible {
+		return managed.ExternalUpdate{}, nil
+	}
+	desired, err := c.desiredOptions(ctx, gp)
+	if err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+
+	o := postgresql.AlterOptionsClause(observed, desired)
+	if o == "" {
+		return managed.ExternalUpdate{}, nil
+	}
+
+	ro, _ := roleIdentifier(*gp.Role)
+	query := fmt.Sprintf("ALTER USER MAPPING FOR %s SERVER %s %s", ro, pq.QuoteIdentifier(*gp.Server), o)
+
+	return managed.ExternalUpdate{}, errors.Wrap(c.db.Exec(ctx, xsql.Query{String: query}), errUpdateUserMapping)
+}
+
+func (c *external) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1alpha1.UserMapping)
+	if !ok {
+		return errors.New(errNotUserMapping)
+	}
+
+	gp := cr.Spec.ForProvider
+	if err := validate(gp); err != nil {
+		return err
+	}
+
+	cr.SetConditions(xpv1.Deleting())
+
+	ro, _ := roleIdentifier(*gp.Role)
+	query := fmt.Sprintf("DROP USER MAPPING IF EXISTS FOR %s SERVER %s", ro, pq.QuoteIdentifier(*gp.Server))
+
+	return errors.Wrap(c.db.Exec(ctx, xsql.Query{String: query}), errDropUserMapping)
+}
@@ -0,0 +1,723 @@
+/*
+Copyright 2022 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package usermapping
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane-contrib/provider-sql/apis/postgresql/v1alpha1"
+	"github.com/crossplane-contrib/provider-sql/pkg/clients/xsql"
+)
+
+type mockDB struct {
+	MockExec                 func(ctx context.Context, q xsql.Query) error
+	MockExecTx               func(ctx context.Context, ql []xsql.Query) error
+	MockScan                 func(ctx context.Context, q xsql.Query, dest ...interface{}) error
+	MockGetConnectionDetails func(username, password string) managed.ConnectionDetails
+}
+
+func (m mockDB) Exec(ctx context.Context, q xsql.Query) error {
+	return m.MockExec(ctx, q)
+}
+func (m mockDB) ExecTx(ctx context.Context, ql []xsql.Query) error {
+	return m.MockExecTx(ctx, ql)
+}
+func (m mockDB) Scan(ctx context.Context, q xsql.Query, dest ...interface{}) error {
+	return m.MockScan(ctx, q, dest...)
+}
+func (m mockDB) Query(ctx context.Context, q xsql.Query) (*sql.Rows, error) {
+	return &sql.Rows{}, nil
+}
+func (m mockDB) GetConnectionDetails(username, password string) managed.ConnectionDetails {
+	return m.MockGetConnectionDetails(username, password)
+}
+
+// scanOptions returns a MockScan that reports a user mapping with the
+// supplied options.
+func scanOptions(opts ...string) func(ctx context.Context, q xsql.Query, dest ...interface{}) error {
+	return func(ctx context.Context, q xsql.Query, dest ...interface{}) error {
+		*dest[0].(*pq.StringArray) = opts
+		*dest[1].(*bool) = true
+		return nil
+	}
+}
+
+// scanHidden returns a MockScan for a mapping whose options we may not see.
+func scanHidden() func(ctx context.Context, q xsql.Query, dest ...interface{}) error {
+	return func(ctx context.Context, q xsql.Query, dest ...interface{}) error {
+		*dest[0].(*pq.StringArray) = nil
+		*dest[1].(*bool) = false
+		return nil
+	}
+}
+
+// remoteCreds returns a kube client that serves a remote credentials secret.
+func remoteCreds(user, pw string) client.Client {
+	return &test.MockClient{
+		MockGet: func(_ context.Context, key client.ObjectKey, obj client.Object) error {
+			s := corev1.Secret{Data: map[string][]byte{
+				xpv1.ResourceCredentialsSecretUserKey:     []byte(user),
+				xpv1.ResourceCredentialsSecretPasswordKey: []byte(pw),
+			}}
+			s.DeepCopyInto(obj.(*corev1.Secret))
+			return nil
+		},
+	}
+}
+
+func mapping(o ...func(*v1alpha1.UserMappingParameters)) *v1alpha1.UserMapping {
+	um := &v1alpha1.UserMapping{
+		Spec: v1alpha1.UserMappingSpec{
+			ForProvider: v1alpha1.UserMappingParameters{
+				Role:   pointer.StringPtr("local"),
+				Server: pointer.StringPtr("remote"),
+				RemoteCredentialsSecretRef: &xpv1.SecretReference{
+					Name:      "remote-creds",
+					Namespace: "default",
+				},
+			},
+		},
+	}
+	for _, fn := range o {
+		fn(&um.Spec.ForProvider)
+	}
+	return um
+}
+
+func TestConnect(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type fields struct {
+		kube  client.Client
+		usage resource.Tracker
+		newDB func(creds map[string][]byte, database string, sslmode string) xsql.DB
+	}
+
+	type args struct {
+		ctx context.Context
+		mg  resource.Managed
+	}
+
+	cases := map[string]struct {
+		reason string
+		fields fields
+		args   args
+		want   error
+	}{
+		"ErrNotUserMapping": {
+			reason: "An error should be returned if the managed resource is not a UserMapping",
+			args: args{
+				mg: nil,
+			},
+			want: errors.New(errNotUserMapping),
+		},
+		"ErrTrackProviderConfigUsage": {
+			reason: "An error should be returned if we can't track our ProviderConfig usage",
+			fields: fields{
+				usage: resource.TrackerFn(func(ctx context.Context, mg resource.Managed) error { return errBoom }),
+			},
+			args: args{
+				mg: &v1alpha1.UserMapping{},
+			},
+			want: errors.Wrap(errBoom, errTrackPCUsage),
+		},
+		"ErrGetProviderConfig": {
+			reason: "An error should be returned if we can't get our ProviderConfig",
+			fields: fields{
+				kube: &test.MockClient{
+					MockGet: test.NewMockGetFn(errBoom),
+				},
+				usage: resource.TrackerFn(func(ctx context.Context, mg resource.Managed) error { return nil }),
+			},
+			args: args{
+				mg: &v1alpha1.UserMapping{
+					Spec: v1alpha1.UserMappingSpec{
+						ResourceSpec: xpv1.ResourceSpec{
+							ProviderConfigReference: &xpv1.Reference{},
+						},
+					},
+				},
+			},
+			want: errors.Wrap(errBoom, errGetPC),
+		},
+		"ErrMissingConnectionSecret": {
+			reason: "An error should be returned if our ProviderConfig doesn't specify a connection secret",
+			fields: fields{
+				kube: &test.MockClient{
+					// We call get to populate the Database struct, then again
+					// to populate the (empty) ProviderConfig struct, resulting
+					// in a ProviderConfig with a nil connection secret.
+					MockGet: test.NewMockGetFn(nil),
+				},
+				usage: resource.TrackerFn(func(ctx context.Context, mg resource.Managed) error { return nil }),
+			},
+			args: args{
+				mg: &v1alpha1.UserMapping{
+					Spec: v1alpha1.UserMappingSpec{
+						ResourceSpec: xpv1.ResourceSpec{
+							ProviderConfigReference: &xpv1.Reference{},
+						},
+					},
+				},
+			},
+			want: errors.New(errNoSecretRef),
+		},
+		"ErrGetConnectionSecret": {
+			reason: "An error should be returned if we can't get our ProviderConfig's connection secret",
+			fields: fields{
+				kube: &test.MockClient{
+					MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+						switch o := obj.(type) {
+						case *v1alpha1.ProviderConfig:
+							o.Spec.Credentials.ConnectionSecretRef = &xpv1.SecretReference{}
+						case *corev1.Secret:
+							return errBoom
+						}
+						return nil
+					}),
+				},
+				usage: resource.TrackerFn(func(ctx context.Context, mg resource.Managed) error { return nil }),
+			},
+			args: args{
+				mg: &v1alpha1.UserMapping{
+					Spec: v1alpha1.UserMappingSpec{
+						ResourceSpec: xpv1.ResourceSpec{
+							ProviderConfigReference: &xpv1.Reference{},
+						},
+					},
+				},
+			},
+			want: errors.Wrap(errBoom, errGetSecret),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := &connector{kube: tc.fields.kube, usage: tc.fields.usage, newDB: tc.fields.newDB}
+			_, err := e.Connect(tc.args.ctx, tc.args.mg)
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Connect(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestObserve(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type fields struct {
+		db   xsql.DB
+		kube client.Client
+	}
+
+	type args struct {
+		ctx context.Context
+		mg  resource.Managed
+	}
+
+	type want struct {
+		o   managed.ExternalObservation
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		fields fields
+		args   args
+		want   want
+	}{
+		"ErrNotUserMapping": {
+			reason: "An error should be returned if the managed resource is not a UserMapping",
+			args: args{
+				mg: nil,
+			},
+			want: want{
+				err: errors.New(errNotUserMapping),
+			},
+		},
+		"ErrNoRole": {
+			reason: "An error should be returned if the role was not resolved",
+			args: args{
+				mg: mapping(func(p *v1alpha1.UserMappingParameters) { p.Role = nil }),
+			},
+			want: want{
+				err: errors.New(errNoRole),
+			},
+		},
+		"ErrNoServer": {
+			reason: "An error should be returned if the server was not resolved",
+			args: args{
+				mg: mapping(func(p *v1alpha1.UserMappingParameters) { p.Server = nil }),
+			},
+			want: want{
+				err: errors.New(errNoServer),
+			},
+		},
+		"ErrNoUserMapping": {
+			reason: "We should return ResourceExists: false when no user mapping is found",
+			fields: fields{
+				db: mockDB{
+					MockScan: func(ctx context.Context, q xsql.Query, dest ...interface{}) error { return sql.ErrNoRows },
+				},
+			},
+			args: args{
+				mg: mapping(),
+			},
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: false},
+			},
+		},
+		"ErrSelectUserMapping": {
+			reason: "We should return any errors encountered while trying to select the user mapping",
+			fields: fields{
+				db: mockDB{
+					MockScan: func(ctx context.Context, q xsql.Query, dest ...interface{}) error { return errBoom },
+				},
+			},
+			args: args{
+				mg: mapping(),
+			},
+			want: want{
+				err: errors.Wrap(errBoom, errSelectUserMapping),
+			},
+		},
+		"ErrGetRemoteCredentials": {
+			reason: "We should return any errors encountered while reading the remote credentials",
+			fields: fields{
+				db: mockDB{
+					MockScan: scanOptions(),
+				},
+				kube: &test.MockClient{
+					MockGet: test.NewMockGetFn(errBoom),
+				},
+			},
+			args: args{
+				mg: mapping(),
+			},
+			want: want{
+				err: errors.Wrap(errBoom, errGetRemoteCredsFail),
+			},
+		},
+		"SuccessPublic": {
+			reason: "PUBLIC mappings should be looked up as the public user",
+			fields: fields{
+				db: mockDB{
+					MockScan: func(ctx context.Context, q xsql.Query, dest ...interface{}) error {
+						if q.Parameters[1] != publicRole {
+							return errors.Errorf("unexpected user %v", q.Parameters[1])
+						}
+						return nil
+					},
+				},
+			},
+			args: args{
+				mg: mapping(func(p *v1alpha1.UserMappingParameters) {
+					p.Role = pointer.StringPtr("PUBLIC")
+					p.RemoteCredentialsSecretRef = nil
+				}),
+			},
+			want: want{
+				o: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: true,
+				},
+			},
+		},
+		"SuccessUpToDate": {
+			reason: "We should report the user mapping as up to date when credentials and options match",
+			fields: fields{
+				db: mockDB{
+					MockScan: scanOptions("user=app", "password=s3cr3t", "fetch_size=100"),
+				},
+				kube: remoteCreds("app", "s3cr3t"),
+			},
+			args: args{
+				mg: mapping(func(p *v1alpha1.UserMappingParameters) {
+					p.Options = []v1alpha1.ForeignOption{{Name: "fetch_size", Value: "100"}}
+				}),
+			},
+			want: want{
+				o: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: true,
+				},
+			},
+		},
+		"SuccessOptionsHidden": {
+			reason: "We should report the user mapping as up to date when we may not see its options",
+			fields: fields{
+				db: mockDB{
+					MockScan: scanHidden(),
+				},
+				kube: remoteCreds("app", "s3cr3t"),
+			},
+			args: args{
+				mg: mapping(func(p *v1alpha1.UserMappingParameters) {
+					p.Options = []v1alpha1.ForeignOption{{Name: "fetch_size", Value: "100"}}
+				}),
+			},
+			want: want{
+				o: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: true,
+				},
+			},
+		},
+		"SuccessPasswordRemoved": {
+			reason: "We should report the user mapping as outdated when it has no remote password",
+			fields: fields{
+				db: mockDB{
+					MockScan: scanOptions("user=app"),
+				},
+				kube: remoteCreds("app", ""),
+			},
+			args: args{
+				mg: mapping(),
+			},
+			want: want{
+				o: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: false,
+				},
+			},
+		},
+		"SuccessPasswordDrift": {
+			reason: "We should report the user mapping as outdated when the remote password changed",
+			fields: fields{
+				db: mockDB{
+					MockScan: scanOptions("user=app", "password=old"),
+				},
+				kube: remoteCreds("app", "s3cr3t"),
+			},
+			args: args{
+				mg: mapping(),
+			},
+			want: want{
+				o: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: false,
+				},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{db: tc.fields.db, kube: tc.fields.kube}
+			got, err := e.Observe(tc.args.ctx, tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, got); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestCreate(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type fields struct {
+		db   xsql.DB
+		kube client.Client
+	}
+
+	type args struct {
+		ctx context.Context
+		mg  resource.Managed
+	}
+
+	type want struct {
+		c   managed.ExternalCreation
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		fields fields
+		args   args
+		want   want
+	}{
+		"ErrNotUserMapping": {
+			reason: "An error should be returned if the managed resource is not a UserMapping",
+			args: args{
+				mg: nil,
+			},
+			want: want{
+				err: errors.New(errNotUserMapping),
+			},
+		},
+		"ErrExec": {
+			reason: "Any errors encountered while creating the user mapping should be returned",
+			fields: fields{
+				db: &mockDB{
+					MockExec: func(ctx context.Context, q xsql.Query) error { return errBoom },
+				},
+				kube: remoteCreds("app", "s3cr3t"),
+			},
+			args: args{
+				mg: mapping(),
+			},
+			want: want{
+				err: errors.Wrap(errBoom, errCreateUserMapping),
+			},
+		},
+		"Success": {
+			reason: "The remote credentials should be set as options of the user mapping",
+			fields: fields{
+				db: &mockDB{
+					MockExec: func(ctx context.Context, q xsql.Query) error {
+						want := `CREATE USER MAPPING FOR "local" SERVER "remote" OPTIONS ("password" 's3cr3t', "user" 'app')`
+						if q.String != want {
+							return errors.Errorf("unexpected query %q", q.String)
+						}
+						return nil
+					},
+				},
+				kube: remoteCreds("app", "s3cr3t"),
+			},
+			args: args{
+				mg: mapping(),
+			},
+			want: want{
+				err: nil,
+			},
+		},
+		"SuccessNoOptions": {
+			reason: "A user mapping without options should be created without an OPTIONS clause",
+			fields: fields{
+				db: &mockDB{
+					MockExec: func(ctx context.Context, q xsql.Query) error {
+						want := `CREATE USER MAPPING FOR PUBLIC SERVER "remote"`
+						if q.String != want {
+							return errors.Errorf("unexpected query %q", q.String)
+						}
+						return nil
+					},
+				},
+			},
+			args: args{
+				mg: mapping(func(p *v1alpha1.UserMappingParameters) {
+					p.Role = pointer.StringPtr("public")
+					p.RemoteCredentialsSecretRef = nil
+				}),
+			},
+			want: want{
+				err: nil,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{db: tc.fields.db, kube: tc.fields.kube}
+			got, err := e.Create(tc.args.ctx, tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Create(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.c, got); diff != "" {
+				t.Errorf("\n%s\ne.Create(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type fields struct {
+		db   xsql.DB
+		kube client.Client
+	}
+
+	type args struct {
+		ctx context.Context
+		mg  resource.Managed
+	}
+
+	type want struct {
+		u   managed.ExternalUpdate
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		fields fields
+		args   args
+		want   want
+	}{
+		"ErrNotUserMapping": {
+			reason: "An error should be returned if the managed resource is not a UserMapping",
+			args: args{
+				mg: nil,
+			},
+			want: want{
+				err: errors.New(errNotUserMapping),
+			},
+		},
+		"ErrSelectUserMapping": {
+			reason: "Errors selecting the current user mapping should be returned",
+			fields: fields{
+				db: &mockDB{
+					MockScan: func(ctx context.Context, q xsql.Query, dest ...interface{}) error { return errBoom },
+				},
+			},
+			args: args{
+				mg: mapping(),
+			},
+			want: want{
+				err: errors.Wrap(errBoom, errSelectUserMapping),
+			},
+		},
+		"ErrExec": {
+			reason: "Errors altering the user mapping should be returned",
+			fields: fields{
+				db: &mockDB{
+					MockScan: scanOptions("user=app", "password=old"),
+					MockExec: func(ctx context.Context, q xsql.Query) error { return errBoom },
+				},
+				kube: remoteCreds("app", "s3cr3t"),
+			},
+			args: args{
+				mg: mapping(),
+			},
+			want: want{
+				err: errors.Wrap(errBoom, errUpdateUserMapping),
+			},
+		},
+		"Success": {
+			reason: "Only the changed options should be altered",
+			fields: fields{
+				db: &mockDB{
+					MockScan: scanOptions("user=app", "password=old", "fetch_size=100"),
+					MockExec: func(ctx context.Context, q xsql.Query) error {
+						want := `ALTER USER MAPPING FOR "local" SERVER "remote" OPTIONS (SET "password" 's3cr3t', DROP "fetch_size")`
+						if q.String != want {
+							return errors.Errorf("unexpected query %q", q.String)
+						}
+						return nil
+					},
+				},
+				kube: remoteCreds("app", "s3cr3t"),
+			},
+			args: args{
+				mg: mapping(),
+			},
+			want: want{
+				err: nil,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{db: tc.fields.db, kube: tc.fields.kube}
+			got, err := e.Update(tc.args.ctx, tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Update(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.u, got); diff != "" {
+				t.Errorf("\n%s\ne.Update(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestDelete(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type fields struct {
+		db xsql.DB
+	}
+
+	type args struct {
+		ctx context.Context
+		mg  resource.Managed
+	}
+
+	cases := map[string]struct {
+		reason string
+		fields fields
+		args   args
+		want   error
+	}{
+		"ErrNotUserMapping": {
+			reason: "An error should be returned if the managed resource is not a UserMapping",
+			args: args{
+				mg: nil,
+			},
+			want: errors.New(errNotUserMapping),
+		},
+		"ErrDropUserMapping": {
+			reason: "Errors dropping a user mapping should be returned",
+			fields: fields{
+				db: &mockDB{
+					MockExec: func(ctx context.Context, q xsql.Query) error {
+						return errBoom
+					},
+				},
+			},
+			args: args{
+				mg: mapping(),
+			},
+			want: errors.Wrap(errBoom, errDropUserMapping),
+		},
+		"Success": {
+			reason: "No error should be returned if the user mapping was dropped",
+			fields: fields{
+				db: &mockDB{
+					MockExec: func(ctx context.Context, q xsql.Query) error {
+						want := `DROP USER MAPPING IF EXISTS FOR "local" SERVER "remote"`
+						if q.String != want {
+							return errors.Errorf("unexpected query %q", q.String)
+						}
+						return nil
+					},
+				},
+			},
+			args: args{
+				mg: mapping(),
+			},
+			want: nil,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{db: tc.fields.db}
+			err := e.Delete(tc.args.ctx, tc.args.mg)
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Delete(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}